@@ -6,14 +6,21 @@ package admin
 import (
 	"sort"
 
+	"github.com/ava-labs/gecko/nat"
 	"github.com/ava-labs/gecko/utils"
 )
 
 // Peerable can return a group of peers
 type Peerable interface{ IPs() []utils.IPDesc }
 
+// NATStatuser can return the status of the NAT traversal of the node
+type NATStatuser interface{ NATStatus() nat.Status }
+
 // Networking provides helper methods for tracking the current network state
-type Networking struct{ peers Peerable }
+type Networking struct {
+	peers Peerable
+	nat   NATStatuser
+}
 
 // Peers returns the current peers
 func (n *Networking) Peers() ([]string, error) {
@@ -4,7 +4,11 @@
 package admin
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -17,11 +21,14 @@ import (
 	cjson "github.com/ava-labs/gecko/utils/json"
 )
 
+var errNoLevel = errors.New("at least one of logLevel and displayLevel must be provided")
+
 // Admin is the API service for node admin management
 type Admin struct {
 	nodeID       ids.ShortID
 	networkID    uint32
 	log          logging.Logger
+	logFactory   logging.Factory
 	networking   Networking
 	performance  Performance
 	chainManager chains.Manager
@@ -29,7 +36,7 @@ type Admin struct {
 }
 
 // NewService returns a new admin API service
-func NewService(nodeID ids.ShortID, networkID uint32, log logging.Logger, chainManager chains.Manager, peers Peerable, httpServer *api.Server) *common.HTTPHandler {
+func NewService(nodeID ids.ShortID, networkID uint32, log logging.Logger, logFactory logging.Factory, chainManager chains.Manager, peers Peerable, natStatus NATStatuser, httpServer *api.Server) *common.HTTPHandler {
 	newServer := rpc.NewServer()
 	codec := cjson.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
@@ -38,9 +45,11 @@ func NewService(nodeID ids.ShortID, networkID uint32, log logging.Logger, chainM
 		nodeID:       nodeID,
 		networkID:    networkID,
 		log:          log,
+		logFactory:   logFactory,
 		chainManager: chainManager,
 		networking: Networking{
 			peers: peers,
+			nat:   natStatus,
 		},
 		httpServer: httpServer,
 	}, "admin")
@@ -226,3 +235,233 @@ func (service *Admin) AliasChain(_ *http.Request, args *AliasChainArgs, reply *A
 	reply.Success = true
 	return service.httpServer.AddAliasesWithReadLock("bc/"+chainID.String(), "bc/"+args.Alias)
 }
+
+// GetChainDatabaseSizeArgs are the arguments for calling GetChainDatabaseSize
+type GetChainDatabaseSizeArgs struct {
+	Chain string `json:"chain"`
+
+	// If true, the chain's keys are counted. This iterates over all of them.
+	CountKeys bool `json:"countKeys"`
+}
+
+// GetChainDatabaseSizeReply are the results from calling GetChainDatabaseSize
+type GetChainDatabaseSizeReply struct {
+	Keys  *cjson.Uint64 `json:"keys,omitempty"`
+	Bytes cjson.Uint64  `json:"bytes"`
+}
+
+// GetChainDatabaseSize returns approximately how many bytes a chain takes up on
+// disk in this node's database and, if requested, how many keys it has
+func (service *Admin) GetChainDatabaseSize(_ *http.Request, args *GetChainDatabaseSizeArgs, reply *GetChainDatabaseSizeReply) error {
+	service.log.Debug("Admin: GetChainDatabaseSize called with Chain: %s", args.Chain)
+
+	chainID, err := service.lookupChain(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	size, err := service.chainManager.DatabaseSize(chainID)
+	if err != nil {
+		return err
+	}
+	reply.Bytes = cjson.Uint64(size)
+
+	if args.CountKeys {
+		keys, err := service.chainManager.DatabaseKeys(chainID)
+		if err != nil {
+			return err
+		}
+		jsonKeys := cjson.Uint64(keys)
+		reply.Keys = &jsonKeys
+	}
+	return nil
+}
+
+// DeleteChainDatabaseArgs are the arguments for calling DeleteChainDatabase
+type DeleteChainDatabaseArgs struct {
+	Chain string `json:"chain"`
+}
+
+// DeleteChainDatabaseReply are the results from calling DeleteChainDatabase
+type DeleteChainDatabaseReply struct {
+	Success bool `json:"success"`
+}
+
+// DeleteChainDatabase deletes the database of a chain that isn't running on
+// this node
+func (service *Admin) DeleteChainDatabase(_ *http.Request, args *DeleteChainDatabaseArgs, reply *DeleteChainDatabaseReply) error {
+	service.log.Info("Admin: DeleteChainDatabase called with Chain: %s", args.Chain)
+
+	chainID, err := service.lookupChain(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	if err := service.chainManager.DeleteDatabase(chainID); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// lookupChain returns the ID of the chain with alias [chain]. Chains that
+// aren't running on this node have no aliases, so [chain] may also be the ID
+// of the chain.
+func (service *Admin) lookupChain(chain string) (ids.ID, error) {
+	if chainID, err := service.chainManager.Lookup(chain); err == nil {
+		return chainID, nil
+	}
+	return ids.FromString(chain)
+}
+
+// LoggerLevels describes a logger of the node
+type LoggerLevels struct {
+	ChainID      string `json:"chainID,omitempty"`
+	Subsystem    string `json:"subsystem,omitempty"`
+	LogLevel     string `json:"logLevel"`
+	DisplayLevel string `json:"displayLevel"`
+}
+
+// GetLoggerLevelsArgs are the arguments for calling GetLoggerLevels
+type GetLoggerLevelsArgs struct{}
+
+// GetLoggerLevelsReply are the results from calling GetLoggerLevels
+type GetLoggerLevelsReply struct {
+	Loggers []LoggerLevels `json:"loggers"`
+}
+
+// GetLoggerLevels returns the loggers of the node along with their levels
+func (service *Admin) GetLoggerLevels(r *http.Request, args *GetLoggerLevelsArgs, reply *GetLoggerLevelsReply) error {
+	service.log.Debug("Admin: GetLoggerLevels called")
+
+	for _, info := range service.logFactory.Loggers() {
+		reply.Loggers = append(reply.Loggers, LoggerLevels{
+			ChainID:      info.ChainID,
+			Subsystem:    info.Subsystem,
+			LogLevel:     strings.TrimSpace(info.LogLevel.String()),
+			DisplayLevel: strings.TrimSpace(info.DisplayLevel.String()),
+		})
+	}
+	return nil
+}
+
+// SetLoggerLevelArgs are the arguments for calling SetLoggerLevel
+type SetLoggerLevelArgs struct {
+	// Chain is the ID or an alias of the chain whose loggers are changed. If
+	// empty, the loggers of every chain, and those not made for a chain, are
+	// changed.
+	Chain string `json:"chain"`
+
+	// Subsystem is the subsystem whose loggers are changed, such as http. If
+	// empty, the loggers of every subsystem are changed.
+	Subsystem string `json:"subsystem"`
+
+	// LogLevel and DisplayLevel are the new levels. A level that is empty is
+	// left unchanged.
+	LogLevel     string `json:"logLevel"`
+	DisplayLevel string `json:"displayLevel"`
+}
+
+// SetLoggerLevelReply are the results from calling SetLoggerLevel
+type SetLoggerLevelReply struct {
+	// Updated is the number of loggers that were changed
+	Updated cjson.Uint32 `json:"updated"`
+}
+
+// SetLoggerLevel changes the log and display levels of the loggers of a chain
+// or subsystem
+func (service *Admin) SetLoggerLevel(r *http.Request, args *SetLoggerLevelArgs, reply *SetLoggerLevelReply) error {
+	service.log.Debug("Admin: SetLoggerLevel called with chain: %q, subsystem: %q, log level: %q, display level: %q", args.Chain, args.Subsystem, args.LogLevel, args.DisplayLevel)
+
+	if args.LogLevel == "" && args.DisplayLevel == "" {
+		return errNoLevel
+	}
+
+	chainID := ""
+	if args.Chain != "" {
+		id, err := service.lookupChain(args.Chain)
+		if err != nil {
+			return fmt.Errorf("couldn't find chain %q: %w", args.Chain, err)
+		}
+		chainID = id.String()
+	}
+
+	// Parse both levels before changing either, so that a bad level doesn't
+	// leave the loggers half updated
+	var logLevel, displayLevel *logging.Level
+	if args.LogLevel != "" {
+		level, err := logging.ToLevel(args.LogLevel)
+		if err != nil {
+			return err
+		}
+		logLevel = &level
+	}
+	if args.DisplayLevel != "" {
+		level, err := logging.ToLevel(args.DisplayLevel)
+		if err != nil {
+			return err
+		}
+		displayLevel = &level
+	}
+
+	updated := service.logFactory.SetLevels(chainID, args.Subsystem, logLevel, displayLevel)
+	reply.Updated = cjson.Uint32(updated)
+	return nil
+}
+
+// NATMapping is the status of a port mapped on the NAT router
+type NATMapping struct {
+	InternalPort cjson.Uint16 `json:"internalPort"`
+	ExternalPort cjson.Uint16 `json:"externalPort"`
+	Mapped       bool         `json:"mapped"`
+	LastUpdated  string       `json:"lastUpdated,omitempty"`
+	Error        string       `json:"error,omitempty"`
+}
+
+// GetNATStatusArgs are the arguments for calling GetNATStatus
+type GetNATStatusArgs struct{}
+
+// GetNATStatusReply are the results from calling GetNATStatus
+type GetNATStatusReply struct {
+	Method       string       `json:"method"`
+	PublicIP     string       `json:"publicIP"`
+	LastResolved string       `json:"lastResolved,omitempty"`
+	Error        string       `json:"error,omitempty"`
+	Mappings     []NATMapping `json:"mappings"`
+}
+
+// GetNATStatus returns the public IP of this node, when it was last resolved,
+// and the status of the ports mapped on the NAT router
+func (service *Admin) GetNATStatus(r *http.Request, args *GetNATStatusArgs, reply *GetNATStatusReply) error {
+	service.log.Debug("Admin: GetNATStatus called")
+
+	status := service.networking.nat.NATStatus()
+	reply.Method = status.Method
+	reply.PublicIP = status.IP.String()
+	reply.LastResolved = formatTime(status.LastResolved)
+	if status.Err != nil {
+		reply.Error = status.Err.Error()
+	}
+	reply.Mappings = make([]NATMapping, len(status.Mappings))
+	for i, mapping := range status.Mappings {
+		reply.Mappings[i] = NATMapping{
+			InternalPort: cjson.Uint16(mapping.InternalPort),
+			ExternalPort: cjson.Uint16(mapping.ExternalPort),
+			Mapped:       mapping.Mapped,
+			LastUpdated:  formatTime(mapping.LastUpdated),
+		}
+		if mapping.Err != nil {
+			reply.Mappings[i].Error = mapping.Err.Error()
+		}
+	}
+	return nil
+}
+
+// formatTime returns [t] in RFC 3339 format, or the empty string if [t] is the
+// zero time
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,163 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ava-labs/gecko/chains"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/logging"
+)
+
+var errUnknownAlias = errors.New("unknown alias")
+
+// testChainManager is a chain manager that knows the aliases in [aliases] and
+// the database sizes in [sizes]
+type testChainManager struct {
+	chains.MockManager
+
+	aliases map[string]ids.ID
+	sizes   map[[32]byte]uint64
+	keys    map[[32]byte]uint64
+	deleted ids.Set
+}
+
+func (m *testChainManager) Lookup(alias string) (ids.ID, error) {
+	if chainID, ok := m.aliases[alias]; ok {
+		return chainID, nil
+	}
+	return ids.ID{}, errUnknownAlias
+}
+
+func (m *testChainManager) DatabaseSize(chainID ids.ID) (uint64, error) {
+	return m.sizes[chainID.Key()], nil
+}
+
+func (m *testChainManager) DatabaseKeys(chainID ids.ID) (uint64, error) {
+	return m.keys[chainID.Key()], nil
+}
+
+func (m *testChainManager) DeleteDatabase(chainID ids.ID) error {
+	m.deleted.Add(chainID)
+	return nil
+}
+
+func TestGetChainDatabaseSize(t *testing.T) {
+	chainID := ids.NewID([32]byte{1})
+	service := &Admin{
+		log: logging.NoLog{},
+		chainManager: &testChainManager{
+			aliases: map[string]ids.ID{"X": chainID},
+			sizes:   map[[32]byte]uint64{chainID.Key(): 100},
+			keys:    map[[32]byte]uint64{chainID.Key(): 3},
+		},
+	}
+
+	// Chains are referenced by alias or, if they aren't running, by ID
+	for _, chain := range []string{"X", chainID.String()} {
+		reply := GetChainDatabaseSizeReply{}
+		if err := service.GetChainDatabaseSize(nil, &GetChainDatabaseSizeArgs{Chain: chain}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if reply.Bytes != 100 {
+			t.Fatalf("Chain %s takes up %d bytes ; Expected: %d", chain, reply.Bytes, 100)
+		}
+		if reply.Keys != nil {
+			t.Fatalf("Keys shouldn't be counted unless requested")
+		}
+	}
+
+	reply := GetChainDatabaseSizeReply{}
+	if err := service.GetChainDatabaseSize(nil, &GetChainDatabaseSizeArgs{Chain: "X", CountKeys: true}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Keys == nil || *reply.Keys != 3 {
+		t.Fatalf("Chain should have %d keys, reply has %v", 3, reply.Keys)
+	}
+
+	if err := service.GetChainDatabaseSize(nil, &GetChainDatabaseSizeArgs{Chain: "Y"}, &GetChainDatabaseSizeReply{}); err == nil {
+		t.Fatalf("Should have errored due to an unknown chain")
+	}
+}
+
+func TestDeleteChainDatabase(t *testing.T) {
+	chainID := ids.NewID([32]byte{1})
+	chainManager := &testChainManager{}
+	service := &Admin{
+		log:          logging.NoLog{},
+		chainManager: chainManager,
+	}
+
+	reply := DeleteChainDatabaseReply{}
+	if err := service.DeleteChainDatabase(nil, &DeleteChainDatabaseArgs{Chain: chainID.String()}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Success || !chainManager.deleted.Contains(chainID) {
+		t.Fatalf("The chain's database should have been deleted")
+	}
+}
+
+func TestSetLoggerLevel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config, err := logging.DefaultConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Directory = dir
+	config.LogLevel = logging.Info
+	config.DisplayLevel = logging.Info
+	config.DisableDisplaying = true
+
+	logFactory := logging.NewFactory(config)
+	defer logFactory.Close()
+
+	chainID := ids.NewID([32]byte{1})
+	if _, err := logFactory.Make(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := logFactory.MakeChain(chainID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := logFactory.MakeChain(chainID, "http"); err != nil {
+		t.Fatal(err)
+	}
+
+	service := &Admin{
+		log:          logging.NoLog{},
+		logFactory:   logFactory,
+		chainManager: &testChainManager{},
+	}
+
+	// Chains that aren't aliased are referenced by ID
+	reply := SetLoggerLevelReply{}
+	if err := service.SetLoggerLevel(nil, &SetLoggerLevelArgs{
+		Chain:        chainID.String(),
+		LogLevel:     "verbo",
+		DisplayLevel: "debug",
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Updated != 2 {
+		t.Fatalf("Should have updated the 2 loggers of the chain, updated %d", reply.Updated)
+	}
+
+	for _, info := range logFactory.Loggers() {
+		expectedLogLevel, expectedDisplayLevel := logging.Info, logging.Info
+		if info.ChainID == chainID.String() {
+			expectedLogLevel, expectedDisplayLevel = logging.Verbo, logging.Debug
+		}
+		if info.LogLevel != expectedLogLevel || info.DisplayLevel != expectedDisplayLevel {
+			t.Fatalf("Wrong levels for logger %+v", info)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/utils/timer"
+	"github.com/ava-labs/gecko/vms/components/codec"
+)
+
+const (
+	// Endpoint is the base of the route of the auth API. Calls to it are
+	// authorized by password rather than by token.
+	Endpoint = "auth"
+
+	// AllEndpoints is the scope that authorizes calls to every endpoint
+	AllEndpoints = "*"
+
+	// headerKey is the header a token is provided in, as "Bearer <token>"
+	headerKey    = "Authorization"
+	headerPrefix = "Bearer "
+
+	// tokenLen is the number of random bytes in a token
+	tokenLen = 32
+
+	// defaultTokenLifespan is how long a token is valid for if no duration
+	// is requested
+	defaultTokenLifespan = 12 * time.Hour
+
+	// maxTokenLifespan is the longest a token can be valid for
+	maxTokenLifespan = 365 * 24 * time.Hour
+)
+
+var (
+	errNoToken          = errors.New("auth token not provided")
+	errInvalidToken     = errors.New("auth token is invalid")
+	errTokenExpired     = errors.New("auth token is expired")
+	errTokenRevoked     = errors.New("auth token was revoked")
+	errTokenOutOfScope  = errors.New("auth token doesn't authorize calls to this endpoint")
+	errWrongPassword    = errors.New("incorrect password")
+	errNoEndpoints      = errors.New("a token must be scoped to at least one endpoint")
+	errInvalidEndpoint  = errors.New("endpoints must start with /ext/ or be *")
+	errInvalidLifespan  = fmt.Errorf("token duration must be positive and at most %s", maxTokenLifespan)
+	errPasswordRequired = errors.New("a password is required to enable API authorization")
+)
+
+// token is the persisted state of an issued token. Tokens are stored keyed by
+// their hash, so the tokens themselves never touch the disk.
+type token struct {
+	// Endpoints are the URL path prefixes the token authorizes calls to
+	Endpoints []string `serialize:"true"`
+
+	// Expiry is the unix time after which the token is no longer valid
+	Expiry uint64 `serialize:"true"`
+
+	// Revoked is true if the token was revoked before it expired
+	Revoked bool `serialize:"true"`
+}
+
+// authorizes returns true if [path] is under one of the endpoints of [t]
+func (t *token) authorizes(path string) bool {
+	for _, endpoint := range t.Endpoints {
+		switch {
+		case endpoint == AllEndpoints:
+			return true
+		case path == endpoint:
+			return true
+		case strings.HasPrefix(path, strings.TrimSuffix(endpoint, "/")+"/"):
+			return true
+		}
+	}
+	return false
+}
+
+// Auth issues the tokens that authorize calls to the API, and checks the
+// tokens provided with calls.
+//
+// Tokens are scoped to endpoint prefixes, so that a token for /ext/bc/X can't
+// be used to call /ext/admin. They are issued and revoked through the auth
+// API, which is protected by the password the node was started with.
+type Auth struct {
+	lock  sync.Mutex
+	log   logging.Logger
+	clock timer.Clock
+	codec codec.Codec
+
+	// passwordHash is the hash of the password of the auth API
+	passwordHash []byte
+
+	// Persists the issued tokens, keyed by their hash
+	db database.Database
+
+	// Cache of the tokens read from [db]
+	tokens map[[32]byte]*token
+}
+
+// Initialize the auth service, with the auth API protected by [password].
+// Tokens are persisted in [db]. Tokens in [db] that have expired are removed.
+func (a *Auth) Initialize(log logging.Logger, db database.Database, password string) error {
+	if password == "" {
+		return errPasswordRequired
+	}
+
+	a.log = log
+	a.codec = codec.NewDefault()
+	a.passwordHash = hashing.ComputeHash256([]byte(password))
+	a.db = db
+	a.tokens = make(map[[32]byte]*token)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.pruneExpired()
+}
+
+// Authorize returns an error if [r] doesn't carry a valid token that
+// authorizes calls to the endpoint it's addressed to
+func (a *Auth) Authorize(r *http.Request) error {
+	header := r.Header.Get(headerKey)
+	if !strings.HasPrefix(header, headerPrefix) {
+		return errNoToken
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	t, err := a.getToken(strings.TrimPrefix(header, headerPrefix))
+	switch {
+	case err != nil:
+		return err
+	case t.Revoked:
+		return errTokenRevoked
+	case a.clock.Unix() > t.Expiry:
+		return errTokenExpired
+	case !t.authorizes(r.URL.Path):
+		return errTokenOutOfScope
+	}
+	return nil
+}
+
+// checkPassword returns an error if [password] isn't the password of the auth
+// API
+func (a *Auth) checkPassword(password string) error {
+	if subtle.ConstantTimeCompare(hashing.ComputeHash256([]byte(password)), a.passwordHash) != 1 {
+		return errWrongPassword
+	}
+	return nil
+}
+
+// newToken issues a token that authorizes calls to [endpoints] for [lifespan]
+//
+// Assumes the lock is held
+func (a *Auth) newToken(endpoints []string, lifespan time.Duration) (string, time.Time, error) {
+	if len(endpoints) == 0 {
+		return "", time.Time{}, errNoEndpoints
+	}
+	for _, endpoint := range endpoints {
+		if endpoint != AllEndpoints && !strings.HasPrefix(endpoint, "/ext/") {
+			return "", time.Time{}, fmt.Errorf("%w: %q", errInvalidEndpoint, endpoint)
+		}
+	}
+	if lifespan <= 0 || lifespan > maxTokenLifespan {
+		return "", time.Time{}, errInvalidLifespan
+	}
+
+	tokenBytes := make([]byte, tokenLen)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", time.Time{}, fmt.Errorf("couldn't generate token: %w", err)
+	}
+
+	// Tokens are only pruned when new ones are issued, so that the database
+	// doesn't grow with every token issued over the life of the node
+	if err := a.pruneExpired(); err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiry := a.clock.Time().Add(lifespan)
+	t := &token{
+		Endpoints: endpoints,
+		Expiry:    uint64(expiry.Unix()),
+	}
+	key := hashing.ComputeHash256Array(tokenBytes)
+	if err := a.putToken(key, t); err != nil {
+		return "", time.Time{}, err
+	}
+	return formatting.CB58{Bytes: tokenBytes}.String(), expiry, nil
+}
+
+// revokeToken marks [tokenStr] as revoked
+//
+// Assumes the lock is held
+func (a *Auth) revokeToken(tokenStr string) error {
+	t, err := a.getToken(tokenStr)
+	if err != nil {
+		return err
+	}
+	key, _ := tokenKey(tokenStr)
+	t.Revoked = true
+	return a.putToken(key, t)
+}
+
+// getToken returns the state of [tokenStr]
+//
+// Assumes the lock is held
+func (a *Auth) getToken(tokenStr string) (*token, error) {
+	key, err := tokenKey(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if t, exists := a.tokens[key]; exists {
+		return t, nil
+	}
+
+	tokenBytes, err := a.db.Get(key[:])
+	if err == database.ErrNotFound {
+		return nil, errInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+
+	t := &token{}
+	if err := a.codec.Unmarshal(tokenBytes, t); err != nil {
+		return nil, err
+	}
+	a.tokens[key] = t
+	return t, nil
+}
+
+// putToken persists [t] under [key]
+//
+// Assumes the lock is held
+func (a *Auth) putToken(key [32]byte, t *token) error {
+	tokenBytes, err := a.codec.Marshal(t)
+	if err != nil {
+		return err
+	}
+	if err := a.db.Put(key[:], tokenBytes); err != nil {
+		return err
+	}
+	a.tokens[key] = t
+	return nil
+}
+
+// pruneExpired removes the tokens that have expired from the database, along
+// with those that were revoked before expiring. Calls carrying a removed token
+// are rejected as carrying an invalid token.
+//
+// Assumes the lock is held
+func (a *Auth) pruneExpired() error {
+	now := a.clock.Unix()
+	expired := [][]byte(nil)
+
+	iter := a.db.NewIterator()
+	for iter.Next() {
+		t := &token{}
+		if err := a.codec.Unmarshal(iter.Value(), t); err != nil {
+			iter.Release()
+			return err
+		}
+		if now > t.Expiry {
+			expired = append(expired, append([]byte(nil), iter.Key()...))
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range expired {
+		if err := a.db.Delete(key); err != nil {
+			return err
+		}
+		cacheKey := [32]byte{}
+		copy(cacheKey[:], key)
+		delete(a.tokens, cacheKey)
+	}
+	if len(expired) > 0 {
+		a.log.Debug("Pruned %d expired auth tokens", len(expired))
+	}
+	return nil
+}
+
+// tokenKey returns the key [tokenStr] is stored under
+func tokenKey(tokenStr string) ([32]byte, error) {
+	cb58 := formatting.CB58{}
+	if err := cb58.FromString(tokenStr); err != nil || len(cb58.Bytes) != tokenLen {
+		return [32]byte{}, errInvalidToken
+	}
+	return hashing.ComputeHash256Array(cb58.Bytes), nil
+}
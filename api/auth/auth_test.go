@@ -0,0 +1,172 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/utils/logging"
+)
+
+const testPassword = "password"
+
+func authorize(a *Auth, path, token string) error {
+	r := httptest.NewRequest("POST", path, nil)
+	if token != "" {
+		r.Header.Set(headerKey, headerPrefix+token)
+	}
+	return a.Authorize(r)
+}
+
+func TestAuthInitialize(t *testing.T) {
+	a := Auth{}
+	if err := a.Initialize(logging.NoLog{}, memdb.New(), ""); err != errPasswordRequired {
+		t.Fatalf("Should have errored with %s, got %v", errPasswordRequired, err)
+	}
+}
+
+func TestAuthNewToken(t *testing.T) {
+	a := Auth{}
+	if err := a.Initialize(logging.NoLog{}, memdb.New(), testPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.NewToken(nil, &NewTokenArgs{Password: "wrong", Endpoints: []string{AllEndpoints}}, &NewTokenReply{}); err != errWrongPassword {
+		t.Fatalf("Should have errored with %s, got %v", errWrongPassword, err)
+	}
+	if err := a.NewToken(nil, &NewTokenArgs{Password: testPassword}, &NewTokenReply{}); err != errNoEndpoints {
+		t.Fatalf("Should have errored with %s, got %v", errNoEndpoints, err)
+	}
+	if err := a.NewToken(nil, &NewTokenArgs{Password: testPassword, Endpoints: []string{"admin"}}, &NewTokenReply{}); !errors.Is(err, errInvalidEndpoint) {
+		t.Fatalf("Should have errored with %s, got %v", errInvalidEndpoint, err)
+	}
+	if err := a.NewToken(nil, &NewTokenArgs{Password: testPassword, Endpoints: []string{AllEndpoints}, Duration: "-1h"}, &NewTokenReply{}); err != errInvalidLifespan {
+		t.Fatalf("Should have errored with %s, got %v", errInvalidLifespan, err)
+	}
+
+	reply := NewTokenReply{}
+	if err := a.NewToken(nil, &NewTokenArgs{Password: testPassword, Endpoints: []string{"/ext/bc/X"}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := authorize(&a, "/ext/bc/X", ""); err != errNoToken {
+		t.Fatalf("Should have errored with %s, got %v", errNoToken, err)
+	}
+	if err := authorize(&a, "/ext/bc/X", "invalid"); err != errInvalidToken {
+		t.Fatalf("Should have errored with %s, got %v", errInvalidToken, err)
+	}
+	if err := authorize(&a, "/ext/bc/X", reply.Token); err != nil {
+		t.Fatal(err)
+	}
+	if err := authorize(&a, "/ext/bc/X/events", reply.Token); err != nil {
+		t.Fatal(err)
+	}
+	if err := authorize(&a, "/ext/bc/XY", reply.Token); err != errTokenOutOfScope {
+		t.Fatalf("Should have errored with %s, got %v", errTokenOutOfScope, err)
+	}
+	if err := authorize(&a, "/ext/admin", reply.Token); err != errTokenOutOfScope {
+		t.Fatalf("Should have errored with %s, got %v", errTokenOutOfScope, err)
+	}
+
+	a.clock.Set(time.Unix(int64(reply.Expiry)+1, 0))
+	if err := authorize(&a, "/ext/bc/X", reply.Token); err != errTokenExpired {
+		t.Fatalf("Should have errored with %s, got %v", errTokenExpired, err)
+	}
+}
+
+func TestAuthRevokeTokenPersists(t *testing.T) {
+	db := memdb.New()
+	a := Auth{}
+	if err := a.Initialize(logging.NoLog{}, db, testPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	revoked := NewTokenReply{}
+	if err := a.NewToken(nil, &NewTokenArgs{Password: testPassword, Endpoints: []string{AllEndpoints}}, &revoked); err != nil {
+		t.Fatal(err)
+	}
+	kept := NewTokenReply{}
+	if err := a.NewToken(nil, &NewTokenArgs{Password: testPassword, Endpoints: []string{AllEndpoints}, Duration: "1h"}, &kept); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.RevokeToken(nil, &RevokeTokenArgs{Password: "wrong", Token: revoked.Token}, &RevokeTokenReply{}); err != errWrongPassword {
+		t.Fatalf("Should have errored with %s, got %v", errWrongPassword, err)
+	}
+	if err := a.RevokeToken(nil, &RevokeTokenArgs{Password: testPassword, Token: revoked.Token}, &RevokeTokenReply{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The tokens should be read back from the database after a restart
+	restarted := Auth{}
+	if err := restarted.Initialize(logging.NoLog{}, db, testPassword); err != nil {
+		t.Fatal(err)
+	}
+	if err := authorize(&restarted, "/ext/admin", revoked.Token); err != errTokenRevoked {
+		t.Fatalf("Should have errored with %s, got %v", errTokenRevoked, err)
+	}
+	if err := authorize(&restarted, "/ext/admin", kept.Token); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuthPrunesExpiredTokens(t *testing.T) {
+	db := memdb.New()
+	a := Auth{}
+	if err := a.Initialize(logging.NoLog{}, db, testPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	short := NewTokenReply{}
+	if err := a.NewToken(nil, &NewTokenArgs{Password: testPassword, Endpoints: []string{AllEndpoints}, Duration: "1h"}, &short); err != nil {
+		t.Fatal(err)
+	}
+	long := NewTokenReply{}
+	if err := a.NewToken(nil, &NewTokenArgs{Password: testPassword, Endpoints: []string{AllEndpoints}, Duration: "3h"}, &long); err != nil {
+		t.Fatal(err)
+	}
+
+	// Issuing a token prunes the tokens that have expired
+	a.clock.Set(time.Unix(int64(short.Expiry)+1, 0))
+	if err := a.NewToken(nil, &NewTokenArgs{Password: testPassword, Endpoints: []string{AllEndpoints}}, &NewTokenReply{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := authorize(&a, "/ext/admin", short.Token); err != errInvalidToken {
+		t.Fatalf("Should have errored with %s, got %v", errInvalidToken, err)
+	}
+	if err := authorize(&a, "/ext/admin", long.Token); err != nil {
+		t.Fatal(err)
+	}
+	if count := countTokens(t, &a); count != 2 {
+		t.Fatalf("Expected 2 tokens in the database, found %d", count)
+	}
+
+	// Initializing prunes the tokens that expired while the node was down
+	restarted := Auth{}
+	restarted.clock.Set(time.Unix(int64(long.Expiry)+1, 0))
+	if err := restarted.Initialize(logging.NoLog{}, db, testPassword); err != nil {
+		t.Fatal(err)
+	}
+	if count := countTokens(t, &restarted); count != 1 {
+		t.Fatalf("Expected 1 token in the database, found %d", count)
+	}
+}
+
+func countTokens(t *testing.T, a *Auth) int {
+	iter := a.db.NewIterator()
+	defer iter.Release()
+
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
@@ -0,0 +1,103 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/ava-labs/gecko/snow/engine/common"
+
+	cjson "github.com/ava-labs/gecko/utils/json"
+)
+
+// CreateHandler returns the handler of the auth API
+func (a *Auth) CreateHandler() *common.HTTPHandler {
+	newServer := rpc.NewServer()
+	codec := cjson.NewCodec()
+	newServer.RegisterCodec(codec, "application/json")
+	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	newServer.RegisterService(a, "auth")
+	return &common.HTTPHandler{LockOptions: common.NoLock, Handler: newServer}
+}
+
+// NewTokenArgs are the arguments for calling NewToken
+type NewTokenArgs struct {
+	Password string `json:"password"`
+
+	// Endpoints the token authorizes calls to, such as /ext/bc/X, or * for
+	// every endpoint
+	Endpoints []string `json:"endpoints"`
+
+	// Duration the token is valid for, such as 12h. Defaults to 12 hours.
+	Duration string `json:"duration"`
+}
+
+// NewTokenReply is the response from calling NewToken
+type NewTokenReply struct {
+	Token string `json:"token"`
+
+	// Expiry is the unix time after which the token is no longer valid
+	Expiry cjson.Uint64 `json:"expiry"`
+}
+
+// NewToken issues a token that authorizes calls to the requested endpoints
+func (a *Auth) NewToken(_ *http.Request, args *NewTokenArgs, reply *NewTokenReply) error {
+	a.log.Debug("Auth: NewToken called with endpoints %v", args.Endpoints)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if err := a.checkPassword(args.Password); err != nil {
+		return err
+	}
+
+	lifespan := defaultTokenLifespan
+	if args.Duration != "" {
+		duration, err := time.ParseDuration(args.Duration)
+		if err != nil {
+			return fmt.Errorf("couldn't parse duration: %w", err)
+		}
+		lifespan = duration
+	}
+
+	token, expiry, err := a.newToken(args.Endpoints, lifespan)
+	if err != nil {
+		return err
+	}
+	reply.Token = token
+	reply.Expiry = cjson.Uint64(expiry.Unix())
+	return nil
+}
+
+// RevokeTokenArgs are the arguments for calling RevokeToken
+type RevokeTokenArgs struct {
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+// RevokeTokenReply is the response from calling RevokeToken
+type RevokeTokenReply struct {
+	Success bool `json:"success"`
+}
+
+// RevokeToken revokes a token before it expires
+func (a *Auth) RevokeToken(_ *http.Request, args *RevokeTokenArgs, reply *RevokeTokenReply) error {
+	a.log.Debug("Auth: RevokeToken called")
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if err := a.checkPassword(args.Password); err != nil {
+		return err
+	}
+	if err := a.revokeToken(args.Token); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
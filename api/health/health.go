@@ -0,0 +1,232 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/utils/timer"
+
+	cjson "github.com/ava-labs/gecko/utils/json"
+)
+
+var (
+	errEmptyCheckName    = errors.New("health check name can't be the empty string")
+	errCheckNotYetRun    = errors.New("health check hasn't been run yet")
+	errAlreadyStarted    = errors.New("health checks have already been started")
+	errCheckPanicked     = errors.New("health check panicked")
+	errDuplicateCheck    = errors.New("a health check with that name is already registered")
+	errNotYetInitialized = errors.New("health checks haven't been initialized")
+)
+
+// Checker returns details about the state of a subsystem, or an error if the
+// subsystem is unhealthy
+type Checker func() (interface{}, error)
+
+type check struct {
+	name    string
+	checker Checker
+
+	// liveness is true if the node can't recover without being restarted when
+	// this check fails
+	liveness bool
+}
+
+// Result is the outcome of the most recent execution of a health check
+type Result struct {
+	// Details returned by the check
+	Details interface{} `json:"details,omitempty"`
+
+	// Error returned by the check, or the empty string if the check passed
+	Error string `json:"error,omitempty"`
+
+	// Liveness is true if the check must pass for the node to be alive
+	Liveness bool `json:"liveness"`
+
+	// Timestamp is when the check was last run
+	Timestamp time.Time `json:"timestamp"`
+
+	// Duration is how long the check took to run
+	Duration time.Duration `json:"duration"`
+
+	// ContiguousFailures is the number of times in a row the check has failed
+	ContiguousFailures cjson.Uint64 `json:"contiguousFailures,omitempty"`
+
+	// TimeOfFirstFailure is when the check started failing, if it is failing
+	TimeOfFirstFailure *time.Time `json:"timeOfFirstFailure,omitempty"`
+}
+
+// Health periodically runs the health checks registered by the subsystems of
+// the node and reports their most recent results.
+//
+// The node is live if every liveness check passes, and ready to handle traffic
+// if every check passes.
+type Health struct {
+	log       logging.Logger
+	clock     timer.Clock
+	frequency time.Duration
+	repeater  *timer.Repeater
+
+	lock    sync.RWMutex
+	started bool
+	checks  []*check
+	results map[string]Result
+}
+
+// Initialize the health checks to be run every [frequency] once started
+func (h *Health) Initialize(log logging.Logger, frequency time.Duration) {
+	h.log = log
+	h.frequency = frequency
+	h.results = make(map[string]Result)
+}
+
+// RegisterLivenessCheck adds a check that must pass for the node to be
+// considered alive. Liveness checks must also pass for the node to be ready.
+func (h *Health) RegisterLivenessCheck(name string, checker Checker) error {
+	return h.register(&check{name: name, checker: checker, liveness: true})
+}
+
+// RegisterReadinessCheck adds a check that must pass for the node to be ready
+// to handle traffic
+func (h *Health) RegisterReadinessCheck(name string, checker Checker) error {
+	return h.register(&check{name: name, checker: checker})
+}
+
+func (h *Health) register(c *check) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	switch {
+	case h.results == nil:
+		return errNotYetInitialized
+	case c.name == "":
+		return errEmptyCheckName
+	}
+	if _, exists := h.results[c.name]; exists {
+		return fmt.Errorf("%w: %s", errDuplicateCheck, c.name)
+	}
+
+	h.checks = append(h.checks, c)
+	h.results[c.name] = Result{
+		Error:    errCheckNotYetRun.Error(),
+		Liveness: c.liveness,
+	}
+	return nil
+}
+
+// Start running the checks in the background. The checks are run once before
+// this function returns.
+func (h *Health) Start() error {
+	h.lock.Lock()
+	if h.started {
+		h.lock.Unlock()
+		return errAlreadyStarted
+	}
+	h.started = true
+	h.repeater = timer.NewRepeater(h.RunChecks, h.frequency)
+	h.lock.Unlock()
+
+	h.RunChecks()
+	go h.log.RecoverAndPanic(h.repeater.Dispatch)
+	return nil
+}
+
+// Stop running the checks in the background
+func (h *Health) Stop() {
+	h.lock.RLock()
+	repeater := h.repeater
+	h.lock.RUnlock()
+
+	if repeater != nil {
+		repeater.Stop()
+	}
+}
+
+// RunChecks runs every registered check and records their results
+func (h *Health) RunChecks() {
+	h.lock.RLock()
+	checks := append([]*check(nil), h.checks...)
+	h.lock.RUnlock()
+
+	// The checks are run without holding the lock, as they may block on other
+	// subsystems of the node
+	results := make([]Result, len(checks))
+	for i, c := range checks {
+		results[i] = h.run(c)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for i, c := range checks {
+		result := results[i]
+		prev := h.results[c.name]
+		if result.Error != "" {
+			result.ContiguousFailures = prev.ContiguousFailures + 1
+			result.TimeOfFirstFailure = prev.TimeOfFirstFailure
+			if result.TimeOfFirstFailure == nil {
+				timestamp := result.Timestamp
+				result.TimeOfFirstFailure = &timestamp
+			}
+			if prev.Error == "" || prev.Timestamp.IsZero() {
+				h.log.Warn("health check %s is failing: %s", c.name, result.Error)
+			}
+		} else if prev.Error != "" && !prev.Timestamp.IsZero() {
+			h.log.Info("health check %s is passing after %d failures", c.name, prev.ContiguousFailures)
+		}
+		h.results[c.name] = result
+	}
+}
+
+// run [c] and return its result
+func (h *Health) run(c *check) (result Result) {
+	start := h.clock.Time()
+	result = Result{
+		Liveness:  c.liveness,
+		Timestamp: start,
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result.Details = nil
+			result.Error = fmt.Sprintf("%s: %v", errCheckPanicked, r)
+		}
+		result.Duration = h.clock.Time().Sub(start)
+	}()
+
+	details, err := c.checker()
+	result.Details = details
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Liveness returns the results of the liveness checks, and true iff they all
+// passed
+func (h *Health) Liveness() (map[string]Result, bool) { return h.Results(true) }
+
+// Readiness returns the results of every check, and true iff they all passed
+func (h *Health) Readiness() (map[string]Result, bool) { return h.Results(false) }
+
+// Results returns the most recent results of the checks, and true iff they
+// all passed. If [livenessOnly], only the liveness checks are included.
+func (h *Health) Results(livenessOnly bool) (map[string]Result, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	healthy := true
+	results := make(map[string]Result, len(h.results))
+	for name, result := range h.results {
+		if livenessOnly && !result.Liveness {
+			continue
+		}
+		results[name] = result
+		healthy = healthy && result.Error == ""
+	}
+	return results, healthy
+}
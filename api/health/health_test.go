@@ -0,0 +1,141 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/gecko/utils/logging"
+)
+
+func TestHealthRegister(t *testing.T) {
+	h := Health{}
+	if err := h.RegisterLivenessCheck("db", func() (interface{}, error) { return nil, nil }); err != errNotYetInitialized {
+		t.Fatalf("Should have errored with %s, got %v", errNotYetInitialized, err)
+	}
+
+	h.Initialize(logging.NoLog{}, time.Hour)
+	if err := h.RegisterLivenessCheck("", func() (interface{}, error) { return nil, nil }); err != errEmptyCheckName {
+		t.Fatalf("Should have errored with %s, got %v", errEmptyCheckName, err)
+	}
+	if err := h.RegisterLivenessCheck("db", func() (interface{}, error) { return nil, nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RegisterReadinessCheck("db", func() (interface{}, error) { return nil, nil }); !errors.Is(err, errDuplicateCheck) {
+		t.Fatalf("Should have errored with %s, got %v", errDuplicateCheck, err)
+	}
+
+	// Checks that haven't run yet are failing
+	if _, live := h.Liveness(); live {
+		t.Fatalf("Node shouldn't be live before the checks are run")
+	}
+}
+
+func TestHealthRunChecks(t *testing.T) {
+	h := Health{}
+	h.Initialize(logging.NoLog{}, time.Hour)
+
+	peers := 0
+	errNoPeers := errors.New("not connected to any peers")
+	if err := h.RegisterLivenessCheck("db", func() (interface{}, error) { return "writable", nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RegisterReadinessCheck("peers", func() (interface{}, error) {
+		if peers == 0 {
+			return peers, errNoPeers
+		}
+		return peers, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RegisterReadinessCheck("panics", func() (interface{}, error) { panic("oops") }); err != nil {
+		t.Fatal(err)
+	}
+
+	h.RunChecks()
+	h.RunChecks()
+
+	liveness, live := h.Liveness()
+	if !live || len(liveness) != 1 || liveness["db"].Details != "writable" {
+		t.Fatalf("Node should be live with only the db check reported: %+v", liveness)
+	}
+
+	readiness, ready := h.Readiness()
+	if ready || len(readiness) != 3 {
+		t.Fatalf("Node shouldn't be ready: %+v", readiness)
+	}
+	if result := readiness["peers"]; result.Error != errNoPeers.Error() || result.ContiguousFailures != 2 || result.TimeOfFirstFailure == nil {
+		t.Fatalf("Wrong result for the peers check: %+v", result)
+	}
+	if result := readiness["panics"]; result.Error == "" {
+		t.Fatalf("Panicking check should be failing")
+	}
+
+	peers = 5
+	h.RunChecks()
+	if result := h.results["peers"]; result.Error != "" || result.ContiguousFailures != 0 || result.TimeOfFirstFailure != nil {
+		t.Fatalf("Peers check should be passing: %+v", result)
+	}
+}
+
+func TestHealthProbes(t *testing.T) {
+	h := Health{}
+	h.Initialize(logging.NoLog{}, time.Hour)
+
+	bootstrapped := false
+	if err := h.RegisterLivenessCheck("db", func() (interface{}, error) { return nil, nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RegisterReadinessCheck("bootstrapped", func() (interface{}, error) {
+		if !bootstrapped {
+			return nil, errors.New("still bootstrapping")
+		}
+		return nil, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+
+	if err := h.Start(); err != errAlreadyStarted {
+		t.Fatalf("Should have errored with %s, got %v", errAlreadyStarted, err)
+	}
+
+	handlers := h.CreateHandlers()
+	probe := func(endpoint, method string) int {
+		w := httptest.NewRecorder()
+		handlers[endpoint].Handler.ServeHTTP(w, httptest.NewRequest(method, "/ext/health"+endpoint, nil))
+		return w.Code
+	}
+
+	if code := probe("/liveness", http.MethodGet); code != http.StatusOK {
+		t.Fatalf("Liveness probe should have returned %d, got %d", http.StatusOK, code)
+	}
+	if code := probe("/readiness", http.MethodGet); code != http.StatusServiceUnavailable {
+		t.Fatalf("Readiness probe should have returned %d, got %d", http.StatusServiceUnavailable, code)
+	}
+	if code := probe("/readiness", http.MethodPost); code != http.StatusMethodNotAllowed {
+		t.Fatalf("Readiness probe should have returned %d, got %d", http.StatusMethodNotAllowed, code)
+	}
+
+	bootstrapped = true
+	h.RunChecks()
+	if code := probe("/readiness", http.MethodGet); code != http.StatusOK {
+		t.Fatalf("Readiness probe should have returned %d, got %d", http.StatusOK, code)
+	}
+
+	reply := HealthReply{}
+	if err := h.GetReadiness(nil, &HealthArgs{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Healthy || len(reply.Checks) != 2 {
+		t.Fatalf("Wrong readiness reply: %+v", reply)
+	}
+}
@@ -0,0 +1,80 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/ava-labs/gecko/snow/engine/common"
+
+	cjson "github.com/ava-labs/gecko/utils/json"
+)
+
+// CreateHandlers returns the handlers of the health API, keyed by the
+// extension of their endpoint:
+//   ""           the JSON RPC service
+//   "/liveness"  responds to GET requests with 200 if the node is live
+//   "/readiness" responds to GET requests with 200 if the node is ready
+// The probe endpoints respond with 503 when a check is failing.
+func (h *Health) CreateHandlers() map[string]*common.HTTPHandler {
+	newServer := rpc.NewServer()
+	codec := cjson.NewCodec()
+	newServer.RegisterCodec(codec, "application/json")
+	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	newServer.RegisterService(h, "health")
+	return map[string]*common.HTTPHandler{
+		"":           {LockOptions: common.NoLock, Handler: newServer},
+		"/liveness":  {LockOptions: common.NoLock, Handler: probeHandler(h.Liveness)},
+		"/readiness": {LockOptions: common.NoLock, Handler: probeHandler(h.Readiness)},
+	}
+}
+
+// probeHandler responds to GET requests with the results of [results]
+func probeHandler(results func() (map[string]Result, bool)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		checks, healthy := results()
+		w.Header().Set("Content-Type", "application/json")
+		if healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(&HealthReply{Checks: checks, Healthy: healthy})
+		}
+	})
+}
+
+// HealthArgs are the arguments for GetLiveness and GetReadiness
+type HealthArgs struct{}
+
+// HealthReply is the response from GetLiveness and GetReadiness
+type HealthReply struct {
+	Checks  map[string]Result `json:"checks"`
+	Healthy bool              `json:"healthy"`
+}
+
+// GetLiveness returns the results of the liveness checks
+func (h *Health) GetLiveness(_ *http.Request, _ *HealthArgs, reply *HealthReply) error {
+	h.log.Debug("Health: GetLiveness called")
+
+	reply.Checks, reply.Healthy = h.Liveness()
+	return nil
+}
+
+// GetReadiness returns the results of every check
+func (h *Health) GetReadiness(_ *http.Request, _ *HealthArgs, reply *HealthReply) error {
+	h.log.Debug("Health: GetReadiness called")
+
+	reply.Checks, reply.Healthy = h.Readiness()
+	return nil
+}
@@ -4,26 +4,98 @@
 package ipcs
 
 import (
-	"nanomsg.org/go/mangos/v2"
+	"sync"
 
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/logging"
 )
 
+// sender is the part of a socket a ChainIPC publishes over
+type sender interface {
+	Send([]byte) error
+	Close() error
+}
+
 // ChainIPC a struct which holds IPC socket information
 type ChainIPC struct {
 	log    logging.Logger
-	socket mangos.Socket
+	socket sender
+
+	// events are the kinds of events published over the socket
+	events map[EventType]bool
+
+	// eventLog, if non-nil, assigns the seqs of the events published and
+	// records the accepted containers
+	eventLog *EventLog
+
+	lock sync.Mutex
+	// seq is the sequence number of the next event published, if there's no
+	// event log
+	seq uint64
 }
 
-// Accept delivers a message to the ChainIPC
+// Issue delivers an issue event to the ChainIPC
+func (cipc *ChainIPC) Issue(chainID, containerID ids.ID, container []byte) error {
+	return cipc.publish(IssueEvent, chainID, containerID, container)
+}
+
+// Accept delivers an accept event to the ChainIPC
 func (cipc *ChainIPC) Accept(chainID, containerID ids.ID, container []byte) error {
-	err := cipc.socket.Send(container)
+	return cipc.publish(AcceptEvent, chainID, containerID, container)
+}
+
+// Reject delivers a reject event to the ChainIPC
+func (cipc *ChainIPC) Reject(chainID, containerID ids.ID, container []byte) error {
+	return cipc.publish(RejectEvent, chainID, containerID, container)
+}
+
+// publish frames the event in an envelope and sends it over the socket, if
+// the ChainIPC publishes events of type [eventType]
+func (cipc *ChainIPC) publish(eventType EventType, chainID, containerID ids.ID, container []byte) error {
+	if !cipc.events[eventType] {
+		return nil
+	}
+
+	cipc.lock.Lock()
+	defer cipc.lock.Unlock()
+
+	// The sequence number is consumed even if sending fails, so that the gap
+	// is visible to consumers
+	seq, err := cipc.nextSeq(eventType, containerID, container)
 	if err != nil {
-		cipc.log.Error("%s while trying to send:\n%s", err, formatting.DumpBytes{Bytes: container})
+		return err
+	}
+
+	event := Event{
+		Type:        eventType,
+		ChainID:     chainID,
+		ContainerID: containerID,
+		Seq:         seq,
+		Container:   container,
+	}
+	msg, err := event.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := cipc.socket.Send(msg); err != nil {
+		cipc.log.Error("%s while trying to send %s event:\n%s", err, eventType, formatting.DumpBytes{Bytes: container})
+		return err
+	}
+	return nil
+}
+
+// nextSeq returns the sequence number of the next event published, recording
+// the event in the event log if there is one
+//
+// Assumes the lock is held
+func (cipc *ChainIPC) nextSeq(eventType EventType, containerID ids.ID, container []byte) (uint64, error) {
+	if cipc.eventLog != nil {
+		return cipc.eventLog.Record(eventType, containerID, container)
 	}
-	return err
+	seq := cipc.seq
+	cipc.seq++
+	return seq, nil
 }
 
 // Stop halts the ChainIPC event loop
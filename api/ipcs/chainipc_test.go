@@ -0,0 +1,216 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/logging"
+)
+
+var errSendFailed = errors.New("send failed")
+
+// testSocket records the messages sent over it
+type testSocket struct {
+	sent    [][]byte
+	sendErr error
+	closed  bool
+}
+
+func (s *testSocket) Send(msg []byte) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *testSocket) Close() error {
+	s.closed = true
+	return nil
+}
+
+// sentEvents parses the messages sent over [socket]
+func sentEvents(t *testing.T, socket *testSocket) []*Event {
+	events := make([]*Event, len(socket.sent))
+	for i, msg := range socket.sent {
+		event, err := ParseEvent(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events[i] = event
+	}
+	return events
+}
+
+func TestChainIPCSeq(t *testing.T) {
+	socket := &testSocket{}
+	cipc := &ChainIPC{
+		log:    logging.NoLog{},
+		socket: socket,
+		events: map[EventType]bool{IssueEvent: true, AcceptEvent: true, RejectEvent: true},
+	}
+
+	chainID := ids.NewID([32]byte{1})
+	errs := []error{
+		cipc.Issue(chainID, ids.NewID([32]byte{2}), []byte{2}),
+		cipc.Accept(chainID, ids.NewID([32]byte{2}), []byte{2}),
+		cipc.Reject(chainID, ids.NewID([32]byte{3}), []byte{3}),
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A failed send still consumes a seq, so consumers see the gap
+	socket.sendErr = errSendFailed
+	if err := cipc.Accept(chainID, ids.NewID([32]byte{4}), []byte{4}); err != errSendFailed {
+		t.Fatalf("Should have errored with %s, got %v", errSendFailed, err)
+	}
+	socket.sendErr = nil
+	if err := cipc.Accept(chainID, ids.NewID([32]byte{5}), []byte{5}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		eventType   EventType
+		containerID byte
+		seq         uint64
+	}{
+		{IssueEvent, 2, 0},
+		{AcceptEvent, 2, 1},
+		{RejectEvent, 3, 2},
+		{AcceptEvent, 5, 4},
+	}
+	events := sentEvents(t, socket)
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, %d were sent", len(expected), len(events))
+	}
+	for i, event := range events {
+		switch {
+		case event.Type != expected[i].eventType:
+			t.Fatalf("Event %d has type %s, expected %s", i, event.Type, expected[i].eventType)
+		case event.Seq != expected[i].seq:
+			t.Fatalf("Event %d has seq %d, expected %d", i, event.Seq, expected[i].seq)
+		case !event.ChainID.Equals(chainID):
+			t.Fatalf("Event %d has chain ID %s, expected %s", i, event.ChainID, chainID)
+		case !event.ContainerID.Equals(ids.NewID([32]byte{expected[i].containerID})):
+			t.Fatalf("Event %d has the wrong container ID %s", i, event.ContainerID)
+		}
+	}
+
+	if err := cipc.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if !socket.closed {
+		t.Fatalf("Stopping should have closed the socket")
+	}
+}
+
+func TestChainIPCFiltersEvents(t *testing.T) {
+	socket := &testSocket{}
+	cipc := &ChainIPC{
+		log:    logging.NoLog{},
+		socket: socket,
+		events: map[EventType]bool{AcceptEvent: true},
+	}
+
+	chainID := ids.NewID([32]byte{1})
+	errs := []error{
+		cipc.Issue(chainID, ids.NewID([32]byte{2}), []byte{2}),
+		cipc.Accept(chainID, ids.NewID([32]byte{2}), []byte{2}),
+		cipc.Reject(chainID, ids.NewID([32]byte{3}), []byte{3}),
+		cipc.Issue(chainID, ids.NewID([32]byte{4}), []byte{4}),
+		cipc.Accept(chainID, ids.NewID([32]byte{4}), []byte{4}),
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Filtered events aren't sent and don't consume a seq
+	events := sentEvents(t, socket)
+	if len(events) != 2 {
+		t.Fatalf("Only the 2 accept events should have been sent, %d events were", len(events))
+	}
+	for i, event := range events {
+		if event.Type != AcceptEvent || event.Seq != uint64(i) {
+			t.Fatalf("Event %d is a %s event with seq %d", i, event.Type, event.Seq)
+		}
+	}
+}
+
+func TestChainIPCResumeFromEventLog(t *testing.T) {
+	db := memdb.New()
+	chainID := ids.NewID([32]byte{1})
+	allEvents := map[EventType]bool{IssueEvent: true, AcceptEvent: true, RejectEvent: true}
+
+	newChainIPC := func(socket *testSocket) *ChainIPC {
+		l, err := NewEventLog(db, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &ChainIPC{
+			log:      logging.NoLog{},
+			socket:   socket,
+			events:   allEvents,
+			eventLog: l,
+		}
+	}
+
+	// The consumer processes the first two events
+	socket := &testSocket{}
+	cipc := newChainIPC(socket)
+	errs := []error{
+		cipc.Issue(chainID, ids.NewID([32]byte{2}), []byte{2}),
+		cipc.Accept(chainID, ids.NewID([32]byte{2}), []byte{2}),
+	}
+	lastProcessed := sentEvents(t, socket)[1].Seq
+
+	// The consumer disconnects while more events are published
+	errs = append(errs,
+		cipc.Issue(chainID, ids.NewID([32]byte{3}), []byte{3}),
+		cipc.Accept(chainID, ids.NewID([32]byte{3}), []byte{3}),
+		cipc.Reject(chainID, ids.NewID([32]byte{4}), []byte{4}),
+	)
+
+	// The node restarts and the chain is published again
+	socket = &testSocket{}
+	cipc = newChainIPC(socket)
+	errs = append(errs,
+		cipc.Accept(chainID, ids.NewID([32]byte{5}), []byte{5}),
+	)
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Seqs keep increasing across the restart
+	events := sentEvents(t, socket)
+	if len(events) != 1 || events[0].Seq != 5 {
+		t.Fatalf("The first event after the restart should have seq 5, got %+v", events)
+	}
+
+	// The consumer fetches the accepted containers it missed, including the
+	// one accepted after the restart, by the seq of their envelopes
+	containers, next, err := cipc.eventLog.Containers(lastProcessed+1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(containers) != 2 || next != 6 {
+		t.Fatalf("Should have fetched 2 containers and next seq 6, fetched %d and %d", len(containers), next)
+	}
+	if containers[0].Seq != 3 || !containers[0].ContainerID.Equals(ids.NewID([32]byte{3})) {
+		t.Fatalf("Wrong first missed container: %+v", containers[0])
+	}
+	if containers[1].Seq != events[0].Seq || !containers[1].ContainerID.Equals(events[0].ContainerID) {
+		t.Fatalf("Logged container %+v doesn't match the published envelope %+v", containers[1], events[0])
+	}
+}
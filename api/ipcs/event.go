@@ -0,0 +1,118 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/wrappers"
+)
+
+// EventType is the kind of consensus event an Event reports
+type EventType byte
+
+// The kinds of events published over an IPC socket
+const (
+	IssueEvent EventType = iota + 1
+	AcceptEvent
+	RejectEvent
+)
+
+var (
+	errUnknownEventType = errors.New("unknown event type")
+	errExtraSpace       = errors.New("trailing buffer space")
+)
+
+// ToEventType returns the event type named [s], which is one of issue,
+// accept and reject
+func ToEventType(s string) (EventType, error) {
+	switch strings.ToLower(s) {
+	case "issue":
+		return IssueEvent, nil
+	case "accept":
+		return AcceptEvent, nil
+	case "reject":
+		return RejectEvent, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", errUnknownEventType, s)
+	}
+}
+
+func (t EventType) String() string {
+	switch t {
+	case IssueEvent:
+		return "issue"
+	case AcceptEvent:
+		return "accept"
+	case RejectEvent:
+		return "reject"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is the envelope every message published over an IPC socket is framed
+// in. It's serialized as the 1 byte type, the 32 byte chain ID, the 32 byte
+// container ID, the 8 byte seq and then the container, prefixed by its 4 byte
+// length.
+//
+// Seq increases by one with every message sent over a socket, so consumers
+// can detect messages they missed. On the decisions socket of a chain, seqs
+// are persisted in the chain's event log, so they keep increasing across
+// restarts and accepted containers can be fetched by the seq they were
+// published with. On the consensus socket, seq starts at 0 whenever the chain
+// is published.
+//
+// The container is sent as the raw bytes the chain serialized it to. Consumers
+// decode it with the codec of the chain's VM.
+type Event struct {
+	Type        EventType
+	ChainID     ids.ID
+	ContainerID ids.ID
+	Seq         uint64
+	Container   []byte
+}
+
+// Bytes returns the serialized form of the event
+func (e *Event) Bytes() ([]byte, error) {
+	p := wrappers.Packer{MaxSize: 1 + 2*hashing.HashLen + wrappers.LongLen + wrappers.IntLen + len(e.Container)}
+	p.PackByte(byte(e.Type))
+	p.PackFixedBytes(e.ChainID.Bytes())
+	p.PackFixedBytes(e.ContainerID.Bytes())
+	p.PackLong(e.Seq)
+	p.PackBytes(e.Container)
+	return p.Bytes, p.Err
+}
+
+// ParseEvent returns the event serialized in [b]
+func ParseEvent(b []byte) (*Event, error) {
+	p := wrappers.Packer{Bytes: b}
+	e := &Event{Type: EventType(p.UnpackByte())}
+	chainID := p.UnpackFixedBytes(hashing.HashLen)
+	containerID := p.UnpackFixedBytes(hashing.HashLen)
+	e.Seq = p.UnpackLong()
+	e.Container = p.UnpackBytes()
+
+	switch {
+	case p.Errored():
+		return nil, p.Err
+	case p.Offset != len(b):
+		return nil, errExtraSpace
+	case e.Type < IssueEvent || e.Type > RejectEvent:
+		return nil, fmt.Errorf("%w: %d", errUnknownEventType, e.Type)
+	}
+
+	var err error
+	if e.ChainID, err = ids.ToID(chainID); err != nil {
+		return nil, err
+	}
+	if e.ContainerID, err = ids.ToID(containerID); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,69 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+func TestEventSerialization(t *testing.T) {
+	event := Event{
+		Type:        RejectEvent,
+		ChainID:     ids.NewID([32]byte{1}),
+		ContainerID: ids.NewID([32]byte{2}),
+		Seq:         7,
+		Container:   []byte{3, 4, 5},
+	}
+	b, err := event.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseEvent(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case parsed.Type != event.Type:
+		t.Fatalf("Wrong type %s, expected %s", parsed.Type, event.Type)
+	case !parsed.ChainID.Equals(event.ChainID):
+		t.Fatalf("Wrong chain ID %s, expected %s", parsed.ChainID, event.ChainID)
+	case !parsed.ContainerID.Equals(event.ContainerID):
+		t.Fatalf("Wrong container ID %s, expected %s", parsed.ContainerID, event.ContainerID)
+	case parsed.Seq != event.Seq:
+		t.Fatalf("Wrong seq %d, expected %d", parsed.Seq, event.Seq)
+	case !bytes.Equal(parsed.Container, event.Container):
+		t.Fatalf("Wrong container %v, expected %v", parsed.Container, event.Container)
+	}
+
+	if _, err := ParseEvent(append(b, 0)); err != errExtraSpace {
+		t.Fatalf("Should have errored with %s, got %v", errExtraSpace, err)
+	}
+	if _, err := ParseEvent(b[:len(b)-1]); err == nil {
+		t.Fatalf("Should have errored on a truncated event")
+	}
+	b[0] = 0
+	if _, err := ParseEvent(b); !errors.Is(err, errUnknownEventType) {
+		t.Fatalf("Should have errored with %s, got %v", errUnknownEventType, err)
+	}
+}
+
+func TestToEventType(t *testing.T) {
+	for _, eventType := range []EventType{IssueEvent, AcceptEvent, RejectEvent} {
+		parsed, err := ToEventType(eventType.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != eventType {
+			t.Fatalf("Parsed %s, expected %s", parsed, eventType)
+		}
+	}
+	if _, err := ToEventType("gossip"); !errors.Is(err, errUnknownEventType) {
+		t.Fatalf("Should have errored with %s, got %v", errUnknownEventType, err)
+	}
+}
@@ -0,0 +1,243 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/wrappers"
+)
+
+// Prefixes of the keys in an event log's database
+const (
+	containerPrefix byte = iota
+	metadataPrefix
+)
+
+var (
+	prunedKey = []byte{metadataPrefix, 0}
+	nextKey   = []byte{metadataPrefix, 1}
+	sizeKey   = []byte{metadataPrefix, 2}
+
+	errPruned      = errors.New("seq was pruned from the event log")
+	errZeroLogSize = errors.New("event log size must be positive")
+)
+
+// LoggedContainer is a container in an event log
+type LoggedContainer struct {
+	// Seq is the seq of the envelope the container was published in
+	Seq         uint64
+	ContainerID ids.ID
+	Container   []byte
+}
+
+// EventLog is a bounded, persistent log of the containers a chain accepted.
+// It also assigns the seqs of the envelopes published over the chain's
+// decisions socket, and keys every accepted container by the seq of the
+// envelope it was published in. Consumers of the socket resume after the last
+// seq they processed to fetch the containers they missed while disconnected.
+//
+// Seqs are persisted, so they keep increasing across restarts of the node and
+// republishing of the chain. Once the log holds [maxSize] containers, the
+// oldest container is pruned whenever one is accepted.
+type EventLog struct {
+	lock    sync.Mutex
+	db      database.Database
+	maxSize uint64
+
+	// pruned is the seq after the newest container pruned from the log.
+	// Containers with a smaller seq may be missing from the log.
+	pruned uint64
+
+	// next is the seq of the next envelope published
+	next uint64
+
+	// size is the number of containers in the log
+	size uint64
+}
+
+// NewEventLog returns the event log persisted in [db], which holds at most
+// [maxSize] containers
+func NewEventLog(db database.Database, maxSize uint64) (*EventLog, error) {
+	if maxSize == 0 {
+		return nil, errZeroLogSize
+	}
+
+	l := &EventLog{
+		db:      db,
+		maxSize: maxSize,
+	}
+	var err error
+	if l.pruned, err = getUint64(db, prunedKey); err != nil {
+		return nil, err
+	}
+	if l.next, err = getUint64(db, nextKey); err != nil {
+		return nil, err
+	}
+	if l.size, err = getUint64(db, sizeKey); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Record assigns the next seq to an envelope of type [eventType] and returns
+// it. If the envelope reports an accepted container, the container is
+// appended to the log under the seq.
+func (l *EventLog) Record(eventType EventType, containerID ids.ID, container []byte) (uint64, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	seq := l.next
+	pruned, next, size := l.pruned, l.next+1, l.size
+
+	batch := l.db.NewBatch()
+	errs := wrappers.Errs{}
+	errs.Add(batch.Put(nextKey, uint64Bytes(next)))
+	if eventType == AcceptEvent {
+		p := wrappers.Packer{MaxSize: hashing.HashLen + wrappers.IntLen + len(container)}
+		p.PackFixedBytes(containerID.Bytes())
+		p.PackBytes(container)
+		if p.Errored() {
+			return 0, p.Err
+		}
+		errs.Add(batch.Put(containerKey(seq), p.Bytes))
+		size++
+
+		if size > l.maxSize {
+			oldest, err := l.oldest()
+			if err != nil {
+				return 0, err
+			}
+			errs.Add(
+				batch.Delete(containerKey(oldest)),
+				batch.Put(prunedKey, uint64Bytes(oldest+1)),
+			)
+			pruned = oldest + 1
+			size--
+		}
+		errs.Add(batch.Put(sizeKey, uint64Bytes(size)))
+	}
+	if errs.Errored() {
+		return 0, errs.Err
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+
+	l.pruned, l.next, l.size = pruned, next, size
+	return seq, nil
+}
+
+// Containers returns up to [max] containers, in order, published in envelopes
+// with a seq of at least [start], along with the seq to resume from in the
+// next call. It errors if containers after [start] may have been pruned from
+// the log.
+func (l *EventLog) Containers(start uint64, max int) ([]LoggedContainer, uint64, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if start < l.pruned {
+		return nil, 0, fmt.Errorf("%w: requested %d but the log starts at %d", errPruned, start, l.pruned)
+	}
+
+	iter := l.db.NewIteratorWithStartAndPrefix(containerKey(start), []byte{containerPrefix})
+	defer iter.Release()
+
+	containers := []LoggedContainer(nil)
+	for len(containers) < max && iter.Next() {
+		seq, err := containerSeq(iter.Key())
+		if err != nil {
+			return nil, 0, err
+		}
+
+		p := wrappers.Packer{Bytes: iter.Value()}
+		containerID := p.UnpackFixedBytes(hashing.HashLen)
+		container := p.UnpackBytes()
+		if p.Errored() {
+			return nil, 0, p.Err
+		}
+		id, err := ids.ToID(containerID)
+		if err != nil {
+			return nil, 0, err
+		}
+		containers = append(containers, LoggedContainer{
+			Seq:         seq,
+			ContainerID: id,
+			Container:   container,
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, 0, err
+	}
+
+	// If the log ran out of containers, the caller has everything published
+	// before the next seq
+	if len(containers) < max {
+		return containers, l.next, nil
+	}
+	return containers, containers[len(containers)-1].Seq + 1, nil
+}
+
+// Bounds returns the oldest seq the log can be read from and the seq of the
+// next envelope published
+func (l *EventLog) Bounds() (uint64, uint64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.pruned, l.next
+}
+
+// oldest returns the seq of the oldest container in the log
+//
+// Assumes the lock is held and the log isn't empty
+func (l *EventLog) oldest() (uint64, error) {
+	iter := l.db.NewIteratorWithPrefix([]byte{containerPrefix})
+	defer iter.Release()
+
+	if !iter.Next() {
+		if err := iter.Error(); err != nil {
+			return 0, err
+		}
+		return 0, database.ErrNotFound
+	}
+	return containerSeq(iter.Key())
+}
+
+// containerKey returns the key the container published with [seq] is stored
+// under. Seqs are big endian so containers are stored in order.
+func containerKey(seq uint64) []byte {
+	return append([]byte{containerPrefix}, uint64Bytes(seq)...)
+}
+
+// containerSeq returns the seq of the container stored under [key]
+func containerSeq(key []byte) (uint64, error) {
+	p := wrappers.Packer{Bytes: key[1:]}
+	seq := p.UnpackLong()
+	return seq, p.Err
+}
+
+func uint64Bytes(n uint64) []byte {
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.LongLen)}
+	p.PackLong(n)
+	return p.Bytes
+}
+
+// getUint64 returns the number stored under [key], or 0 if there isn't one
+func getUint64(db database.Database, key []byte) (uint64, error) {
+	b, err := db.Get(key)
+	if err == database.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	p := wrappers.Packer{Bytes: b}
+	n := p.UnpackLong()
+	return n, p.Err
+}
@@ -0,0 +1,137 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/ids"
+)
+
+func TestEventLogPrunes(t *testing.T) {
+	db := memdb.New()
+	l, err := NewEventLog(db, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := byte(0); i < 5; i++ {
+		seq, err := l.Record(AcceptEvent, ids.NewID([32]byte{i}), []byte{i})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seq != uint64(i) {
+			t.Fatalf("Recorded seq %d, expected %d", seq, i)
+		}
+	}
+
+	if pruned, next := l.Bounds(); pruned != 2 || next != 5 {
+		t.Fatalf("Log should hold seqs [2, 5), holds [%d, %d)", pruned, next)
+	}
+	if _, _, err := l.Containers(1, 10); !errors.Is(err, errPruned) {
+		t.Fatalf("Should have errored with %s, got %v", errPruned, err)
+	}
+
+	containers, next, err := l.Containers(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(containers) != 2 || next != 4 {
+		t.Fatalf("Should have returned 2 containers and next seq 4, returned %d and %d", len(containers), next)
+	}
+	for i, container := range containers {
+		expected := byte(i + 2)
+		if container.Seq != uint64(expected) || !container.ContainerID.Equals(ids.NewID([32]byte{expected})) || !bytes.Equal(container.Container, []byte{expected}) {
+			t.Fatalf("Wrong container at seq %d: %+v", expected, container)
+		}
+	}
+
+	containers, next, err = l.Containers(next, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(containers) != 1 || next != 5 {
+		t.Fatalf("Should have returned 1 container and next seq 5, returned %d and %d", len(containers), next)
+	}
+}
+
+func TestEventLogSkipsUnacceptedEvents(t *testing.T) {
+	l, err := NewEventLog(memdb.New(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Issue and reject events consume seqs, but only accepted containers are
+	// logged
+	events := []EventType{IssueEvent, AcceptEvent, IssueEvent, RejectEvent, AcceptEvent, AcceptEvent}
+	for i, eventType := range events {
+		if _, err := l.Record(eventType, ids.NewID([32]byte{byte(i)}), []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The accept at seq 1 was pruned to keep 2 containers
+	if pruned, next := l.Bounds(); pruned != 2 || next != 6 {
+		t.Fatalf("Log should hold seqs [2, 6), holds [%d, %d)", pruned, next)
+	}
+	containers, next, err := l.Containers(2, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(containers) != 2 || containers[0].Seq != 4 || containers[1].Seq != 5 || next != 6 {
+		t.Fatalf("Should have returned the containers at seqs 4 and 5 and next seq 6, returned %+v and %d", containers, next)
+	}
+
+	// Resuming after the last seq returns nothing until more is accepted
+	containers, next, err = l.Containers(next, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(containers) != 0 || next != 6 {
+		t.Fatalf("Should have returned no containers and next seq 6, returned %d and %d", len(containers), next)
+	}
+}
+
+func TestEventLogPersists(t *testing.T) {
+	db := memdb.New()
+	l, err := NewEventLog(db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := byte(0); i < 3; i++ {
+		if _, err := l.Record(AcceptEvent, ids.NewID([32]byte{i}), []byte{i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := l.Record(RejectEvent, ids.NewID([32]byte{3}), []byte{3}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The log should resume where it left off after a restart
+	restarted, err := NewEventLog(db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seq, err := restarted.Record(AcceptEvent, ids.NewID([32]byte{4}), []byte{4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq != 4 {
+		t.Fatalf("Seq should continue at 4 after a restart, got %d", seq)
+	}
+	containers, next, err := restarted.Containers(0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(containers) != 4 || next != 5 {
+		t.Fatalf("Should have returned 4 containers and next seq 5, returned %d and %d", len(containers), next)
+	}
+
+	if _, err := NewEventLog(db, 0); err != errZeroLogSize {
+		t.Fatalf("Should have errored with %s, got %v", errZeroLogSize, err)
+	}
+}
@@ -15,51 +15,131 @@ import (
 
 	"github.com/ava-labs/gecko/api"
 	"github.com/ava-labs/gecko/chains"
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/engine/common"
 	"github.com/ava-labs/gecko/snow/triggers"
+	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/json"
 	"github.com/ava-labs/gecko/utils/logging"
 	"github.com/ava-labs/gecko/utils/wrappers"
 )
 
-const baseURL = "ipc:///tmp/"
+const (
+	baseURL = "ipc:///tmp/"
+
+	// ipcIdentifier is the name the IPCs are registered under with the event
+	// dispatchers
+	ipcIdentifier = "ipc"
+
+	// maxFetch is the most containers GetAcceptedContainers returns
+	maxFetch = 1024
+)
+
+// publishedPrefix is the prefix of the database that records the chains that
+// are published, so that they're published again after a restart
+var publishedPrefix = []byte("published")
+
+// publishedChain holds the sockets a chain is published over
+type publishedChain struct {
+	// decisions publishes the transactions, or blocks, the chain decides on
+	decisions *ChainIPC
+
+	// consensus publishes the vertices, or blocks, the chain runs consensus on
+	consensus *ChainIPC
+
+	// log assigns the seqs of the decisions and records the containers the
+	// chain accepts
+	log *EventLog
+}
 
 // IPCs maintains the IPCs
 type IPCs struct {
-	log          logging.Logger
-	chainManager chains.Manager
-	httpServer   *api.Server
-	events       *triggers.EventDispatcher
-	chains       map[[32]byte]*ChainIPC
+	log                 logging.Logger
+	chainManager        chains.Manager
+	httpServer          *api.Server
+	decisionDispatcher  *triggers.EventDispatcher
+	consensusDispatcher *triggers.EventDispatcher
+	db                  database.Database
+	publishedDB         database.Database
+	logSize             uint64
+	chains              map[[32]byte]*publishedChain
 }
 
-// NewService returns a new IPCs API service
-func NewService(log logging.Logger, chainManager chains.Manager, events *triggers.EventDispatcher, httpServer *api.Server) *common.HTTPHandler {
+// NewService returns a new IPCs API service. The chains that were published
+// when the node last stopped are published again.
+func NewService(log logging.Logger, chainManager chains.Manager, decisionDispatcher, consensusDispatcher *triggers.EventDispatcher, db database.Database, logSize uint64, httpServer *api.Server) *common.HTTPHandler {
+	ipcs := &IPCs{
+		log:                 log,
+		chainManager:        chainManager,
+		httpServer:          httpServer,
+		decisionDispatcher:  decisionDispatcher,
+		consensusDispatcher: consensusDispatcher,
+		db:                  db,
+		publishedDB:         prefixdb.New(publishedPrefix, db),
+		logSize:             logSize,
+		chains:              map[[32]byte]*publishedChain{},
+	}
+	if err := ipcs.republish(); err != nil {
+		log.Error("couldn't publish the chains published before the restart: %s", err)
+	}
+
 	newServer := rpc.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
-	newServer.RegisterService(&IPCs{
-		log:          log,
-		chainManager: chainManager,
-		httpServer:   httpServer,
-		events:       events,
-		chains:       map[[32]byte]*ChainIPC{},
-	}, "ipcs")
+	newServer.RegisterService(ipcs, "ipcs")
 	return &common.HTTPHandler{Handler: newServer}
 }
 
+// republish publishes the chains recorded in the database as published
+func (ipc *IPCs) republish() error {
+	iter := ipc.publishedDB.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		chainID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return err
+		}
+		events := map[EventType]bool{}
+		for _, eventType := range iter.Value() {
+			events[EventType(eventType)] = true
+		}
+		if _, _, err := ipc.publishChain(chainID, events); err != nil {
+			ipc.log.Error("couldn't publish %s again: %s", chainID, err)
+			continue
+		}
+		ipc.log.Info("published %s again", chainID)
+	}
+	return iter.Error()
+}
+
 // PublishBlockchainArgs are the arguments for calling PublishBlockchain
 type PublishBlockchainArgs struct {
 	BlockchainID string `json:"blockchainID"`
+
+	// Events are the kinds of events to publish, out of issue, accept and
+	// reject. Defaults to every kind.
+	Events []string `json:"events"`
 }
 
 // PublishBlockchainReply are the results from calling PublishBlockchain
 type PublishBlockchainReply struct {
+	// URL is the socket the chain's decisions are published over
 	URL string `json:"url"`
+
+	// ConsensusURL is the socket the containers the chain runs consensus on
+	// are published over
+	ConsensusURL string `json:"consensusURL"`
 }
 
-// PublishBlockchain publishes the finalized accepted transactions from the blockchainID over the IPC
+// PublishBlockchain publishes the events of the blockchainID over the IPC.
+// Every message is an Event envelope. While the chain is published, the
+// accepted containers published over its decisions socket are also recorded
+// in its event log. The chain stays published across restarts until it's
+// unpublished.
 func (ipc *IPCs) PublishBlockchain(r *http.Request, args *PublishBlockchainArgs, reply *PublishBlockchainReply) error {
 	chainID, err := ipc.chainManager.Lookup(args.BlockchainID)
 	if err != nil {
@@ -67,41 +147,122 @@ func (ipc *IPCs) PublishBlockchain(r *http.Request, args *PublishBlockchainArgs,
 		return err
 	}
 
-	chainIDKey := chainID.Key()
-	chainIDStr := chainID.String()
-	url := baseURL + chainIDStr + ".ipc"
-
-	reply.URL = url
+	events := map[EventType]bool{}
+	for _, name := range args.Events {
+		eventType, err := ToEventType(name)
+		if err != nil {
+			return err
+		}
+		events[eventType] = true
+	}
+	if len(events) == 0 {
+		events[IssueEvent] = true
+		events[AcceptEvent] = true
+		events[RejectEvent] = true
+	}
 
-	if _, ok := ipc.chains[chainIDKey]; ok {
-		ipc.log.Info("returning existing blockchainID %s", chainIDStr)
+	if _, ok := ipc.chains[chainID.Key()]; ok {
+		ipc.log.Info("returning existing blockchainID %s", chainID)
+		reply.URL, reply.ConsensusURL = socketURLs(chainID)
 		return nil
 	}
 
+	eventBytes := make([]byte, 0, len(events))
+	for eventType := range events {
+		eventBytes = append(eventBytes, byte(eventType))
+	}
+	if err := ipc.publishedDB.Put(chainID.Bytes(), eventBytes); err != nil {
+		return err
+	}
+
+	reply.URL, reply.ConsensusURL, err = ipc.publishChain(chainID, events)
+	if err != nil {
+		ipc.publishedDB.Delete(chainID.Bytes())
+	}
+	return err
+}
+
+// publishChain opens the sockets that publish the [events] of [chainID] and
+// returns their URLs
+func (ipc *IPCs) publishChain(chainID ids.ID, events map[EventType]bool) (string, string, error) {
+	decisionsURL, consensusURL := socketURLs(chainID)
+
+	log, err := ipc.eventLog(chainID)
+	if err != nil {
+		return "", "", err
+	}
+	decisions, err := ipc.publish(chainID, decisionsURL, events, log, ipc.decisionDispatcher)
+	if err != nil {
+		return "", "", err
+	}
+	consensus, err := ipc.publish(chainID, consensusURL, events, nil, ipc.consensusDispatcher)
+	if err != nil {
+		ipc.unpublish(chainID, decisions, ipc.decisionDispatcher)
+		return "", "", err
+	}
+
+	ipc.chains[chainID.Key()] = &publishedChain{
+		decisions: decisions,
+		consensus: consensus,
+		log:       log,
+	}
+	return decisionsURL, consensusURL, nil
+}
+
+// socketURLs returns the URLs of the decisions and consensus sockets of
+// [chainID]
+func socketURLs(chainID ids.ID) (string, string) {
+	chainIDStr := chainID.String()
+	return baseURL + chainIDStr + ".ipc", baseURL + chainIDStr + ".consensus.ipc"
+}
+
+// eventLog returns the event log of [chainID]
+func (ipc *IPCs) eventLog(chainID ids.ID) (*EventLog, error) {
+	if chain, ok := ipc.chains[chainID.Key()]; ok {
+		return chain.log, nil
+	}
+	return NewEventLog(prefixdb.New(chainID.Bytes(), ipc.db), ipc.logSize)
+}
+
+// publish opens a socket at [url] and registers it with [dispatcher] to
+// publish the [events] of [chainID]. If [eventLog] is non-nil, it assigns the
+// seqs of the events.
+func (ipc *IPCs) publish(chainID ids.ID, url string, events map[EventType]bool, eventLog *EventLog, dispatcher *triggers.EventDispatcher) (*ChainIPC, error) {
 	sock, err := pub.NewSocket()
 	if err != nil {
 		ipc.log.Error("can't get new pub socket: %s", err)
-		return err
+		return nil, err
 	}
 
 	if err = sock.Listen(url); err != nil {
 		ipc.log.Error("can't listen on pub socket: %s", err)
 		sock.Close()
-		return err
+		return nil, err
 	}
 
 	chainIPC := &ChainIPC{
-		log:    ipc.log,
-		socket: sock,
+		log:      ipc.log,
+		socket:   sock,
+		events:   events,
+		eventLog: eventLog,
 	}
-	if err := ipc.events.RegisterChain(chainID, "ipc", chainIPC); err != nil {
+	if err := dispatcher.RegisterChain(chainID, ipcIdentifier, chainIPC); err != nil {
 		ipc.log.Error("couldn't register event: %s", err)
 		sock.Close()
-		return err
+		return nil, err
 	}
+	return chainIPC, nil
+}
 
-	ipc.chains[chainIDKey] = chainIPC
-	return nil
+// unpublish closes the socket of [chainIPC] and deregisters it from
+// [dispatcher]
+func (ipc *IPCs) unpublish(chainID ids.ID, chainIPC *ChainIPC, dispatcher *triggers.EventDispatcher) error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		chainIPC.Stop(),
+		dispatcher.DeregisterChain(chainID, ipcIdentifier),
+	)
+	return errs.Err
 }
 
 // UnpublishBlockchainArgs are the arguments for calling UnpublishBlockchain
@@ -114,7 +275,8 @@ type UnpublishBlockchainReply struct {
 	Success bool `json:"success"`
 }
 
-// UnpublishBlockchain closes publishing of a blockchainID
+// UnpublishBlockchain closes publishing of a blockchainID. Its event log is
+// kept, so the containers it accepted while published can still be fetched.
 func (ipc *IPCs) UnpublishBlockchain(r *http.Request, args *UnpublishBlockchainArgs, reply *UnpublishBlockchainReply) error {
 	chainID, err := ipc.chainManager.Lookup(args.BlockchainID)
 	if err != nil {
@@ -131,11 +293,86 @@ func (ipc *IPCs) UnpublishBlockchain(r *http.Request, args *UnpublishBlockchainA
 
 	errs := wrappers.Errs{}
 	errs.Add(
-		chain.Stop(),
-		ipc.events.DeregisterChain(chainID, "ipc"),
+		ipc.unpublish(chainID, chain.decisions, ipc.decisionDispatcher),
+		ipc.unpublish(chainID, chain.consensus, ipc.consensusDispatcher),
+		ipc.publishedDB.Delete(chainID.Bytes()),
 	)
 	delete(ipc.chains, chainIDKey)
 
 	reply.Success = true
 	return errs.Err
 }
+
+// GetAcceptedContainersArgs are the arguments for calling GetAcceptedContainers
+type GetAcceptedContainersArgs struct {
+	BlockchainID string `json:"blockchainID"`
+
+	// StartSeq is the seq to start returning containers from, which is the
+	// seq after the last envelope the consumer processed
+	StartSeq json.Uint64 `json:"startSeq"`
+
+	// NumToFetch is the most containers to return. Defaults to, and is capped
+	// at, 1024.
+	NumToFetch json.Uint32 `json:"numToFetch"`
+}
+
+// AcceptedContainer is a container in the event log of a chain
+type AcceptedContainer struct {
+	// Seq is the seq of the envelope the container was published in
+	Seq         json.Uint64     `json:"seq"`
+	ContainerID ids.ID          `json:"containerID"`
+	Container   formatting.CB58 `json:"container"`
+}
+
+// GetAcceptedContainersReply are the results from calling GetAcceptedContainers
+type GetAcceptedContainersReply struct {
+	Containers []AcceptedContainer `json:"containers"`
+
+	// NextSeq is the seq to resume from in the next call
+	NextSeq json.Uint64 `json:"nextSeq"`
+
+	// OldestSeq is the oldest seq the log can still be read from
+	OldestSeq json.Uint64 `json:"oldestSeq"`
+}
+
+// GetAcceptedContainers returns the containers the blockchainID accepted while
+// it was published, starting at StartSeq. Consumers that reconnect to the
+// decisions socket of a chain call it with the seq after the last envelope
+// they processed to fetch the containers they missed, including those
+// accepted before the node restarted.
+func (ipc *IPCs) GetAcceptedContainers(r *http.Request, args *GetAcceptedContainersArgs, reply *GetAcceptedContainersReply) error {
+	ipc.log.Debug("IPCs: GetAcceptedContainers called for %s from seq %d", args.BlockchainID, args.StartSeq)
+
+	chainID, err := ipc.chainManager.Lookup(args.BlockchainID)
+	if err != nil {
+		ipc.log.Error("unknown blockchainID %s: %s", args.BlockchainID, err)
+		return err
+	}
+
+	log, err := ipc.eventLog(chainID)
+	if err != nil {
+		return err
+	}
+
+	numToFetch := int(args.NumToFetch)
+	if numToFetch == 0 || numToFetch > maxFetch {
+		numToFetch = maxFetch
+	}
+	containers, next, err := log.Containers(uint64(args.StartSeq), numToFetch)
+	if err != nil {
+		return err
+	}
+
+	reply.Containers = make([]AcceptedContainer, len(containers))
+	for i, container := range containers {
+		reply.Containers[i] = AcceptedContainer{
+			Seq:         json.Uint64(container.Seq),
+			ContainerID: container.ContainerID,
+			Container:   formatting.CB58{Bytes: container.Container},
+		}
+	}
+	oldest, _ := log.Bounds()
+	reply.NextSeq = json.Uint64(next)
+	reply.OldestSeq = json.Uint64(oldest)
+	return nil
+}
@@ -6,11 +6,13 @@ package keystore
 import (
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
 )
 
 // BlockchainKeystore ...
 type BlockchainKeystore struct {
 	blockchainID ids.ID
+	account      uint32
 	ks           *Keystore
 }
 
@@ -18,3 +20,8 @@ type BlockchainKeystore struct {
 func (bks *BlockchainKeystore) GetDatabase(username, password string) (database.Database, error) {
 	return bks.ks.GetDatabase(bks.blockchainID, username, password)
 }
+
+// NewKey ...
+func (bks *BlockchainKeystore) NewKey(username, password string) (*crypto.PrivateKeySECP256K1R, error) {
+	return bks.ks.NewKey(bks.blockchainID, bks.account, username, password)
+}
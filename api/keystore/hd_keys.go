@@ -0,0 +1,129 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/encdb"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/hashing"
+)
+
+const (
+	// bip44Purpose and avaCoinType are the first levels of the paths that
+	// keys are derived along
+	bip44Purpose = 44
+	avaCoinType  = 9000
+
+	// otherVMsAccount is the account of blockchains whose VM has no account
+	// of its own
+	otherVMsAccount = 3
+)
+
+var (
+	// hdKeysID is the ID that the keystore stores a user's mnemonic under,
+	// next to the data of the user's blockchains. It's encrypted with the
+	// user's password, and is exported and deleted along with that data.
+	// It isn't ids.Empty, which is the ID of the platform chain.
+	hdKeysID = ids.NewID(hashing.ComputeHash256Array([]byte("keystore hd keys")))
+
+	// mnemonicKey is the key of the user's mnemonic in the database of
+	// hdKeysID. The index of the next key of a blockchain is stored under the
+	// blockchain's ID.
+	mnemonicKey = []byte("mnemonic")
+
+	// vmAccounts are the BIP44 accounts of the blockchains of each VM, by the
+	// VM's alias. Every blockchain of a VM derives its keys along the VM's
+	// account, so the keys don't depend on the blockchain's ID.
+	vmAccounts = map[string]uint32{
+		"avm":      0,
+		"platform": 1,
+		"evm":      2,
+	}
+)
+
+// VMAccount returns the BIP44 account that blockchains of the VM with
+// [vmAliases] derive their keys along
+func VMAccount(vmAliases []string) uint32 {
+	for _, alias := range vmAliases {
+		if account, ok := vmAccounts[alias]; ok {
+			return account
+		}
+	}
+	return otherVMsAccount
+}
+
+// DerivationPath returns the BIP32 path along which the keys of blockchains
+// with the BIP44 [account] are derived from a user's mnemonic:
+//
+//	m/44'/9000'/<account>'/0
+//
+// The i-th key of a blockchain is the child i of this path.
+func DerivationPath(account uint32) []uint32 {
+	return []uint32{
+		crypto.HardenedKeyStart + bip44Purpose,
+		crypto.HardenedKeyStart + avaCoinType,
+		crypto.HardenedKeyStart + account,
+		0,
+	}
+}
+
+// Returns the database of [username]'s data on the blockchain [bID]
+// Assumes the user exists and [password] is correct
+func (ks *Keystore) userDatabase(bID ids.ID, username, password string) (database.Database, error) {
+	userDB := prefixdb.New([]byte(username), ks.bcDB)
+	bcDB := prefixdb.NewNested(bID.Bytes(), userDB)
+	return encdb.New([]byte(password), bcDB)
+}
+
+// Returns the next key derived from [mnemonic] along [account] for the
+// blockchain [bID], and persists in [db] that it has been derived
+func (ks *Keystore) deriveKey(db database.Database, bID ids.ID, account uint32, mnemonic string) (*crypto.PrivateKeySECP256K1R, error) {
+	index := uint32(0)
+	indexBytes, err := db.Get(bID.Bytes())
+	switch err {
+	case nil:
+		if err := ks.codec.Unmarshal(indexBytes, &index); err != nil {
+			return nil, err
+		}
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	seed, err := crypto.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	master, err := crypto.NewMasterKeySECP256K1R(seed)
+	if err != nil {
+		return nil, err
+	}
+	chainKey, err := master.Derive(DerivationPath(account))
+	if err != nil {
+		return nil, err
+	}
+
+	// With negligible probability, the key at an index is invalid and is
+	// skipped
+	var child *crypto.ExtendedKeySECP256K1R
+	for child == nil {
+		if index >= crypto.HardenedKeyStart {
+			return nil, errKeysExhausted
+		}
+		child, _ = chainKey.Child(index)
+		index++
+	}
+
+	indexBytes, err = ks.codec.Marshal(index)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Put(bID.Bytes(), indexBytes); err != nil {
+		return nil, err
+	}
+	return child.PrivateKey(), nil
+}
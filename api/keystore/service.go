@@ -7,16 +7,17 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/ava-labs/gecko/chains/atomic"
 	"github.com/ava-labs/gecko/database"
-	"github.com/ava-labs/gecko/database/encdb"
 	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/engine/common"
+	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/logging"
 	"github.com/ava-labs/gecko/vms/components/codec"
@@ -46,6 +47,8 @@ var (
 	errEmptyUsername     = errors.New("username can't be the empty string")
 	errUserPassMaxLength = fmt.Errorf("CreateUser call rejected due to username or password exceeding maximum length of %d chars", maxUserPassLen)
 	errWeakPassword      = errors.New("Failed to create user as the given password is too weak. A stronger password is one of 8 or more characters containing attributes of upper and lowercase letters, numbers, and/or special characters")
+	errNoMnemonic        = errors.New("user doesn't hold a mnemonic")
+	errKeysExhausted     = errors.New("all keys of the blockchain have been derived")
 )
 
 // KeyValuePair ...
@@ -123,11 +126,19 @@ func (ks *Keystore) getUser(username string) (*User, error) {
 type CreateUserArgs struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+
+	// If true, the user holds a new random mnemonic, which the keys the user
+	// creates on blockchains are derived from
+	HD bool `json:"hd"`
 }
 
 // CreateUserReply is the response from calling CreateUser
 type CreateUserReply struct {
 	Success bool `json:"success"`
+
+	// The mnemonic of the user, if it holds one. It restores the user's keys
+	// with ImportMnemonic.
+	Mnemonic string `json:"mnemonic,omitempty"`
 }
 
 // CreateUser creates an empty user with the provided username and password
@@ -137,23 +148,41 @@ func (ks *Keystore) CreateUser(_ *http.Request, args *CreateUserArgs, reply *Cre
 
 	ks.log.Verbo("CreateUser called with %.*s", maxUserPassLen, args.Username)
 
-	if len(args.Username) > maxUserPassLen || len(args.Password) > maxUserPassLen {
+	mnemonic := ""
+	if args.HD {
+		var err error
+		if mnemonic, err = crypto.NewMnemonic(); err != nil {
+			return fmt.Errorf("problem generating mnemonic: %w", err)
+		}
+	}
+	if err := ks.createUser(args.Username, args.Password, mnemonic); err != nil {
+		return err
+	}
+	reply.Success = true
+	reply.Mnemonic = mnemonic
+	return nil
+}
+
+// Persists a new user that holds [mnemonic], unless it's empty
+// Assumes the lock is held
+func (ks *Keystore) createUser(username, password, mnemonic string) error {
+	if len(username) > maxUserPassLen || len(password) > maxUserPassLen {
 		return errUserPassMaxLength
 	}
 
-	if args.Username == "" {
+	if username == "" {
 		return errEmptyUsername
 	}
-	if usr, err := ks.getUser(args.Username); err == nil || usr != nil {
-		return fmt.Errorf("user already exists: %s", args.Username)
+	if usr, err := ks.getUser(username); err == nil || usr != nil {
+		return fmt.Errorf("user already exists: %s", username)
 	}
 
-	if zxcvbn.PasswordStrength(args.Password, nil).Score < requiredPassScore {
+	if zxcvbn.PasswordStrength(password, nil).Score < requiredPassScore {
 		return errWeakPassword
 	}
 
 	usr := &User{}
-	if err := usr.Initialize(args.Password); err != nil {
+	if err := usr.Initialize(password); err != nil {
 		return err
 	}
 
@@ -162,11 +191,28 @@ func (ks *Keystore) CreateUser(_ *http.Request, args *CreateUserArgs, reply *Cre
 		return err
 	}
 
-	if err := ks.userDB.Put([]byte(args.Username), usrBytes); err != nil {
+	userBatch := ks.userDB.NewBatch()
+	if err := userBatch.Put([]byte(username), usrBytes); err != nil {
 		return err
 	}
-	ks.users[args.Username] = usr
-	reply.Success = true
+
+	batches := []database.Batch(nil)
+	if mnemonic != "" {
+		hdDB, err := ks.userDatabase(hdKeysID, username, password)
+		if err != nil {
+			return err
+		}
+		hdBatch := hdDB.NewBatch()
+		if err := hdBatch.Put(mnemonicKey, []byte(mnemonic)); err != nil {
+			return err
+		}
+		batches = append(batches, hdBatch)
+	}
+
+	if err := atomic.WriteAll(userBatch, batches...); err != nil {
+		return err
+	}
+	ks.users[username] = usr
 	return nil
 }
 
@@ -301,6 +347,89 @@ func (ks *Keystore) ImportUser(r *http.Request, args *ImportUserArgs, reply *Imp
 	return nil
 }
 
+// ImportMnemonicArgs are arguments for ImportMnemonic
+type ImportMnemonicArgs struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Mnemonic string `json:"mnemonic"`
+}
+
+// ImportMnemonicReply is the response for ImportMnemonic
+type ImportMnemonicReply struct {
+	Success bool `json:"success"`
+
+	// Note describes how the keys of the mnemonic are restored
+	Note string `json:"note"`
+}
+
+// importMnemonicNote is the note of ImportMnemonic replies
+const importMnemonicNote = "No keys were restored yet. The keys of a blockchain are restored in order " +
+	"as addresses are created on it, so create as many addresses on each blockchain as the mnemonic's user had."
+
+// ImportMnemonic creates a user that holds the BIP39 mnemonic [args.Mnemonic].
+// The keys the user creates on a blockchain are the keys derived from the
+// mnemonic for that blockchain, in order, so creating keys restores the keys
+// of the user the mnemonic was exported from. No keys are derived until then.
+func (ks *Keystore) ImportMnemonic(_ *http.Request, args *ImportMnemonicArgs, reply *ImportMnemonicReply) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	ks.log.Verbo("ImportMnemonic called for %.*s", maxUserPassLen, args.Username)
+
+	if _, err := crypto.MnemonicToEntropy(args.Mnemonic); err != nil {
+		return fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	mnemonic := strings.Join(strings.Fields(args.Mnemonic), " ")
+	if err := ks.createUser(args.Username, args.Password, mnemonic); err != nil {
+		return err
+	}
+	reply.Success = true
+	reply.Note = importMnemonicNote
+	return nil
+}
+
+// ExportMnemonicArgs are arguments for ExportMnemonic
+type ExportMnemonicArgs struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ExportMnemonicReply is the response for ExportMnemonic
+type ExportMnemonicReply struct {
+	Mnemonic string `json:"mnemonic"`
+}
+
+// ExportMnemonic returns the mnemonic that the user's keys are derived from
+func (ks *Keystore) ExportMnemonic(_ *http.Request, args *ExportMnemonicArgs, reply *ExportMnemonicReply) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	ks.log.Verbo("ExportMnemonic called for %s", args.Username)
+
+	usr, err := ks.getUser(args.Username)
+	if err != nil {
+		return err
+	}
+	if !usr.CheckPassword(args.Password) {
+		return fmt.Errorf("incorrect password for %s", args.Username)
+	}
+
+	hdDB, err := ks.userDatabase(hdKeysID, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := hdDB.Get(mnemonicKey)
+	switch err {
+	case nil:
+		reply.Mnemonic = string(mnemonic)
+		return nil
+	case database.ErrNotFound:
+		return errNoMnemonic
+	default:
+		return err
+	}
+}
+
 // DeleteUserArgs are arguments for passing into DeleteUser requests
 type DeleteUserArgs struct {
 	Username string `json:"username"`
@@ -365,10 +494,12 @@ func (ks *Keystore) DeleteUser(_ *http.Request, args *DeleteUserArgs, reply *Del
 	return nil
 }
 
-// NewBlockchainKeyStore ...
-func (ks *Keystore) NewBlockchainKeyStore(blockchainID ids.ID) *BlockchainKeystore {
+// NewBlockchainKeyStore returns the keystore of the blockchain [blockchainID],
+// whose keys are derived along the BIP44 [account]
+func (ks *Keystore) NewBlockchainKeyStore(blockchainID ids.ID, account uint32) *BlockchainKeystore {
 	return &BlockchainKeystore{
 		blockchainID: blockchainID,
+		account:      account,
 		ks:           ks,
 	}
 }
@@ -386,13 +517,41 @@ func (ks *Keystore) GetDatabase(bID ids.ID, username, password string) (database
 		return nil, fmt.Errorf("incorrect password for user '%s'", username)
 	}
 
-	userDB := prefixdb.New([]byte(username), ks.bcDB)
-	bcDB := prefixdb.NewNested(bID.Bytes(), userDB)
-	encDB, err := encdb.New([]byte(password), bcDB)
+	return ks.userDatabase(bID, username, password)
+}
+
+// NewKey returns a new key of [username] on the blockchain [bID]. If the user
+// holds a mnemonic, the key is the next key of the blockchain derived from it
+// along DerivationPath(account). Otherwise, the key is random.
+// The key isn't persisted.
+func (ks *Keystore) NewKey(bID ids.ID, account uint32, username, password string) (*crypto.PrivateKeySECP256K1R, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
 
+	usr, err := ks.getUser(username)
 	if err != nil {
 		return nil, err
 	}
+	if !usr.CheckPassword(password) {
+		return nil, fmt.Errorf("incorrect password for user '%s'", username)
+	}
 
-	return encDB, nil
+	hdDB, err := ks.userDatabase(hdKeysID, username, password)
+	if err != nil {
+		return nil, err
+	}
+	mnemonic, err := hdDB.Get(mnemonicKey)
+	switch err {
+	case nil:
+		return ks.deriveKey(hdDB, bID, account, string(mnemonic))
+	case database.ErrNotFound:
+		factory := crypto.FactorySECP256K1R{}
+		sk, err := factory.NewPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		return sk.(*crypto.PrivateKeySECP256K1R), nil
+	default:
+		return nil, err
+	}
 }
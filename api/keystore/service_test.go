@@ -12,6 +12,7 @@ import (
 
 	"github.com/ava-labs/gecko/database/memdb"
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/logging"
 )
 
@@ -367,3 +368,223 @@ func TestServiceDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceHDKeys(t *testing.T) {
+	ks := Keystore{}
+	ks.Initialize(logging.NoLog{}, memdb.New())
+
+	createReply := CreateUserReply{}
+	if err := ks.CreateUser(nil, &CreateUserArgs{
+		Username: "bob",
+		Password: strongPassword,
+		HD:       true,
+	}, &createReply); err != nil {
+		t.Fatal(err)
+	}
+	mnemonic := createReply.Mnemonic
+	if _, err := crypto.MnemonicToEntropy(mnemonic); err != nil {
+		t.Fatalf("User should hold a valid mnemonic: %s", err)
+	}
+
+	exportReply := ExportMnemonicReply{}
+	if err := ks.ExportMnemonic(nil, &ExportMnemonicArgs{
+		Username: "bob",
+		Password: strongPassword,
+	}, &exportReply); err != nil {
+		t.Fatal(err)
+	}
+	if exportReply.Mnemonic != mnemonic {
+		t.Fatalf("Exported mnemonic %q ; Expected: %q", exportReply.Mnemonic, mnemonic)
+	}
+
+	// Keys of AVM blockchains are derived along m/44'/9000'/0'/0, in order
+	chainID := ids.NewID([32]byte{1})
+	avmAccount := VMAccount([]string{"avm"})
+	path := DerivationPath(avmAccount)
+	expectedPath := []uint32{
+		crypto.HardenedKeyStart + 44,
+		crypto.HardenedKeyStart + 9000,
+		crypto.HardenedKeyStart + 0,
+		0,
+	}
+	if !reflect.DeepEqual(path, expectedPath) {
+		t.Fatalf("AVM keys are derived along %v ; Expected: %v", path, expectedPath)
+	}
+	seed, err := crypto.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	master, err := crypto.NewMasterKeySECP256K1R(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uint32(0); i < 2; i++ {
+		key, err := ks.NewKey(chainID, avmAccount, "bob", strongPassword)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := master.Derive(append(path, i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(key.Bytes(), expected.PrivateKey().Bytes()) {
+			t.Fatalf("Key %d of the blockchain wasn't derived from the mnemonic", i)
+		}
+	}
+
+	// Keys don't depend on the blockchain's ID, so they're the same on other
+	// blockchains of the VM
+	firstKey, err := master.Derive(append(path, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherChainKey, err := ks.NewKey(ids.NewID([32]byte{2}), avmAccount, "bob", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(otherChainKey.Bytes(), firstKey.PrivateKey().Bytes()) {
+		t.Fatalf("Blockchains of the same VM should derive the same keys")
+	}
+
+	// Blockchains of other VMs have their own keys
+	platformKey, err := ks.NewKey(ids.Empty, VMAccount([]string{"platform"}), "bob", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(platformKey.Bytes(), firstKey.PrivateKey().Bytes()) {
+		t.Fatalf("Blockchains of different VMs shouldn't share keys")
+	}
+	if account := VMAccount([]string{"timestamp"}); account == avmAccount || account == VMAccount([]string{"platform"}) {
+		t.Fatalf("VMs without an account of their own shouldn't share the account of another VM")
+	}
+
+	if _, err := ks.NewKey(chainID, avmAccount, "bob", "wrong password"); err == nil {
+		t.Fatalf("Should have errored due to an incorrect password")
+	}
+
+	// A user imported by mnemonic derives the same keys from the start, as
+	// addresses are created
+	importReply := ImportMnemonicReply{}
+	if err := ks.ImportMnemonic(nil, &ImportMnemonicArgs{
+		Username: "alice",
+		Password: strongPassword,
+		Mnemonic: mnemonic,
+	}, &importReply); err != nil {
+		t.Fatal(err)
+	}
+	if importReply.Note == "" {
+		t.Fatalf("Reply should note how the keys are restored")
+	}
+	key, err := ks.NewKey(chainID, avmAccount, "alice", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(key.Bytes(), firstKey.PrivateKey().Bytes()) {
+		t.Fatalf("Imported user should have restored the first key of the blockchain")
+	}
+
+	if err := ks.ImportMnemonic(nil, &ImportMnemonicArgs{
+		Username: "carol",
+		Password: strongPassword,
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+	}, &ImportMnemonicReply{}); err == nil {
+		t.Fatalf("Should have errored due to an invalid mnemonic")
+	}
+}
+
+func TestServiceHDKeysExportImportUser(t *testing.T) {
+	ks := Keystore{}
+	ks.Initialize(logging.NoLog{}, memdb.New())
+
+	createReply := CreateUserReply{}
+	if err := ks.CreateUser(nil, &CreateUserArgs{
+		Username: "bob",
+		Password: strongPassword,
+		HD:       true,
+	}, &createReply); err != nil {
+		t.Fatal(err)
+	}
+	chainID := ids.NewID([32]byte{1})
+	if _, err := ks.NewKey(chainID, 0, "bob", strongPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	exportReply := ExportUserReply{}
+	if err := ks.ExportUser(nil, &ExportUserArgs{
+		Username: "bob",
+		Password: strongPassword,
+	}, &exportReply); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.ImportUser(nil, &ImportUserArgs{
+		Username: "dave",
+		Password: strongPassword,
+		User:     exportReply.User,
+	}, &ImportUserReply{}); err != nil {
+		t.Fatal(err)
+	}
+
+	mnemonicReply := ExportMnemonicReply{}
+	if err := ks.ExportMnemonic(nil, &ExportMnemonicArgs{
+		Username: "dave",
+		Password: strongPassword,
+	}, &mnemonicReply); err != nil {
+		t.Fatal(err)
+	}
+	if mnemonicReply.Mnemonic != createReply.Mnemonic {
+		t.Fatalf("Imported user holds %q ; Expected: %q", mnemonicReply.Mnemonic, createReply.Mnemonic)
+	}
+
+	// The imported user continues from the next key of the blockchain
+	seed, err := crypto.MnemonicToSeed(createReply.Mnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	master, err := crypto.NewMasterKeySECP256K1R(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := master.Derive(append(DerivationPath(0), 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ks.NewKey(chainID, 0, "dave", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(key.Bytes(), expected.PrivateKey().Bytes()) {
+		t.Fatalf("Imported user should have derived the second key of the blockchain")
+	}
+}
+
+func TestServiceNoMnemonic(t *testing.T) {
+	ks := Keystore{}
+	ks.Initialize(logging.NoLog{}, memdb.New())
+
+	if err := ks.CreateUser(nil, &CreateUserArgs{
+		Username: "bob",
+		Password: strongPassword,
+	}, &CreateUserReply{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.ExportMnemonic(nil, &ExportMnemonicArgs{
+		Username: "bob",
+		Password: strongPassword,
+	}, &ExportMnemonicReply{}); err != errNoMnemonic {
+		t.Fatalf("Expected %s, got %v", errNoMnemonic, err)
+	}
+
+	// Users without a mnemonic get random keys
+	chainID := ids.NewID([32]byte{1})
+	key0, err := ks.NewKey(chainID, 0, "bob", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key1, err := ks.NewKey(chainID, 0, "bob", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(key0.Bytes(), key1.Bytes()) {
+		t.Fatalf("New keys should differ")
+	}
+}
@@ -5,8 +5,17 @@ package api
 
 import (
 	"net/http"
+
+	"github.com/ava-labs/gecko/api/auth"
 )
 
+// contextKey is the type of the keys of values the server attaches to requests
+type contextKey int
+
+// internalCallKey marks requests made through Server.Call, which originate in
+// this process and don't need to be authorized
+const internalCallKey contextKey = iota
+
 type middlewareHandler struct {
 	before, after func()
 	handler       http.Handler
@@ -21,3 +30,21 @@ func (mh middlewareHandler) ServeHTTP(writer http.ResponseWriter, request *http.
 	}
 	mh.handler.ServeHTTP(writer, request)
 }
+
+// authMiddleware only passes requests that are authorized by [auth] through to
+// [handler]
+type authMiddleware struct {
+	auth    *auth.Auth
+	handler http.Handler
+}
+
+func (am authMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if internal, _ := request.Context().Value(internalCallKey).(bool); !internal {
+		if err := am.auth.Authorize(request); err != nil {
+			writer.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+	am.handler.ServeHTTP(writer, request)
+}
@@ -4,17 +4,20 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/gorilla/handlers"
 
 	"github.com/rs/cors"
 
+	"github.com/ava-labs/gecko/api/auth"
 	"github.com/ava-labs/gecko/snow"
 	"github.com/ava-labs/gecko/snow/engine/common"
 	"github.com/ava-labs/gecko/utils/logging"
@@ -32,6 +35,15 @@ type Server struct {
 	factory logging.Factory
 	router  *router
 	portURL string
+
+	// If non-nil, calls to the routes must carry a token issued by [auth]
+	auth *auth.Auth
+
+	lock sync.Mutex
+	// srv is the HTTP server the API is dispatched on, if it was dispatched
+	srv *http.Server
+	// closed is true once Shutdown has been called
+	closed bool
 }
 
 // Initialize creates the API server at the provided port
@@ -42,16 +54,70 @@ func (s *Server) Initialize(log logging.Logger, factory logging.Factory, port ui
 	s.router = newRouter()
 }
 
-// Dispatch starts the API server
+// RequireAuthorization makes every route added after this call, other than the
+// auth API itself and routes added with AddPublicRoute, reject calls that
+// don't carry a token issued by [a] that authorizes them
+func (s *Server) RequireAuthorization(a *auth.Auth) { s.auth = a }
+
+// Dispatch starts the API server. Returns nil once the server is shut down.
 func (s *Server) Dispatch() error {
-	handler := cors.Default().Handler(s.router)
-	return http.ListenAndServe(s.portURL, handler)
+	srv := s.newHTTPServer()
+	if srv == nil {
+		return nil
+	}
+	return ignoreServerClosed(srv.ListenAndServe())
 }
 
-// DispatchTLS starts the API server with the provided TLS certificate
+// DispatchTLS starts the API server with the provided TLS certificate. Returns
+// nil once the server is shut down.
 func (s *Server) DispatchTLS(certFile, keyFile string) error {
-	handler := cors.Default().Handler(s.router)
-	return http.ListenAndServeTLS(s.portURL, certFile, keyFile, handler)
+	srv := s.newHTTPServer()
+	if srv == nil {
+		return nil
+	}
+	return ignoreServerClosed(srv.ListenAndServeTLS(certFile, keyFile))
+}
+
+// Shutdown stops the API server from accepting calls, and waits up to
+// [timeout] for the calls in flight to finish
+func (s *Server) Shutdown(timeout time.Duration) error {
+	s.lock.Lock()
+	s.closed = true
+	srv := s.srv
+	s.lock.Unlock()
+
+	if srv == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
+
+// newHTTPServer returns the HTTP server to dispatch the API on, or nil if the
+// API server was already shut down
+func (s *Server) newHTTPServer() *http.Server {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.srv = &http.Server{
+		Addr:    s.portURL,
+		Handler: cors.Default().Handler(s.router),
+	}
+	return s.srv
+}
+
+// ignoreServerClosed returns nil if [err] reports that the server was shut
+// down
+func ignoreServerClosed(err error) error {
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
 }
 
 // RegisterChain registers the API endpoints associated with this chain That
@@ -90,27 +156,44 @@ func (s *Server) RegisterChain(ctx *snow.Context, vmIntf interface{}) {
 
 // AddRoute registers the appropriate endpoint for the vm given an endpoint
 func (s *Server) AddRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string, log logging.Logger) error {
+	return s.addRoute(handler, lock, base, endpoint, log, base != auth.Endpoint)
+}
+
+// AddPublicRoute registers the endpoint like AddRoute, but calls to it never
+// need to be authorized. This is meant for endpoints, such as health probes,
+// that are called by tools that can't be issued tokens.
+func (s *Server) AddPublicRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string, log logging.Logger) error {
+	return s.addRoute(handler, lock, base, endpoint, log, false)
+}
+
+func (s *Server) addRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string, log logging.Logger, requireAuth bool) error {
 	url := fmt.Sprintf("%s/%s", baseURL, base)
 	s.log.Info("adding route %s%s", url, endpoint)
-	h := handlers.CombinedLoggingHandler(log, handler.Handler)
+	var h http.Handler
 	switch handler.LockOptions {
 	case common.WriteLock:
-		return s.router.AddRouter(url, endpoint, middlewareHandler{
+		h = middlewareHandler{
 			before:  lock.Lock,
 			after:   lock.Unlock,
-			handler: h,
-		})
+			handler: handler.Handler,
+		}
 	case common.ReadLock:
-		return s.router.AddRouter(url, endpoint, middlewareHandler{
+		h = middlewareHandler{
 			before:  lock.RLock,
 			after:   lock.RUnlock,
-			handler: h,
-		})
+			handler: handler.Handler,
+		}
 	case common.NoLock:
-		return s.router.AddRouter(url, endpoint, h)
+		h = handler.Handler
 	default:
 		return errUnknownLockOption
 	}
+	// Requests are authorized before the lock is grabbed, so that unauthorized
+	// requests can't hold up the chain
+	if s.auth != nil && requireAuth {
+		h = authMiddleware{auth: s.auth, handler: h}
+	}
+	return s.router.AddRouter(url, endpoint, handlers.CombinedLoggingHandler(log, h))
 }
 
 // AddAliases registers aliases to the server
@@ -155,6 +238,7 @@ func (s *Server) Call(
 	if err != nil {
 		return err
 	}
+	req = req.WithContext(context.WithValue(req.Context(), internalCallKey, true))
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
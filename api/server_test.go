@@ -9,10 +9,13 @@ import (
 	"net/http/httptest"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"github.com/gorilla/rpc/v2/json2"
 
+	"github.com/ava-labs/gecko/api/auth"
+	"github.com/ava-labs/gecko/database/memdb"
 	"github.com/ava-labs/gecko/snow/engine/common"
 	"github.com/ava-labs/gecko/utils/logging"
 )
@@ -58,3 +61,131 @@ func TestCall(t *testing.T) {
 		t.Fatalf("Should have been called")
 	}
 }
+
+func TestRequireAuthorization(t *testing.T) {
+	a := &auth.Auth{}
+	if err := a.Initialize(logging.NoLog{}, memdb.New(), "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := Server{}
+	s.Initialize(logging.NoLog{}, logging.NoFactory{}, 8080)
+	s.RequireAuthorization(a)
+
+	serv := &Service{}
+	newServer := rpc.NewServer()
+	newServer.RegisterCodec(json2.NewCodec(), "application/json")
+	newServer.RegisterService(serv, "test")
+	lock := new(sync.RWMutex)
+	if err := s.AddRoute(&common.HTTPHandler{Handler: newServer}, lock, "vm/lol", "", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddRoute(a.CreateHandler(), new(sync.RWMutex), auth.Endpoint, "", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+	probed := false
+	probe := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { probed = true })
+	if err := s.AddPublicRoute(&common.HTTPHandler{LockOptions: common.NoLock, Handler: probe}, new(sync.RWMutex), "health", "/liveness", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+
+	call := func(path, method, token string, args interface{}) *httptest.ResponseRecorder {
+		buf, err := json2.EncodeClientRequest(method, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest("POST", path, bytes.NewBuffer(buf))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		writer := httptest.NewRecorder()
+		s.router.ServeHTTP(writer, req)
+		return writer
+	}
+
+	if writer := call("/ext/vm/lol", "test.Call", "", &Args{}); writer.Code != http.StatusUnauthorized || serv.called {
+		t.Fatalf("Unauthorized call should have been rejected, got status %d", writer.Code)
+	}
+
+	// Unauthorized calls are rejected without waiting for the chain's lock
+	lock.Lock()
+	rejected := make(chan int, 1)
+	go func() { rejected <- call("/ext/vm/lol", "test.Call", "", &Args{}).Code }()
+	select {
+	case code := <-rejected:
+		if code != http.StatusUnauthorized {
+			t.Fatalf("Unauthorized call should have been rejected, got status %d", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Unauthorized call waited for the lock")
+	}
+	lock.Unlock()
+
+	// Public routes don't need a token
+	writer := httptest.NewRecorder()
+	s.router.ServeHTTP(writer, httptest.NewRequest("GET", "/ext/health/liveness", nil))
+	if writer.Code != http.StatusOK || !probed {
+		t.Fatalf("Call to a public route should have succeeded, got status %d", writer.Code)
+	}
+
+	// The auth API is protected by its password rather than by tokens
+	writer = call("/ext/auth", "auth.newToken", "", &auth.NewTokenArgs{
+		Password:  "password",
+		Endpoints: []string{"/ext/vm/lol"},
+	})
+	reply := auth.NewTokenReply{}
+	if err := json2.DecodeClientResponse(writer.Body, &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	if writer := call("/ext/vm/lol", "test.Call", reply.Token, &Args{}); writer.Code != http.StatusOK || !serv.called {
+		t.Fatalf("Authorized call should have succeeded, got status %d", writer.Code)
+	}
+
+	// Calls from within the node don't need a token
+	serv.called = false
+	buf, err := json2.EncodeClientRequest("test.Call", &Args{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer = httptest.NewRecorder()
+	headers := map[string]string{"Content-Type": "application/json"}
+	if err := s.Call(writer, "POST", "lol", "", bytes.NewBuffer(buf), headers); err != nil {
+		t.Fatal(err)
+	}
+	if !serv.called {
+		t.Fatalf("Internal call should have succeeded")
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	s := Server{}
+	s.Initialize(logging.NoLog{}, logging.NoFactory{}, 0)
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Dispatch() }()
+
+	// Wait for the server to be dispatched before shutting it down
+	for {
+		s.lock.Lock()
+		dispatched := s.srv != nil
+		s.lock.Unlock()
+		if dispatched {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := s.Shutdown(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("Dispatch should have returned nil once shut down, got %s", err)
+	}
+
+	// A server that was shut down can't be dispatched again
+	if err := s.Dispatch(); err != nil {
+		t.Fatalf("Dispatch should have returned nil after shutting down, got %s", err)
+	}
+}
@@ -24,6 +24,12 @@ type Cacher interface {
 	Flush()
 }
 
+// Sizable is implemented by values that report how many bytes they take up, so
+// that they can be stored in a cache bounded by size
+type Sizable interface {
+	Size() int
+}
+
 // Evictable allows the object to be notified when it is evicted
 type Evictable interface {
 	ID() ids.ID
@@ -23,6 +23,9 @@ type LRU struct {
 	entryMap  map[[32]byte]*list.Element
 	entryList *list.List
 	Size      int
+
+	// Metrics of the cache. If nil, metrics aren't recorded.
+	Metrics *Metrics
 }
 
 // Put implements the cache interface
@@ -76,6 +79,7 @@ func (c *LRU) resize() {
 
 		val := e.Value.(*entry)
 		delete(c.entryMap, val.Key.Key())
+		c.Metrics.evicted()
 	}
 }
 
@@ -90,6 +94,7 @@ func (c *LRU) put(key ids.ID, value interface{}) {
 
 			val := e.Value.(*entry)
 			delete(c.entryMap, val.Key.Key())
+			c.Metrics.evicted()
 			val.Key = key
 			val.Value = value
 		} else {
@@ -114,9 +119,11 @@ func (c *LRU) get(key ids.ID) (interface{}, bool) {
 	if e, ok := c.entryMap[key.Key()]; ok {
 		c.entryList.MoveToBack(e)
 
+		c.Metrics.hit()
 		val := e.Value.(*entry)
 		return val.Value, true
 	}
+	c.Metrics.miss()
 	return struct{}{}, false
 }
 
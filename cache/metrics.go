@@ -0,0 +1,72 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/gecko/utils/wrappers"
+)
+
+// Metrics counts the hits, misses and evictions of a cache
+type Metrics struct {
+	hits, misses, evictions prometheus.Counter
+}
+
+// NewMetrics returns the metrics of the cache [name], registered with
+// [registerer] under [namespace]. If they can't be registered, nil metrics are
+// returned, which caches treat as not recording metrics.
+func NewMetrics(namespace, name string, registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		hits: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_cache_hits", name),
+				Help:      fmt.Sprintf("Number of lookups found in the %s cache", name),
+			}),
+		misses: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_cache_misses", name),
+				Help:      fmt.Sprintf("Number of lookups not found in the %s cache", name),
+			}),
+		evictions: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_cache_evictions", name),
+				Help:      fmt.Sprintf("Number of entries evicted from the %s cache to make space", name),
+			}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.hits),
+		registerer.Register(m.misses),
+		registerer.Register(m.evictions),
+	)
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+	return m, nil
+}
+
+func (m *Metrics) hit() {
+	if m != nil {
+		m.hits.Inc()
+	}
+}
+
+func (m *Metrics) miss() {
+	if m != nil {
+		m.misses.Inc()
+	}
+}
+
+func (m *Metrics) evicted() {
+	if m != nil {
+		m.evictions.Inc()
+	}
+}
@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics("gecko_test", "state", registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := LRU{Size: 1, Metrics: metrics}
+
+	id1 := ids.NewID([32]byte{1})
+	id2 := ids.NewID([32]byte{2})
+
+	cache.Get(id1)
+	cache.Put(id1, 1)
+	cache.Get(id1)
+	cache.Put(id2, 2)
+	cache.Get(id1)
+
+	if hits := testutil.ToFloat64(metrics.hits); hits != 1 {
+		t.Fatalf("Should have recorded 1 hit, recorded %f", hits)
+	}
+	if misses := testutil.ToFloat64(metrics.misses); misses != 2 {
+		t.Fatalf("Should have recorded 2 misses, recorded %f", misses)
+	}
+	if evictions := testutil.ToFloat64(metrics.evictions); evictions != 1 {
+		t.Fatalf("Should have recorded 1 eviction, recorded %f", evictions)
+	}
+
+	if metrics, err := NewMetrics("gecko_test", "state", registry); err == nil {
+		t.Fatalf("Should have failed to register the metrics twice")
+	} else if metrics != nil {
+		t.Fatalf("Metrics that failed to register shouldn't be returned")
+	}
+}
+
+func TestEvictableLRUMetrics(t *testing.T) {
+	metrics, err := NewMetrics("gecko_test", "unique", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := EvictableLRU{Size: 1, Metrics: metrics}
+
+	value1 := &evictable{id: ids.NewID([32]byte{1})}
+	value2 := &evictable{id: ids.NewID([32]byte{2})}
+	cache.Deduplicate(value1)
+	cache.Deduplicate(value1)
+	cache.Deduplicate(value2)
+	cache.Flush()
+
+	if hits := testutil.ToFloat64(metrics.hits); hits != 1 {
+		t.Fatalf("Should have recorded 1 hit, recorded %f", hits)
+	}
+	if misses := testutil.ToFloat64(metrics.misses); misses != 2 {
+		t.Fatalf("Should have recorded 2 misses, recorded %f", misses)
+	}
+	// Flushing isn't an eviction to make space
+	if evictions := testutil.ToFloat64(metrics.evictions); evictions != 1 {
+		t.Fatalf("Should have recorded 1 eviction, recorded %f", evictions)
+	}
+	if value2.evicted != 1 {
+		t.Fatalf("Flushed value should have been evicted")
+	}
+}
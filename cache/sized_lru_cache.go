@@ -0,0 +1,178 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"container/list"
+	"reflect"
+	"sync"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/hashing"
+)
+
+// entryOverhead is roughly how many bytes an entry takes up besides its value,
+// including its key
+const entryOverhead = 64
+
+type sizedEntry struct {
+	Key   ids.ID
+	Value interface{}
+	Size  int
+}
+
+// SizedLRU is a key value store bounded by the number of bytes its entries
+// take up. If the bound would be exceeded, the least recently used values are
+// evicted until it isn't.
+//
+// Values that implement Sizable take up their size, byte slices and strings
+// their length, and ID slices the length of their IDs. Other values take up the size of
+// their type, which doesn't include any memory they point to. A value larger
+// than the bound isn't kept.
+type SizedLRU struct {
+	lock      sync.Mutex
+	entryMap  map[[32]byte]*list.Element
+	entryList *list.List
+	size      int
+
+	// MaxSize is the number of bytes the entries may take up
+	MaxSize int
+
+	// Metrics of the cache. If nil, metrics aren't recorded.
+	Metrics *Metrics
+}
+
+// Put implements the cache interface
+func (c *SizedLRU) Put(key ids.ID, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+// Get implements the cache interface
+func (c *SizedLRU) Get(key ids.ID) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+// Evict implements the cache interface
+func (c *SizedLRU) Evict(key ids.ID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+// Flush implements the cache interface
+func (c *SizedLRU) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+// Size returns the number of bytes the entries take up
+func (c *SizedLRU) Size() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.size
+}
+
+func (c *SizedLRU) init() {
+	if c.entryMap == nil {
+		c.entryMap = make(map[[32]byte]*list.Element)
+	}
+	if c.entryList == nil {
+		c.entryList = list.New()
+	}
+}
+
+func (c *SizedLRU) resize() {
+	for c.size > c.MaxSize && c.entryList.Len() > 0 {
+		c.remove(c.entryList.Front())
+		c.Metrics.evicted()
+	}
+}
+
+func (c *SizedLRU) remove(e *list.Element) {
+	c.entryList.Remove(e)
+
+	val := e.Value.(*sizedEntry)
+	delete(c.entryMap, val.Key.Key())
+	c.size -= val.Size
+}
+
+func (c *SizedLRU) put(key ids.ID, value interface{}) {
+	c.init()
+
+	size := sizeOf(value)
+	if e, ok := c.entryMap[key.Key()]; !ok {
+		c.entryMap[key.Key()] = c.entryList.PushBack(&sizedEntry{
+			Key:   key,
+			Value: value,
+			Size:  size,
+		})
+	} else {
+		c.entryList.MoveToBack(e)
+
+		val := e.Value.(*sizedEntry)
+		c.size -= val.Size
+		val.Value = value
+		val.Size = size
+	}
+	c.size += size
+	c.resize()
+}
+
+func (c *SizedLRU) get(key ids.ID) (interface{}, bool) {
+	c.init()
+
+	if e, ok := c.entryMap[key.Key()]; ok {
+		c.Metrics.hit()
+		c.entryList.MoveToBack(e)
+
+		val := e.Value.(*sizedEntry)
+		return val.Value, true
+	}
+	c.Metrics.miss()
+	return struct{}{}, false
+}
+
+func (c *SizedLRU) evict(key ids.ID) {
+	c.init()
+
+	if e, ok := c.entryMap[key.Key()]; ok {
+		c.remove(e)
+	}
+}
+
+func (c *SizedLRU) flush() {
+	c.init()
+
+	c.entryMap = make(map[[32]byte]*list.Element)
+	c.entryList = list.New()
+	c.size = 0
+}
+
+// sizeOf returns the number of bytes an entry with [value] takes up
+func sizeOf(value interface{}) int {
+	switch value := value.(type) {
+	case Sizable:
+		return entryOverhead + value.Size()
+	case []byte:
+		return entryOverhead + len(value)
+	case string:
+		return entryOverhead + len(value)
+	case []ids.ID:
+		return entryOverhead + len(value)*hashing.HashLen
+	case nil:
+		return entryOverhead
+	default:
+		return entryOverhead + int(reflect.TypeOf(value).Size())
+	}
+}
@@ -0,0 +1,108 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+type sizedValue int
+
+func (v sizedValue) Size() int { return int(v) }
+
+func TestSizedLRU(t *testing.T) {
+	cache := SizedLRU{MaxSize: 2*entryOverhead + 100}
+
+	id1 := ids.NewID([32]byte{1})
+	id2 := ids.NewID([32]byte{2})
+	id3 := ids.NewID([32]byte{3})
+
+	cache.Put(id1, sizedValue(50))
+	cache.Put(id2, make([]byte, 50))
+	if size := cache.Size(); size != 2*entryOverhead+100 {
+		t.Fatalf("Cache should take up %d bytes, takes up %d", 2*entryOverhead+100, size)
+	}
+	if _, found := cache.Get(id1); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	}
+
+	// id2 is the least recently used, so it's evicted to make space
+	cache.Put(id3, sizedValue(1))
+	if _, found := cache.Get(id2); found {
+		t.Fatalf("Retrieved value when none exists")
+	}
+	if value, found := cache.Get(id1); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	} else if value != sizedValue(50) {
+		t.Fatalf("Failed to retrieve correct value when one exists")
+	}
+	if _, found := cache.Get(id3); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	}
+
+	// Growing a value evicts others
+	cache.Put(id1, sizedValue(100))
+	if _, found := cache.Get(id3); found {
+		t.Fatalf("Retrieved value when none exists")
+	}
+	if size := cache.Size(); size != entryOverhead+100 {
+		t.Fatalf("Cache should take up %d bytes, takes up %d", entryOverhead+100, size)
+	}
+
+	cache.Evict(id1)
+	if size := cache.Size(); size != 0 {
+		t.Fatalf("Cache should be empty, takes up %d bytes", size)
+	}
+}
+
+func TestSizedLRUTooLarge(t *testing.T) {
+	cache := SizedLRU{MaxSize: entryOverhead + 10}
+
+	id1 := ids.NewID([32]byte{1})
+	id2 := ids.NewID([32]byte{2})
+
+	cache.Put(id1, sizedValue(10))
+	cache.Put(id2, sizedValue(11))
+	if _, found := cache.Get(id2); found {
+		t.Fatalf("Value larger than the cache shouldn't be kept")
+	}
+	if _, found := cache.Get(id1); found {
+		t.Fatalf("Value should have been evicted")
+	}
+}
+
+func TestSizedLRUFlush(t *testing.T) {
+	cache := SizedLRU{MaxSize: 1000}
+
+	id1 := ids.NewID([32]byte{1})
+	cache.Put(id1, "value")
+	if size := cache.Size(); size != entryOverhead+5 {
+		t.Fatalf("Cache should take up %d bytes, takes up %d", entryOverhead+5, size)
+	}
+
+	cache.Flush()
+	if _, found := cache.Get(id1); found {
+		t.Fatalf("Retrieved value when none exists")
+	}
+	if size := cache.Size(); size != 0 {
+		t.Fatalf("Cache should be empty, takes up %d bytes", size)
+	}
+}
+
+func TestSizedLRUSizeOf(t *testing.T) {
+	cache := SizedLRU{MaxSize: 1024}
+
+	cache.Put(ids.NewID([32]byte{1}), []ids.ID{ids.Empty, ids.Empty})
+	if size, expected := cache.Size(), entryOverhead+2*32; size != expected {
+		t.Fatalf("Cache should take up %d bytes, takes up %d", expected, size)
+	}
+
+	// Values of fixed size types take up the size of their type
+	cache.Put(ids.NewID([32]byte{2}), uint32(1))
+	if size, expected := cache.Size(), 2*entryOverhead+2*32+4; size != expected {
+		t.Fatalf("Cache should take up %d bytes, takes up %d", expected, size)
+	}
+}
@@ -14,6 +14,9 @@ type EvictableLRU struct {
 	entryMap  map[[32]byte]*list.Element
 	entryList *list.List
 	Size      int
+
+	// Metrics of the cache. If nil, metrics aren't recorded.
+	Metrics *Metrics
 }
 
 // Deduplicate implements the Deduplicator interface
@@ -46,21 +49,27 @@ func (c *EvictableLRU) init() {
 
 func (c *EvictableLRU) resize() {
 	for c.entryList.Len() > c.Size {
-		e := c.entryList.Front()
-		c.entryList.Remove(e)
-
-		val := e.Value.(Evictable)
-		delete(c.entryMap, val.ID().Key())
-		val.Evict()
+		c.evictOldest()
+		c.Metrics.evicted()
 	}
 }
 
+func (c *EvictableLRU) evictOldest() {
+	e := c.entryList.Front()
+	c.entryList.Remove(e)
+
+	val := e.Value.(Evictable)
+	delete(c.entryMap, val.ID().Key())
+	val.Evict()
+}
+
 func (c *EvictableLRU) deduplicate(value Evictable) Evictable {
 	c.init()
 	c.resize()
 
 	key := value.ID().Key()
 	if e, ok := c.entryMap[key]; !ok {
+		c.Metrics.miss()
 		if c.entryList.Len() >= c.Size {
 			e = c.entryList.Front()
 			c.entryList.MoveToBack(e)
@@ -68,6 +77,7 @@ func (c *EvictableLRU) deduplicate(value Evictable) Evictable {
 			val := e.Value.(Evictable)
 			delete(c.entryMap, val.ID().Key())
 			val.Evict()
+			c.Metrics.evicted()
 
 			e.Value = value
 		} else {
@@ -75,6 +85,7 @@ func (c *EvictableLRU) deduplicate(value Evictable) Evictable {
 		}
 		c.entryMap[key] = e
 	} else {
+		c.Metrics.hit()
 		c.entryList.MoveToBack(e)
 
 		val := e.Value.(Evictable)
@@ -86,8 +97,7 @@ func (c *EvictableLRU) deduplicate(value Evictable) Evictable {
 func (c *EvictableLRU) flush() {
 	c.init()
 
-	size := c.Size
-	c.Size = 0
-	c.resize()
-	c.Size = size
+	for c.entryList.Len() > 0 {
+		c.evictOldest()
+	}
 }
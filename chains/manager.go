@@ -5,12 +5,14 @@ package chains
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/gecko/api"
 	"github.com/ava-labs/gecko/api/keystore"
 	"github.com/ava-labs/gecko/chains/atomic"
 	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/meterdb"
 	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
@@ -37,6 +39,26 @@ import (
 	smeng "github.com/ava-labs/gecko/snow/engine/snowman"
 )
 
+// Prefixes of the databases of a chain, under the chain's database
+const (
+	vmDBPrefix                  = "vm"
+	vertexDBPrefix              = "vertex"
+	vertexBootstrappingDBPrefix = "vertex_bootstrapping"
+	txBootstrappingDBPrefix     = "tx_bootstrapping"
+	bootstrappingDBPrefix       = "bootstrapping"
+)
+
+// chainDBPrefixes are the prefixes of all the databases a chain may have.
+// Prefixes of nested prefix databases are collapsed into a single hash, so a
+// chain's data isn't stored under the prefix of the chain's database itself.
+var chainDBPrefixes = []string{
+	vmDBPrefix,
+	vertexDBPrefix,
+	vertexBootstrappingDBPrefix,
+	txBootstrappingDBPrefix,
+	bootstrappingDBPrefix,
+}
+
 const (
 	defaultChannelSize = 1000
 	requestTimeout     = 2 * time.Second
@@ -76,6 +98,25 @@ type Manager interface {
 	// Add an alias to a chain
 	Alias(ids.ID, string) error
 
+	// Return the IDs of the chains that have been created, or are waiting to
+	// be created
+	Chains() []ids.ID
+
+	// Returns true iff the chain with the given ID has finished bootstrapping
+	IsBootstrapped(ids.ID) bool
+
+	// Returns approximately how many bytes the chain with the given ID takes
+	// up in this node's database
+	DatabaseSize(ids.ID) (uint64, error)
+
+	// Returns how many keys the chain with the given ID has in this node's
+	// database. It iterates over all of them.
+	DatabaseKeys(ids.ID) (uint64, error)
+
+	// Delete the database of the chain with the given ID. The chain must not
+	// have been created on this node.
+	DeleteDatabase(ids.ID) error
+
 	Shutdown()
 }
 
@@ -106,6 +147,8 @@ type manager struct {
 	sender          sender.ExternalSender // Sends consensus messages to other validators
 	timeoutManager  *timeout.Manager      // Manages request timeouts when sending messages to other validators
 	consensusParams avacon.Parameters     // The consensus parameters (alpha, beta, etc.) for new chains
+	dbMetrics       *meterdb.Metrics      // Metrics of the databases of the chains
+	slowDBThreshold time.Duration         // Database calls that take at least this long are logged
 	validators      validators.Manager    // Validators validating on this chain
 	registrants     []Registrant          // Those notified when a chain is created
 	nodeID          ids.ShortID           // The ID of this node
@@ -117,6 +160,10 @@ type manager struct {
 
 	unblocked     bool
 	blockedChains []ChainParameters
+
+	chainsLock   sync.Mutex
+	chains       ids.Set // Chains that have been created or are waiting to be created
+	bootstrapped ids.Set // Chains that have finished bootstrapping
 }
 
 // New returns a new Manager where:
@@ -135,6 +182,8 @@ func New(
 	router router.Router,
 	sender sender.ExternalSender,
 	consensusParams avacon.Parameters,
+	shutdownTimeout time.Duration,
+	slowDBThreshold time.Duration,
 	validators validators.Manager,
 	nodeID ids.ShortID,
 	networkID uint32,
@@ -147,7 +196,12 @@ func New(
 	timeoutManager.Initialize(requestTimeout)
 	go log.RecoverAndPanic(timeoutManager.Dispatch)
 
-	router.Initialize(log, &timeoutManager, gossipFrequency)
+	router.Initialize(log, &timeoutManager, gossipFrequency, shutdownTimeout)
+
+	dbMetrics, err := meterdb.NewMetrics("gecko", consensusParams.Metrics)
+	if err != nil {
+		log.Error("Failed to register database statistics due to %s. Database metrics won't be reported", err)
+	}
 
 	m := &manager{
 		stakingEnabled:  stakingEnabled,
@@ -161,6 +215,8 @@ func New(
 		sender:          sender,
 		timeoutManager:  &timeoutManager,
 		consensusParams: consensusParams,
+		dbMetrics:       dbMetrics,
+		slowDBThreshold: slowDBThreshold,
 		validators:      validators,
 		nodeID:          nodeID,
 		networkID:       networkID,
@@ -178,6 +234,10 @@ func (m *manager) Router() router.Router { return m.chainRouter }
 
 // Create a chain
 func (m *manager) CreateChain(chain ChainParameters) {
+	m.chainsLock.Lock()
+	m.chains.Add(chain.ID)
+	m.chainsLock.Unlock()
+
 	if !m.unblocked {
 		m.blockedChains = append(m.blockedChains, chain)
 	} else {
@@ -265,7 +325,7 @@ func (m *manager) ForceCreateChain(chain ChainParameters) {
 		ConsensusDispatcher: m.consensusEvents,
 		NodeID:              m.nodeID,
 		HTTP:                m.server,
-		Keystore:            m.keystore.NewBlockchainKeyStore(chain.ID),
+		Keystore:            m.keystore.NewBlockchainKeyStore(chain.ID, keystore.VMAccount(m.vmManager.Aliases(vmID))),
 		SharedMemory:        m.sharedMemory.NewBlockchainSharedMemory(chain.ID),
 		BCLookup:            m,
 	}
@@ -275,6 +335,8 @@ func (m *manager) ForceCreateChain(chain ChainParameters) {
 	} else {
 		consensusParams.Namespace = fmt.Sprintf("gecko_%s", ctx.ChainID)
 	}
+	ctx.Namespace = consensusParams.Namespace
+	ctx.Metrics = consensusParams.Metrics
 
 	// The validators of this blockchain
 	var validators validators.Set // Validators validating this blockchain
@@ -331,6 +393,10 @@ func (m *manager) ForceCreateChain(chain ChainParameters) {
 	// Associate the newly created chain with its default alias
 	m.log.AssertNoError(m.Alias(chain.ID, chain.ID.String()))
 
+	m.chainsLock.Lock()
+	m.chains.Add(chain.ID)
+	m.chainsLock.Unlock()
+
 	// Notify those that registered to be notified when a new chain is created
 	m.notifyRegistrants(ctx, vm)
 }
@@ -338,6 +404,93 @@ func (m *manager) ForceCreateChain(chain ChainParameters) {
 // Implements Manager.AddRegistrant
 func (m *manager) AddRegistrant(r Registrant) { m.registrants = append(m.registrants, r) }
 
+// Chains returns the IDs of the chains that have been created, or are waiting
+// to be created
+func (m *manager) Chains() []ids.ID {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	return m.chains.List()
+}
+
+// IsBootstrapped returns true iff the chain with ID [chainID] has finished
+// bootstrapping
+func (m *manager) IsBootstrapped(chainID ids.ID) bool {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	return m.bootstrapped.Contains(chainID)
+}
+
+// DatabaseSize returns approximately how many bytes the chain with ID
+// [chainID] takes up in this node's database
+func (m *manager) DatabaseSize(chainID ids.ID) (uint64, error) {
+	total := uint64(0)
+	for _, db := range m.chainDBs(chainID) {
+		size, err := db.ApproximateSize(nil)
+		if err != nil {
+			return 0, err
+		}
+		total += size
+	}
+	return total, nil
+}
+
+// DatabaseKeys returns how many keys the chain with ID [chainID] has in this
+// node's database
+func (m *manager) DatabaseKeys(chainID ids.ID) (uint64, error) {
+	total := uint64(0)
+	for _, db := range m.chainDBs(chainID) {
+		keys, err := database.CountKeys(db, nil)
+		if err != nil {
+			return 0, err
+		}
+		total += keys
+	}
+	return total, nil
+}
+
+// DeleteDatabase deletes the database of the chain with ID [chainID]. Returns
+// an error if the chain has been created, or is waiting to be created.
+func (m *manager) DeleteDatabase(chainID ids.ID) error {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	if m.chains.Contains(chainID) {
+		return fmt.Errorf("chain %s has been created on this node", chainID)
+	}
+	for _, db := range m.chainDBs(chainID) {
+		if err := db.DeleteRange(nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chainDB returns the database of the chain with ID [chainID]
+func (m *manager) chainDB(chainID ids.ID) database.Database {
+	return prefixdb.New(chainID.Bytes(), m.db)
+}
+
+// chainDBs returns every database the chain with ID [chainID] may have
+func (m *manager) chainDBs(chainID ids.ID) []database.Database {
+	db := m.chainDB(chainID)
+	dbs := make([]database.Database, len(chainDBPrefixes))
+	for i, prefix := range chainDBPrefixes {
+		dbs[i] = prefixdb.New([]byte(prefix), db)
+	}
+	return dbs
+}
+
+// markBootstrapped records that the chain with ID [chainID] has finished
+// bootstrapping
+func (m *manager) markBootstrapped(chainID ids.ID) {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	m.bootstrapped.Add(chainID)
+}
+
 func (m *manager) unblockChains() {
 	m.unblocked = true
 	blocked := m.blockedChains
@@ -347,6 +500,14 @@ func (m *manager) unblockChains() {
 	}
 }
 
+// meterDB returns [db], the database [name] of the chain in [ctx], recording
+// metrics and logging slow calls. [db] is wrapped even if the database metrics
+// couldn't be registered, as the prefixes of prefix databases in the chain
+// would otherwise be compressed with the prefix of [db], changing their keys.
+func (m *manager) meterDB(ctx *snow.Context, name string, db database.Database) database.Database {
+	return meterdb.New(ctx.ChainID.String(), name, m.dbMetrics, ctx.Log, m.slowDBThreshold, db)
+}
+
 // Create a DAG-based blockchain that uses Avalanche
 func (m *manager) createAvalancheChain(
 	ctx *snow.Context,
@@ -360,11 +521,11 @@ func (m *manager) createAvalancheChain(
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
 
-	db := prefixdb.New(ctx.ChainID.Bytes(), m.db)
-	vmDB := prefixdb.New([]byte("vm"), db)
-	vertexDB := prefixdb.New([]byte("vertex"), db)
-	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bootstrapping"), db)
-	txBootstrappingDB := prefixdb.New([]byte("tx_bootstrapping"), db)
+	db := m.chainDB(ctx.ChainID)
+	vmDB := m.meterDB(ctx, vmDBPrefix, prefixdb.New([]byte(vmDBPrefix), db))
+	vertexDB := m.meterDB(ctx, vertexDBPrefix, prefixdb.New([]byte(vertexDBPrefix), db))
+	vertexBootstrappingDB := m.meterDB(ctx, vertexBootstrappingDBPrefix, prefixdb.New([]byte(vertexBootstrappingDBPrefix), db))
+	txBootstrappingDB := m.meterDB(ctx, txBootstrappingDBPrefix, prefixdb.New([]byte(txBootstrappingDBPrefix), db))
 
 	vtxBlocker, err := queue.New(vertexBootstrappingDB)
 	if err != nil {
@@ -425,6 +586,9 @@ func (m *manager) createAvalancheChain(
 			TxBlocked:  txBlocker,
 			State:      vtxState,
 			VM:         vm,
+			Bootstrapped: func() {
+				m.markBootstrapped(ctx.ChainID)
+			},
 		},
 		Params:    consensusParams,
 		Consensus: &avacon.Topological{},
@@ -466,9 +630,9 @@ func (m *manager) createSnowmanChain(
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
 
-	db := prefixdb.New(ctx.ChainID.Bytes(), m.db)
-	vmDB := prefixdb.New([]byte("vm"), db)
-	bootstrappingDB := prefixdb.New([]byte("bootstrapping"), db)
+	db := m.chainDB(ctx.ChainID)
+	vmDB := m.meterDB(ctx, vmDBPrefix, prefixdb.New([]byte(vmDBPrefix), db))
+	bootstrappingDB := m.meterDB(ctx, bootstrappingDBPrefix, prefixdb.New([]byte(bootstrappingDBPrefix), db))
 
 	blocked, err := queue.New(bootstrappingDB)
 	if err != nil {
@@ -508,9 +672,12 @@ func (m *manager) createSnowmanChain(
 				Alpha:      bootstrapWeight/2 + 1, // must be > 50%
 				Sender:     &sender,
 			},
-			Blocked:      blocked,
-			VM:           vm,
-			Bootstrapped: m.unblockChains,
+			Blocked: blocked,
+			VM:      vm,
+			Bootstrapped: func() {
+				m.markBootstrapped(ctx.ChainID)
+				m.unblockChains()
+			},
 		},
 		Params:    consensusParams,
 		Consensus: &smcon.Topological{},
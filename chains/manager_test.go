@@ -0,0 +1,82 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/database/versiondb"
+	"github.com/ava-labs/gecko/ids"
+)
+
+func TestDeleteDatabase(t *testing.T) {
+	m := &manager{db: memdb.New()}
+
+	chainID := ids.NewID([32]byte{1})
+	otherChainID := ids.NewID([32]byte{2})
+
+	// Write to the databases of the chains the way a running chain does
+	write := func(chainID ids.ID) []database.Database {
+		chainDB := m.chainDB(chainID)
+		vmDB := versiondb.New(prefixdb.New([]byte(vmDBPrefix), chainDB))
+		bootstrappingDB := prefixdb.New([]byte(bootstrappingDBPrefix), chainDB)
+		for _, db := range []database.Database{vmDB, bootstrappingDB} {
+			if err := db.Put([]byte("key"), []byte("value")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := vmDB.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		return []database.Database{vmDB, bootstrappingDB}
+	}
+	dbs := write(chainID)
+	otherDBs := write(otherChainID)
+
+	if size, err := m.DatabaseSize(chainID); err != nil {
+		t.Fatal(err)
+	} else if size == 0 {
+		t.Fatalf("The chain's data should take up space")
+	}
+	if keys, err := m.DatabaseKeys(chainID); err != nil {
+		t.Fatal(err)
+	} else if keys != 2 {
+		t.Fatalf("The chain should have %d keys, has %d", 2, keys)
+	}
+
+	// The database of a chain that was created can't be deleted
+	m.chains.Add(chainID)
+	if err := m.DeleteDatabase(chainID); err == nil {
+		t.Fatalf("Should have errored due to the chain having been created")
+	}
+	m.chains.Remove(chainID)
+
+	if err := m.DeleteDatabase(chainID); err != nil {
+		t.Fatal(err)
+	}
+	for _, db := range dbs {
+		if has, err := db.Has([]byte("key")); err != nil {
+			t.Fatal(err)
+		} else if has {
+			t.Fatalf("The chain's data should have been deleted")
+		}
+	}
+	if size, err := m.DatabaseSize(chainID); err != nil {
+		t.Fatal(err)
+	} else if size != 0 {
+		t.Fatalf("The deleted chain should take up no space, takes up %d bytes", size)
+	}
+
+	// The data of other chains is kept
+	for _, db := range otherDBs {
+		if has, err := db.Has([]byte("key")); err != nil {
+			t.Fatal(err)
+		} else if !has {
+			t.Fatalf("The data of another chain shouldn't have been deleted")
+		}
+	}
+}
@@ -33,5 +33,20 @@ func (mm MockManager) Aliases(ids.ID) []string { return nil }
 // Alias ...
 func (mm MockManager) Alias(ids.ID, string) error { return nil }
 
+// Chains ...
+func (mm MockManager) Chains() []ids.ID { return nil }
+
+// IsBootstrapped ...
+func (mm MockManager) IsBootstrapped(ids.ID) bool { return false }
+
+// DatabaseSize ...
+func (mm MockManager) DatabaseSize(ids.ID) (uint64, error) { return 0, nil }
+
+// DatabaseKeys ...
+func (mm MockManager) DatabaseKeys(ids.ID) (uint64, error) { return 0, nil }
+
+// DeleteDatabase ...
+func (mm MockManager) DeleteDatabase(ids.ID) error { return nil }
+
 // Shutdown ...
 func (mm MockManager) Shutdown() {}
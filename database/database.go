@@ -49,6 +49,52 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// RangeDeleter wraps the DeleteRange and DeletePrefix methods of a backing data
+// store.
+type RangeDeleter interface {
+	// DeleteRange removes the keys in the range [start, limit) from the
+	// key-value data store.
+	//
+	// A nil start is treated as a key before all keys in the DB.
+	// And a nil limit is treated as a key after all keys in the DB.
+	// Therefore if both are nil then it will delete every key in the DB.
+	DeleteRange(start []byte, limit []byte) error
+
+	// DeletePrefix removes the keys that start with the given prefix from the
+	// key-value data store.
+	DeletePrefix(prefix []byte) error
+}
+
+// Sizer wraps the ApproximateSize method of a backing data store.
+type Sizer interface {
+	// ApproximateSize returns approximately how many bytes the keys that start
+	// with the given prefix, and their values, take up in the key-value data
+	// store. Keys aren't counted, as that requires iterating over them. Use
+	// CountKeys to count them.
+	ApproximateSize(prefix []byte) (size uint64, err error)
+}
+
+// Snapshot is a read-only view of a key-value data store at the point in time
+// it was taken.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases associated resources. Release should always succeed and
+	// can be called multiple times without causing error. After a snapshot is
+	// released, reading from it returns ErrClosed.
+	Release()
+}
+
+// Snapshotter wraps the Snapshot method of a backing data store.
+type Snapshotter interface {
+	// Snapshot returns a view of the current state of the key-value data
+	// store. Writes made to the data store after the snapshot is taken aren't
+	// visible through the snapshot. The snapshot should be released once it's
+	// no longer needed.
+	Snapshot() (Snapshot, error)
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
@@ -58,5 +104,8 @@ type Database interface {
 	Iteratee
 	Stater
 	Compacter
+	RangeDeleter
+	Sizer
+	Snapshotter
 	io.Closer
 }
@@ -149,6 +149,40 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(start, limit)
 }
 
+// DeleteRange implements the Database interface
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.DeleteRange(start, limit)
+}
+
+// DeletePrefix implements the Database interface
+func (db *Database) DeletePrefix(prefix []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.DeletePrefix(prefix)
+}
+
+// ApproximateSize implements the Database interface. The size includes the
+// encryption overhead of the values.
+func (db *Database) ApproximateSize(prefix []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	return db.db.ApproximateSize(prefix)
+}
+
 // Close implements the Database interface
 func (db *Database) Close() error {
 	db.lock.Lock()
@@ -0,0 +1,63 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"github.com/ava-labs/gecko/database"
+)
+
+// Snapshot implements the Database interface by taking a snapshot of the
+// underlying database
+func (db *Database) Snapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	dbSnapshot, err := db.db.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: dbSnapshot,
+		db:       db,
+	}, nil
+}
+
+// snapshot decrypts the values read from a snapshot of the underlying database
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// Get implements the Snapshot interface
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	encVal, err := s.Snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.decrypt(encVal)
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+}
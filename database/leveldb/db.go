@@ -138,6 +138,30 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return updateError(db.DB.CompactRange(util.Range{Start: start, Limit: limit}))
 }
 
+// DeleteRange removes the keys in the range [start, limit) from the database.
+// LevelDB doesn't support range tombstones, so the keys are deleted in
+// batches.
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	return database.DeleteRangeByIteration(db, start, limit)
+}
+
+// DeletePrefix removes the keys that start with the provided prefix from the
+// database
+func (db *Database) DeletePrefix(prefix []byte) error {
+	return database.DeleteRangeByIteration(db, prefix, database.PrefixLimit(prefix))
+}
+
+// ApproximateSize returns approximately how many bytes of the file system the
+// keys that start with the provided prefix take up. It doesn't include data
+// that hasn't been flushed to disk yet.
+func (db *Database) ApproximateSize(prefix []byte) (uint64, error) {
+	sizes, err := db.DB.SizeOf([]util.Range{*util.BytesPrefix(prefix)})
+	if err != nil {
+		return 0, updateError(err)
+	}
+	return uint64(sizes.Sum()), nil
+}
+
 // Close implements the Database interface
 func (db *Database) Close() error { return updateError(db.DB.Close()) }
 
@@ -220,7 +244,7 @@ func (it *iter) Value() []byte { return utils.CopyBytes(it.Iterator.Value()) }
 
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
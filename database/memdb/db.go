@@ -21,6 +21,10 @@ const DefaultSize = 1 << 10
 type Database struct {
 	lock sync.RWMutex
 	db   map[string][]byte
+
+	// shared is true if a snapshot may be reading from [db]. If so, [db] is
+	// copied before it's modified.
+	shared bool
 }
 
 // New returns a map with the Database interface methods implemented.
@@ -76,6 +80,7 @@ func (db *Database) Put(key []byte, value []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
+	db.unshare()
 	db.db[string(key)] = utils.CopyBytes(value)
 	return nil
 }
@@ -88,6 +93,7 @@ func (db *Database) Delete(key []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
+	db.unshare()
 	delete(db.db, string(key))
 	return nil
 }
@@ -116,11 +122,107 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.db == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(db.db, start, prefix)
+}
+
+// Stat implements the Database interface
+func (db *Database) Stat(property string) (string, error) { return "", database.ErrNotFound }
+
+// Compact implements the Database interface
+func (db *Database) Compact(start []byte, limit []byte) error { return nil }
+
+// Snapshot implements the Database interface. The snapshot shares the
+// database's map, which is copied the next time the database is written to.
+func (db *Database) Snapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	db.shared = true
+	return &snapshot{db: db.db}, nil
+}
+
+// DeleteRange implements the Database interface
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
 
+	db.unshare()
 	startString := string(start)
+	limitString := string(limit)
+	for key := range db.db {
+		if key >= startString && (limit == nil || key < limitString) {
+			delete(db.db, key)
+		}
+	}
+	return nil
+}
+
+// DeletePrefix implements the Database interface
+func (db *Database) DeletePrefix(prefix []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+
+	db.unshare()
 	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.db))
 	for key := range db.db {
+		if strings.HasPrefix(key, prefixString) {
+			delete(db.db, key)
+		}
+	}
+	return nil
+}
+
+// ApproximateSize implements the Database interface
+func (db *Database) ApproximateSize(prefix []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+
+	prefixString := string(prefix)
+	size := uint64(0)
+	for key, value := range db.db {
+		if strings.HasPrefix(key, prefixString) {
+			size += uint64(len(key) + len(value))
+		}
+	}
+	return size, nil
+}
+
+// unshare copies the database's map if a snapshot may be reading from it. Must
+// be called with the write lock held before the map is modified.
+func (db *Database) unshare() {
+	if !db.shared {
+		return
+	}
+	copied := make(map[string][]byte, len(db.db))
+	for key, value := range db.db {
+		copied[key] = value
+	}
+	db.db = copied
+	db.shared = false
+}
+
+// newIterator returns an iterator over the keys of [db] that start with
+// [prefix] and are at least [start]
+func newIterator(db map[string][]byte, start, prefix []byte) *iterator {
+	startString := string(start)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(db))
+	for key := range db {
 		if strings.HasPrefix(key, prefixString) && key >= startString {
 			keys = append(keys, key)
 		}
@@ -128,7 +230,7 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	sort.Strings(keys) // Keys need to be in sorted order
 	values := make([][]byte, 0, len(keys))
 	for _, key := range keys {
-		values = append(values, db.db[key])
+		values = append(values, db[key])
 	}
 	return &iterator{
 		keys:   keys,
@@ -136,12 +238,6 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
-// Stat implements the Database interface
-func (db *Database) Stat(property string) (string, error) { return "", database.ErrNotFound }
-
-// Compact implements the Database interface
-func (db *Database) Compact(start []byte, limit []byte) error { return nil }
-
 type keyValue struct {
 	key    []byte
 	value  []byte
@@ -178,6 +274,7 @@ func (b *batch) Write() error {
 		return database.ErrClosed
 	}
 
+	b.db.unshare()
 	for _, kv := range b.writes {
 		key := string(kv.key)
 		if kv.delete {
@@ -0,0 +1,77 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package memdb
+
+import (
+	"sync"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/nodb"
+	"github.com/ava-labs/gecko/utils"
+)
+
+// snapshot is a read-only view of the database. The map it reads from is never
+// modified.
+type snapshot struct {
+	lock sync.RWMutex
+	db   map[string][]byte
+}
+
+// Has implements the Snapshot interface
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db == nil {
+		return false, database.ErrClosed
+	}
+	_, ok := s.db[string(key)]
+	return ok, nil
+}
+
+// Get implements the Snapshot interface
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db == nil {
+		return nil, database.ErrClosed
+	}
+	if entry, ok := s.db[string(key)]; ok {
+		return utils.CopyBytes(entry), nil
+	}
+	return nil, database.ErrNotFound
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(s.db, start, prefix)
+}
+
+// Release implements the Snapshot interface
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.db = nil
+}
@@ -0,0 +1,260 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"time"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/utils/timer"
+)
+
+// Database records the duration of the calls made to a database, the bytes
+// read from and written to it, and the lifetimes of its iterators. Calls, and
+// iterators open for longer, than the slow threshold are logged.
+//
+// Database doesn't change the keys it passes through, so it should wrap the
+// databases that are used directly. A prefixdb wrapping a Database can't
+// compress its prefix with the prefix of a prefixdb inside the Database, so
+// its keys would change.
+type Database struct {
+	chain, name   string
+	log           logging.Logger
+	slowThreshold time.Duration
+	clock         timer.Clock
+	metrics       dbMetrics
+	db            database.Database
+}
+
+// New returns a new database that meters [db], the database [name] of
+// [chain]. Calls that take at least [slowThreshold] are logged to [log]. If
+// [slowThreshold] isn't positive, slow calls aren't logged.
+func New(
+	chain, name string,
+	metrics *Metrics,
+	log logging.Logger,
+	slowThreshold time.Duration,
+	db database.Database,
+) *Database {
+	return &Database{
+		chain:         chain,
+		name:          name,
+		log:           log,
+		slowThreshold: slowThreshold,
+		metrics:       metrics.forDB(chain, name),
+		db:            db,
+	}
+}
+
+// Has implements the Database interface
+func (db *Database) Has(key []byte) (bool, error) {
+	start := db.clock.Time()
+	has, err := db.db.Has(key)
+	db.observe(hasMethod, start)
+	db.metrics.readBytes.Add(float64(len(key)))
+	return has, err
+}
+
+// Get implements the Database interface
+func (db *Database) Get(key []byte) ([]byte, error) {
+	start := db.clock.Time()
+	value, err := db.db.Get(key)
+	db.observe(getMethod, start)
+	db.metrics.readBytes.Add(float64(len(key) + len(value)))
+	return value, err
+}
+
+// Put implements the Database interface
+func (db *Database) Put(key, value []byte) error {
+	start := db.clock.Time()
+	err := db.db.Put(key, value)
+	db.observe(putMethod, start)
+	db.metrics.writtenBytes.Add(float64(len(key) + len(value)))
+	return err
+}
+
+// Delete implements the Database interface
+func (db *Database) Delete(key []byte) error {
+	start := db.clock.Time()
+	err := db.db.Delete(key)
+	db.observe(deleteMethod, start)
+	db.metrics.writtenBytes.Add(float64(len(key)))
+	return err
+}
+
+// NewBatch implements the Database interface
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		Batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+// NewIterator implements the Database interface
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Database interface
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Database interface
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Database interface
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	created := db.clock.Time()
+	it := db.db.NewIteratorWithStartAndPrefix(start, prefix)
+	db.observe(newIteratorMethod, created)
+	db.metrics.openIterators.Inc()
+	return &iterator{
+		Iterator: it,
+		db:       db,
+		created:  created,
+	}
+}
+
+// Stat implements the Database interface
+func (db *Database) Stat(stat string) (string, error) {
+	start := db.clock.Time()
+	value, err := db.db.Stat(stat)
+	db.observe(statMethod, start)
+	return value, err
+}
+
+// Compact implements the Database interface
+func (db *Database) Compact(start, limit []byte) error {
+	startTime := db.clock.Time()
+	err := db.db.Compact(start, limit)
+	db.observe(compactMethod, startTime)
+	return err
+}
+
+// DeleteRange implements the Database interface
+func (db *Database) DeleteRange(start, limit []byte) error {
+	startTime := db.clock.Time()
+	err := db.db.DeleteRange(start, limit)
+	db.observe(deleteRangeMethod, startTime)
+	return err
+}
+
+// DeletePrefix implements the Database interface
+func (db *Database) DeletePrefix(prefix []byte) error {
+	start := db.clock.Time()
+	err := db.db.DeletePrefix(prefix)
+	db.observe(deletePrefixMethod, start)
+	return err
+}
+
+// ApproximateSize implements the Database interface
+func (db *Database) ApproximateSize(prefix []byte) (uint64, error) {
+	start := db.clock.Time()
+	size, err := db.db.ApproximateSize(prefix)
+	db.observe(sizeMethod, start)
+	return size, err
+}
+
+// Snapshot implements the Database interface. Reads from the snapshot aren't
+// metered.
+func (db *Database) Snapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	snapshot, err := db.db.Snapshot()
+	db.observe(snapshotMethod, start)
+	return snapshot, err
+}
+
+// Close implements the Database interface
+func (db *Database) Close() error {
+	start := db.clock.Time()
+	err := db.db.Close()
+	db.observe(closeMethod, start)
+	return err
+}
+
+// observe records that a call to [method] started at [start] has returned
+func (db *Database) observe(method string, start time.Time) {
+	duration := db.clock.Time().Sub(start)
+	db.metrics.duration[method].Observe(duration.Seconds())
+	if db.slowThreshold > 0 && duration >= db.slowThreshold {
+		db.log.Warn("%s on the %s database of chain %s took %s", method, db.name, db.chain, duration)
+	}
+}
+
+type batch struct {
+	database.Batch
+	db *Database
+
+	// bytes of keys and values written to the batch since it was last reset
+	size int
+}
+
+// Put implements the Batch interface
+func (b *batch) Put(key, value []byte) error {
+	b.size += len(key) + len(value)
+	return b.Batch.Put(key, value)
+}
+
+// Delete implements the Batch interface
+func (b *batch) Delete(key []byte) error {
+	b.size += len(key)
+	return b.Batch.Delete(key)
+}
+
+// Write implements the Batch interface
+func (b *batch) Write() error {
+	start := b.db.clock.Time()
+	err := b.Batch.Write()
+	b.db.observe(batchWriteMethod, start)
+	b.db.metrics.writtenBytes.Add(float64(b.size))
+	return err
+}
+
+// Reset implements the Batch interface
+func (b *batch) Reset() {
+	b.size = 0
+	b.Batch.Reset()
+}
+
+type iterator struct {
+	database.Iterator
+	db *Database
+
+	created  time.Time
+	entries  int
+	released bool
+}
+
+// Next implements the Iterator interface
+func (it *iterator) Next() bool {
+	start := it.db.clock.Time()
+	next := it.Iterator.Next()
+	it.db.observe(iteratorNextMethod, start)
+	if next {
+		it.entries++
+		it.db.metrics.readBytes.Add(float64(len(it.Iterator.Key()) + len(it.Iterator.Value())))
+	}
+	return next
+}
+
+// Release implements the Iterator interface
+func (it *iterator) Release() {
+	it.Iterator.Release()
+	if it.released {
+		return
+	}
+	it.released = true
+
+	lifetime := it.db.clock.Time().Sub(it.created)
+	it.db.metrics.openIterators.Dec()
+	it.db.metrics.iteratorLifetime.Observe(lifetime.Seconds())
+	if it.db.slowThreshold > 0 && lifetime >= it.db.slowThreshold {
+		it.db.log.Warn("iterator over the %s database of chain %s was open for %s and read %d entries",
+			it.db.name, it.db.chain, lifetime, it.entries)
+	}
+}
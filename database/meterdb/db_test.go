@@ -0,0 +1,143 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/utils/logging"
+)
+
+// warnLog records the warnings logged to it
+type warnLog struct {
+	logging.NoLog
+	warnings []string
+}
+
+func (l *warnLog) Warn(format string, args ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, args...))
+}
+
+func TestInterface(t *testing.T) {
+	metrics, err := NewMetrics("gecko", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range database.Tests {
+		test(t, New("chain", "vm", metrics, logging.NoLog{}, 0, memdb.New()))
+	}
+}
+
+func TestInterfaceWithoutMetrics(t *testing.T) {
+	for _, test := range database.Tests {
+		test(t, New("chain", "vm", nil, logging.NoLog{}, 0, memdb.New()))
+	}
+}
+
+func TestNewMetricsAlreadyRegistered(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	if _, err := NewMetrics("gecko", registry); err != nil {
+		t.Fatal(err)
+	}
+	metrics, err := NewMetrics("gecko", registry)
+	if err == nil {
+		t.Fatalf("Should have failed to register the metrics twice")
+	}
+	if metrics != nil {
+		t.Fatalf("Should have returned nil metrics on failure")
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics("gecko", registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := New("chain", "vm", metrics, logging.NoLog{}, 0, memdb.New())
+
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	batch := db.NewBatch()
+	if err := batch.Put([]byte("k"), []byte("v")); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if written := testutil.ToFloat64(db.metrics.writtenBytes); written != 10 {
+		t.Fatalf("Should have written 10 bytes, wrote %f", written)
+	}
+
+	if _, err := db.Get([]byte("key")); err != nil {
+		t.Fatal(err)
+	}
+	it := db.NewIterator()
+	if open := testutil.ToFloat64(db.metrics.openIterators); open != 1 {
+		t.Fatalf("Should have 1 open iterator, has %f", open)
+	}
+	for it.Next() {
+	}
+	it.Release()
+	it.Release()
+	if open := testutil.ToFloat64(db.metrics.openIterators); open != 0 {
+		t.Fatalf("Should have no open iterators, has %f", open)
+	}
+	// "key" and "value" were read by Get and the iterator, "k" and "v" by the
+	// iterator
+	if read := testutil.ToFloat64(db.metrics.readBytes); read != 18 {
+		t.Fatalf("Should have read 18 bytes, read %f", read)
+	}
+
+	// The metrics of other databases are labeled separately
+	other := New("other chain", "vm", metrics, logging.NoLog{}, 0, memdb.New())
+	if err := other.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	if written := testutil.ToFloat64(db.metrics.writtenBytes); written != 10 {
+		t.Fatalf("Writes to another database shouldn't be recorded, recorded %f bytes", written)
+	}
+
+	if _, err := NewMetrics("gecko", registry); err == nil {
+		t.Fatalf("Should have failed to register the metrics twice")
+	}
+}
+
+func TestSlowOperations(t *testing.T) {
+	metrics, err := NewMetrics("gecko", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := &warnLog{}
+	db := New("chain", "vm", metrics, log, 1, memdb.New())
+
+	if _, err := db.Has([]byte("key")); err != nil {
+		t.Fatal(err)
+	}
+	if len(log.warnings) != 1 {
+		t.Fatalf("Should have logged the slow call, logged %v", log.warnings)
+	}
+
+	it := db.NewIterator()
+	it.Release()
+	// Creating the iterator and its lifetime are both slow
+	if len(log.warnings) != 3 {
+		t.Fatalf("Should have logged the slow iterator, logged %v", log.warnings)
+	}
+
+	db.slowThreshold = 0
+	if _, err := db.Has([]byte("key")); err != nil {
+		t.Fatal(err)
+	}
+	if len(log.warnings) != 3 {
+		t.Fatalf("Shouldn't log calls without a threshold, logged %v", log.warnings)
+	}
+}
@@ -0,0 +1,158 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/gecko/utils/wrappers"
+)
+
+// Labels of the database metrics
+const (
+	chainLabel  = "chain"
+	dbLabel     = "db"
+	methodLabel = "method"
+)
+
+// Methods whose durations are recorded
+const (
+	hasMethod          = "has"
+	getMethod          = "get"
+	putMethod          = "put"
+	deleteMethod       = "delete"
+	batchWriteMethod   = "batch_write"
+	newIteratorMethod  = "new_iterator"
+	iteratorNextMethod = "iterator_next"
+	statMethod         = "stat"
+	compactMethod      = "compact"
+	deleteRangeMethod  = "delete_range"
+	deletePrefixMethod = "delete_prefix"
+	sizeMethod         = "approximate_size"
+	snapshotMethod     = "snapshot"
+	closeMethod        = "close"
+)
+
+var methods = []string{
+	hasMethod,
+	getMethod,
+	putMethod,
+	deleteMethod,
+	batchWriteMethod,
+	newIteratorMethod,
+	iteratorNextMethod,
+	statMethod,
+	compactMethod,
+	deleteRangeMethod,
+	deletePrefixMethod,
+	sizeMethod,
+	snapshotMethod,
+	closeMethod,
+}
+
+// Metrics of the databases of every chain. The metrics of a database are
+// labeled with the chain and the name of the database.
+type Metrics struct {
+	duration         *prometheus.HistogramVec
+	readBytes        *prometheus.CounterVec
+	writtenBytes     *prometheus.CounterVec
+	openIterators    *prometheus.GaugeVec
+	iteratorLifetime *prometheus.HistogramVec
+}
+
+// NewMetrics returns database metrics registered with [registerer] under
+// [namespace]. Returns nil if they couldn't be registered.
+func NewMetrics(namespace string, registerer prometheus.Registerer) (*Metrics, error) {
+	m := newMetrics(namespace)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.duration),
+		registerer.Register(m.readBytes),
+		registerer.Register(m.writtenBytes),
+		registerer.Register(m.openIterators),
+		registerer.Register(m.iteratorLifetime),
+	)
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+	return m, nil
+}
+
+// newMetrics returns database metrics under [namespace] that aren't registered
+func newMetrics(namespace string) *Metrics {
+	return &Metrics{
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "db_call_duration_seconds",
+				Help:      "Time spent in database calls, by method",
+				Buckets:   prometheus.ExponentialBuckets(1e-6, 4, 12), // 1us to ~4s
+			},
+			[]string{chainLabel, dbLabel, methodLabel},
+		),
+		readBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "db_read_bytes",
+				Help:      "Number of bytes of keys and values read from the database",
+			},
+			[]string{chainLabel, dbLabel},
+		),
+		writtenBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "db_written_bytes",
+				Help:      "Number of bytes of keys and values written to the database",
+			},
+			[]string{chainLabel, dbLabel},
+		),
+		openIterators: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "db_open_iterators",
+				Help:      "Number of iterators that haven't been released",
+			},
+			[]string{chainLabel, dbLabel},
+		),
+		iteratorLifetime: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "db_iterator_lifetime_seconds",
+				Help:      "Time between the creation and the release of iterators",
+				Buckets:   prometheus.ExponentialBuckets(1e-5, 4, 12), // 10us to ~40s
+			},
+			[]string{chainLabel, dbLabel},
+		),
+	}
+}
+
+// dbMetrics are the metrics of a single database
+type dbMetrics struct {
+	duration         map[string]prometheus.Observer
+	readBytes        prometheus.Counter
+	writtenBytes     prometheus.Counter
+	openIterators    prometheus.Gauge
+	iteratorLifetime prometheus.Observer
+}
+
+func (m *Metrics) forDB(chain, db string) dbMetrics {
+	// Without registered metrics, the calls are recorded but not reported
+	if m == nil {
+		m = newMetrics("")
+	}
+
+	labels := prometheus.Labels{chainLabel: chain, dbLabel: db}
+	dbm := dbMetrics{
+		duration:         make(map[string]prometheus.Observer, len(methods)),
+		readBytes:        m.readBytes.With(labels),
+		writtenBytes:     m.writtenBytes.With(labels),
+		openIterators:    m.openIterators.With(labels),
+		iteratorLifetime: m.iteratorLifetime.With(labels),
+	}
+	for _, method := range methods {
+		dbm.duration[method] = m.duration.WithLabelValues(chain, db, method)
+	}
+	return dbm
+}
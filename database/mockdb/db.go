@@ -32,6 +32,10 @@ type Database struct {
 	OnNewIteratorWithStartAndPrefix func([]byte, []byte) database.Iterator
 	OnStat                          func() (string, error)
 	OnCompact                       func([]byte, []byte) error
+	OnDeleteRange                   func([]byte, []byte) error
+	OnDeletePrefix                  func([]byte) error
+	OnApproximateSize               func([]byte) (uint64, error)
+	OnSnapshot                      func() (database.Snapshot, error)
 	OnClose                         func() error
 }
 
@@ -123,6 +127,38 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.OnCompact(start, limit)
 }
 
+// DeleteRange implements the database.Database interface
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	if db.OnDeleteRange == nil {
+		return errNoFunction
+	}
+	return db.OnDeleteRange(start, limit)
+}
+
+// DeletePrefix implements the database.Database interface
+func (db *Database) DeletePrefix(prefix []byte) error {
+	if db.OnDeletePrefix == nil {
+		return errNoFunction
+	}
+	return db.OnDeletePrefix(prefix)
+}
+
+// ApproximateSize implements the database.Database interface
+func (db *Database) ApproximateSize(prefix []byte) (uint64, error) {
+	if db.OnApproximateSize == nil {
+		return 0, errNoFunction
+	}
+	return db.OnApproximateSize(prefix)
+}
+
+// Snapshot implements the database.Database interface
+func (db *Database) Snapshot() (database.Snapshot, error) {
+	if db.OnSnapshot == nil {
+		return nil, errNoFunction
+	}
+	return db.OnSnapshot()
+}
+
 // Close implements the database.Database interface
 func (db *Database) Close() error {
 	if db.OnClose == nil {
@@ -49,6 +49,18 @@ func TestDefaultError(t *testing.T) {
 	if _, err := db.Stat(); err == nil {
 		t.Fatal("should have errored")
 	}
+	if err := db.DeleteRange([]byte{}, []byte{}); err == nil {
+		t.Fatal("should have errored")
+	}
+	if err := db.DeletePrefix([]byte{}); err == nil {
+		t.Fatal("should have errored")
+	}
+	if _, err := db.ApproximateSize([]byte{}); err == nil {
+		t.Fatal("should have errored")
+	}
+	if _, err := db.Snapshot(); err == nil {
+		t.Fatal("should have errored")
+	}
 }
 
 // Assert that mocking works for Get
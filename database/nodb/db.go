@@ -45,6 +45,18 @@ func (*Database) Stat(string) (string, error) { return "", database.ErrClosed }
 // Compact returns nil
 func (*Database) Compact(_, _ []byte) error { return database.ErrClosed }
 
+// DeleteRange returns an error
+func (*Database) DeleteRange(_, _ []byte) error { return database.ErrClosed }
+
+// DeletePrefix returns an error
+func (*Database) DeletePrefix([]byte) error { return database.ErrClosed }
+
+// ApproximateSize returns an error
+func (*Database) ApproximateSize([]byte) (uint64, error) { return 0, database.ErrClosed }
+
+// Snapshot returns an error
+func (*Database) Snapshot() (database.Snapshot, error) { return nil, database.ErrClosed }
+
 // Close returns nil
 func (*Database) Close() error { return database.ErrClosed }
 
@@ -142,6 +142,44 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(db.prefix(start), db.prefix(limit))
 }
 
+// DeleteRange implements the Database interface
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+
+	prefixedLimit := database.PrefixLimit(db.dbPrefix)
+	if limit != nil {
+		prefixedLimit = db.prefix(limit)
+	}
+	return db.db.DeleteRange(db.prefix(start), prefixedLimit)
+}
+
+// DeletePrefix implements the Database interface
+func (db *Database) DeletePrefix(prefix []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.DeletePrefix(db.prefix(prefix))
+}
+
+// ApproximateSize implements the Database interface
+func (db *Database) ApproximateSize(prefix []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	return db.db.ApproximateSize(db.prefix(prefix))
+}
+
 // Close implements the Database interface
 func (db *Database) Close() error {
 	db.lock.Lock()
@@ -21,3 +21,37 @@ func TestInterface(t *testing.T) {
 		test(t, NewNested([]byte("ld"), New([]byte("wor"), db)))
 	}
 }
+
+func TestDeleteRangeIsolation(t *testing.T) {
+	db := memdb.New()
+	left := New([]byte("left"), db)
+	right := New([]byte("right"), db)
+
+	key := []byte("key")
+	if err := left.Put(key, []byte("left")); err != nil {
+		t.Fatal(err)
+	} else if err := right.Put(key, []byte("right")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := left.DeleteRange(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if has, err := left.Has(key); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("DeleteRange should have deleted the key")
+	}
+	if has, err := right.Has(key); err != nil {
+		t.Fatal(err)
+	} else if !has {
+		t.Fatalf("DeleteRange shouldn't have deleted the key of another prefix")
+	}
+
+	if keys, err := database.CountKeys(db, nil); err != nil {
+		t.Fatal(err)
+	} else if keys != 1 {
+		t.Fatalf("Expected 1 key to be left but found %d", keys)
+	}
+}
@@ -0,0 +1,60 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prefixdb
+
+import (
+	"github.com/ava-labs/gecko/database"
+)
+
+// Snapshot implements the Database interface by taking a snapshot of the
+// underlying database
+func (db *Database) Snapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	dbSnapshot, err := db.db.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: dbSnapshot,
+		db:       db,
+	}, nil
+}
+
+// snapshot prefixes the keys read from a snapshot of the underlying database
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// Has implements the Snapshot interface
+func (s *snapshot) Has(key []byte) (bool, error) { return s.Snapshot.Has(s.db.prefix(key)) }
+
+// Get implements the Snapshot interface
+func (s *snapshot) Get(key []byte) ([]byte, error) { return s.Snapshot.Get(s.db.prefix(key)) }
+
+// NewIterator implements the Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(s.db.prefix(start), s.db.prefix(prefix)),
+		db:       s.db,
+	}
+}
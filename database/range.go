@@ -0,0 +1,100 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"bytes"
+)
+
+// deleteBatchSize is the number of keys DeleteRangeByIteration deletes per
+// batch
+const deleteBatchSize = 1024
+
+// PrefixLimit returns the smallest key that is larger than every key starting
+// with [prefix]. If there is no such key, nil is returned.
+func PrefixLimit(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			limit := make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i]++
+			return limit
+		}
+	}
+	return nil
+}
+
+// DeleteRangeByIteration removes the keys of [db] in the range [start, limit)
+// by iterating over them and deleting them in batches. It can be used by
+// databases that can't delete ranges natively.
+func DeleteRangeByIteration(db Database, start, limit []byte) error {
+	for {
+		keys, err := rangeKeys(db, start, limit)
+		if err != nil {
+			return err
+		}
+
+		batch := db.NewBatch()
+		for _, key := range keys {
+			if err := batch.Delete(key); err != nil {
+				return err
+			}
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		if len(keys) < deleteBatchSize {
+			return nil
+		}
+		// The next batch starts at the key right after the last deleted key
+		lastKey := keys[len(keys)-1]
+		start = make([]byte, len(lastKey)+1)
+		copy(start, lastKey)
+	}
+}
+
+// rangeKeys returns up to deleteBatchSize keys of [db] in the range
+// [start, limit)
+func rangeKeys(db Iteratee, start, limit []byte) ([][]byte, error) {
+	it := db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	keys := [][]byte(nil)
+	for len(keys) < deleteBatchSize && it.Next() {
+		key := it.Key()
+		if limit != nil && bytes.Compare(key, limit) >= 0 {
+			break
+		}
+		keys = append(keys, key)
+	}
+	return keys, it.Error()
+}
+
+// SizeByIteration returns how many bytes the keys of [db] that start with
+// [prefix], and their values, take up, by iterating over them. It can be used
+// by databases that can't estimate sizes natively.
+func SizeByIteration(db Iteratee, prefix []byte) (uint64, error) {
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	size := uint64(0)
+	for it.Next() {
+		size += uint64(len(it.Key()) + len(it.Value()))
+	}
+	return size, it.Error()
+}
+
+// CountKeys returns how many keys of [db] start with [prefix]. It iterates
+// over all of them, so it takes time linear in their number.
+func CountKeys(db Iteratee, prefix []byte) (uint64, error) {
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	keys := uint64(0)
+	for it.Next() {
+		keys++
+	}
+	return keys, it.Error()
+}
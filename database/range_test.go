@@ -0,0 +1,28 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrefixLimit(t *testing.T) {
+	tests := []struct {
+		prefix []byte
+		limit  []byte
+	}{
+		{prefix: nil, limit: nil},
+		{prefix: []byte{}, limit: nil},
+		{prefix: []byte{0x00}, limit: []byte{0x01}},
+		{prefix: []byte{0x01, 0x02}, limit: []byte{0x01, 0x03}},
+		{prefix: []byte{0x01, 0xff}, limit: []byte{0x02}},
+		{prefix: []byte{0xff, 0xff}, limit: nil},
+	}
+	for _, test := range tests {
+		if limit := PrefixLimit(test.prefix); !bytes.Equal(limit, test.limit) || (limit == nil) != (test.limit == nil) {
+			t.Fatalf("PrefixLimit(0x%x) returned 0x%x ; Expected: 0x%x", test.prefix, limit, test.limit)
+		}
+	}
+}
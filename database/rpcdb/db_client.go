@@ -119,6 +119,24 @@ func (db *DatabaseClient) Compact(start, limit []byte) error {
 	return updateError(err)
 }
 
+// DeleteRange deletes the keys in the range one batch at a time, as the server
+// doesn't expose range deletion
+func (db *DatabaseClient) DeleteRange(start, limit []byte) error {
+	return database.DeleteRangeByIteration(db, start, limit)
+}
+
+// DeletePrefix deletes the keys with the prefix one batch at a time, as the
+// server doesn't expose range deletion
+func (db *DatabaseClient) DeletePrefix(prefix []byte) error {
+	return database.DeleteRangeByIteration(db, prefix, database.PrefixLimit(prefix))
+}
+
+// ApproximateSize iterates over the keys with the prefix, as the server doesn't
+// expose size estimates
+func (db *DatabaseClient) ApproximateSize(prefix []byte) (uint64, error) {
+	return database.SizeByIteration(db, prefix)
+}
+
 // Close returns nil
 func (db *DatabaseClient) Close() error {
 	_, err := db.client.Close(context.Background(), &rpcdbproto.CloseRequest{})
@@ -5,6 +5,7 @@ package rpcdb
 
 import (
 	"errors"
+	"sync"
 
 	"golang.org/x/net/context"
 
@@ -21,8 +22,15 @@ type DatabaseServer struct {
 	db    database.Database
 	batch database.Batch
 
+	// lock protects the iterators and snapshots, which may be created and
+	// released concurrently
+	lock sync.Mutex
+
 	nextIteratorID uint64
 	iterators      map[uint64]database.Iterator
+
+	nextSnapshotID uint64
+	snapshots      map[uint64]database.Snapshot
 }
 
 // NewServer returns a database instance that is managed remotely
@@ -31,6 +39,7 @@ func NewServer(db database.Database) *DatabaseServer {
 		db:        db,
 		batch:     db.NewBatch(),
 		iterators: make(map[uint64]database.Iterator),
+		snapshots: make(map[uint64]database.Snapshot),
 	}
 }
 
@@ -102,17 +111,13 @@ func (db *DatabaseServer) WriteBatch(_ context.Context, req *rpcdbproto.WriteBat
 
 // NewIteratorWithStartAndPrefix ...
 func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbproto.NewIteratorWithStartAndPrefixRequest) (*rpcdbproto.NewIteratorWithStartAndPrefixResponse, error) {
-	id := db.nextIteratorID
 	it := db.db.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
-	db.iterators[id] = it
-
-	db.nextIteratorID++
-	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: db.addIterator(it)}, nil
 }
 
 // IteratorNext ...
 func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbproto.IteratorNextRequest) (*rpcdbproto.IteratorNextResponse, error) {
-	it, exists := db.iterators[req.Id]
+	it, exists := db.iterator(req.Id)
 	if !exists {
 		return nil, errUnknownIterator
 	}
@@ -125,7 +130,7 @@ func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbproto.Iterat
 
 // IteratorError ...
 func (db *DatabaseServer) IteratorError(_ context.Context, req *rpcdbproto.IteratorErrorRequest) (*rpcdbproto.IteratorErrorResponse, error) {
-	it, exists := db.iterators[req.Id]
+	it, exists := db.iterator(req.Id)
 	if !exists {
 		return nil, errUnknownIterator
 	}
@@ -134,10 +139,113 @@ func (db *DatabaseServer) IteratorError(_ context.Context, req *rpcdbproto.Itera
 
 // IteratorRelease ...
 func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbproto.IteratorReleaseRequest) (*rpcdbproto.IteratorReleaseResponse, error) {
+	db.lock.Lock()
 	it, exists := db.iterators[req.Id]
+	delete(db.iterators, req.Id)
+	db.lock.Unlock()
+
 	if exists {
-		delete(db.iterators, req.Id)
 		it.Release()
 	}
 	return &rpcdbproto.IteratorReleaseResponse{}, nil
 }
+
+// NewSnapshot ...
+func (db *DatabaseServer) NewSnapshot(_ context.Context, _ *rpcdbproto.NewSnapshotRequest) (*rpcdbproto.NewSnapshotResponse, error) {
+	snapshot, err := db.db.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	id := db.nextSnapshotID
+	db.snapshots[id] = snapshot
+
+	db.nextSnapshotID++
+	return &rpcdbproto.NewSnapshotResponse{Id: id}, nil
+}
+
+// SnapshotHas ...
+func (db *DatabaseServer) SnapshotHas(_ context.Context, req *rpcdbproto.SnapshotHasRequest) (*rpcdbproto.SnapshotHasResponse, error) {
+	snapshot, err := db.snapshot(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	has, err := snapshot.Has(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcdbproto.SnapshotHasResponse{Has: has}, nil
+}
+
+// SnapshotGet ...
+func (db *DatabaseServer) SnapshotGet(_ context.Context, req *rpcdbproto.SnapshotGetRequest) (*rpcdbproto.SnapshotGetResponse, error) {
+	snapshot, err := db.snapshot(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	value, err := snapshot.Get(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcdbproto.SnapshotGetResponse{Value: value}, nil
+}
+
+// SnapshotNewIteratorWithStartAndPrefix ...
+func (db *DatabaseServer) SnapshotNewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbproto.SnapshotNewIteratorWithStartAndPrefixRequest) (*rpcdbproto.SnapshotNewIteratorWithStartAndPrefixResponse, error) {
+	snapshot, err := db.snapshot(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	it := snapshot.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	return &rpcdbproto.SnapshotNewIteratorWithStartAndPrefixResponse{Id: db.addIterator(it)}, nil
+}
+
+// SnapshotRelease ...
+func (db *DatabaseServer) SnapshotRelease(_ context.Context, req *rpcdbproto.SnapshotReleaseRequest) (*rpcdbproto.SnapshotReleaseResponse, error) {
+	db.lock.Lock()
+	snapshot, exists := db.snapshots[req.Id]
+	delete(db.snapshots, req.Id)
+	db.lock.Unlock()
+
+	if exists {
+		snapshot.Release()
+	}
+	return &rpcdbproto.SnapshotReleaseResponse{}, nil
+}
+
+// addIterator returns the ID [it] can be referred to by
+func (db *DatabaseServer) addIterator(it database.Iterator) uint64 {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	id := db.nextIteratorID
+	db.iterators[id] = it
+
+	db.nextIteratorID++
+	return id
+}
+
+// iterator returns the iterator with ID [id], if it exists
+func (db *DatabaseServer) iterator(id uint64) (database.Iterator, bool) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	it, exists := db.iterators[id]
+	return it, exists
+}
+
+// snapshot returns the snapshot with ID [id]. Snapshots that have been
+// released are reported as closed.
+func (db *DatabaseServer) snapshot(id uint64) (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, exists := db.snapshots[id]
+	if !exists {
+		return nil, database.ErrClosed
+	}
+	return snapshot, nil
+}
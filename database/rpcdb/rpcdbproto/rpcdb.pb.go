@@ -975,6 +975,420 @@ func (m *IteratorReleaseResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_IteratorReleaseResponse proto.InternalMessageInfo
 
+type NewSnapshotRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotRequest) Reset()         { *m = NewSnapshotRequest{} }
+func (m *NewSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotRequest) ProtoMessage()    {}
+func (*NewSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{25}
+}
+
+func (m *NewSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotRequest.Unmarshal(m, b)
+}
+func (m *NewSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotRequest.Merge(m, src)
+}
+func (m *NewSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotRequest.Size(m)
+}
+func (m *NewSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotRequest proto.InternalMessageInfo
+
+type NewSnapshotResponse struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotResponse) Reset()         { *m = NewSnapshotResponse{} }
+func (m *NewSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotResponse) ProtoMessage()    {}
+func (*NewSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{26}
+}
+
+func (m *NewSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotResponse.Unmarshal(m, b)
+}
+func (m *NewSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotResponse.Merge(m, src)
+}
+func (m *NewSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotResponse.Size(m)
+}
+func (m *NewSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotResponse proto.InternalMessageInfo
+
+func (m *NewSnapshotResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotHasRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotHasRequest) Reset()         { *m = SnapshotHasRequest{} }
+func (m *SnapshotHasRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotHasRequest) ProtoMessage()    {}
+func (*SnapshotHasRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{27}
+}
+
+func (m *SnapshotHasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotHasRequest.Unmarshal(m, b)
+}
+func (m *SnapshotHasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotHasRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotHasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotHasRequest.Merge(m, src)
+}
+func (m *SnapshotHasRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotHasRequest.Size(m)
+}
+func (m *SnapshotHasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotHasRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotHasRequest proto.InternalMessageInfo
+
+func (m *SnapshotHasRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotHasRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type SnapshotHasResponse struct {
+	Has                  bool     `protobuf:"varint,1,opt,name=has,proto3" json:"has,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotHasResponse) Reset()         { *m = SnapshotHasResponse{} }
+func (m *SnapshotHasResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotHasResponse) ProtoMessage()    {}
+func (*SnapshotHasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{28}
+}
+
+func (m *SnapshotHasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotHasResponse.Unmarshal(m, b)
+}
+func (m *SnapshotHasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotHasResponse.Marshal(b, m, deterministic)
+}
+func (m *SnapshotHasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotHasResponse.Merge(m, src)
+}
+func (m *SnapshotHasResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotHasResponse.Size(m)
+}
+func (m *SnapshotHasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotHasResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotHasResponse proto.InternalMessageInfo
+
+func (m *SnapshotHasResponse) GetHas() bool {
+	if m != nil {
+		return m.Has
+	}
+	return false
+}
+
+type SnapshotGetRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotGetRequest) Reset()         { *m = SnapshotGetRequest{} }
+func (m *SnapshotGetRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotGetRequest) ProtoMessage()    {}
+func (*SnapshotGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{29}
+}
+
+func (m *SnapshotGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotGetRequest.Unmarshal(m, b)
+}
+func (m *SnapshotGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotGetRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotGetRequest.Merge(m, src)
+}
+func (m *SnapshotGetRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotGetRequest.Size(m)
+}
+func (m *SnapshotGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotGetRequest proto.InternalMessageInfo
+
+func (m *SnapshotGetRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotGetRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type SnapshotGetResponse struct {
+	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotGetResponse) Reset()         { *m = SnapshotGetResponse{} }
+func (m *SnapshotGetResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotGetResponse) ProtoMessage()    {}
+func (*SnapshotGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{30}
+}
+
+func (m *SnapshotGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotGetResponse.Unmarshal(m, b)
+}
+func (m *SnapshotGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotGetResponse.Marshal(b, m, deterministic)
+}
+func (m *SnapshotGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotGetResponse.Merge(m, src)
+}
+func (m *SnapshotGetResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotGetResponse.Size(m)
+}
+func (m *SnapshotGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotGetResponse proto.InternalMessageInfo
+
+func (m *SnapshotGetResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type SnapshotNewIteratorWithStartAndPrefixRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start                []byte   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Prefix               []byte   `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) Reset() {
+	*m = SnapshotNewIteratorWithStartAndPrefixRequest{}
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*SnapshotNewIteratorWithStartAndPrefixRequest) ProtoMessage() {}
+func (*SnapshotNewIteratorWithStartAndPrefixRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{31}
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Unmarshal(m, b)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Merge(m, src)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Size(m)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest proto.InternalMessageInfo
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+type SnapshotNewIteratorWithStartAndPrefixResponse struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixResponse) Reset() {
+	*m = SnapshotNewIteratorWithStartAndPrefixResponse{}
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixResponse) String() string {
+	return proto.CompactTextString(m)
+}
+func (*SnapshotNewIteratorWithStartAndPrefixResponse) ProtoMessage() {}
+func (*SnapshotNewIteratorWithStartAndPrefixResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{32}
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixResponse.Unmarshal(m, b)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixResponse.Marshal(b, m, deterministic)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixResponse.Merge(m, src)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixResponse.Size(m)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixResponse proto.InternalMessageInfo
+
+func (m *SnapshotNewIteratorWithStartAndPrefixResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotReleaseRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseRequest) Reset()         { *m = SnapshotReleaseRequest{} }
+func (m *SnapshotReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseRequest) ProtoMessage()    {}
+func (*SnapshotReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{33}
+}
+
+func (m *SnapshotReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseRequest.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseRequest.Merge(m, src)
+}
+func (m *SnapshotReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseRequest.Size(m)
+}
+func (m *SnapshotReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseRequest proto.InternalMessageInfo
+
+func (m *SnapshotReleaseRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotReleaseResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseResponse) Reset()         { *m = SnapshotReleaseResponse{} }
+func (m *SnapshotReleaseResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseResponse) ProtoMessage()    {}
+func (*SnapshotReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{34}
+}
+
+func (m *SnapshotReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseResponse.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseResponse.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseResponse.Merge(m, src)
+}
+func (m *SnapshotReleaseResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseResponse.Size(m)
+}
+func (m *SnapshotReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*HasRequest)(nil), "rpcdbproto.HasRequest")
 	proto.RegisterType((*HasResponse)(nil), "rpcdbproto.HasResponse")
@@ -1001,53 +1415,73 @@ func init() {
 	proto.RegisterType((*IteratorErrorResponse)(nil), "rpcdbproto.IteratorErrorResponse")
 	proto.RegisterType((*IteratorReleaseRequest)(nil), "rpcdbproto.IteratorReleaseRequest")
 	proto.RegisterType((*IteratorReleaseResponse)(nil), "rpcdbproto.IteratorReleaseResponse")
+	proto.RegisterType((*NewSnapshotRequest)(nil), "rpcdbproto.NewSnapshotRequest")
+	proto.RegisterType((*NewSnapshotResponse)(nil), "rpcdbproto.NewSnapshotResponse")
+	proto.RegisterType((*SnapshotHasRequest)(nil), "rpcdbproto.SnapshotHasRequest")
+	proto.RegisterType((*SnapshotHasResponse)(nil), "rpcdbproto.SnapshotHasResponse")
+	proto.RegisterType((*SnapshotGetRequest)(nil), "rpcdbproto.SnapshotGetRequest")
+	proto.RegisterType((*SnapshotGetResponse)(nil), "rpcdbproto.SnapshotGetResponse")
+	proto.RegisterType((*SnapshotNewIteratorWithStartAndPrefixRequest)(nil), "rpcdbproto.SnapshotNewIteratorWithStartAndPrefixRequest")
+	proto.RegisterType((*SnapshotNewIteratorWithStartAndPrefixResponse)(nil), "rpcdbproto.SnapshotNewIteratorWithStartAndPrefixResponse")
+	proto.RegisterType((*SnapshotReleaseRequest)(nil), "rpcdbproto.SnapshotReleaseRequest")
+	proto.RegisterType((*SnapshotReleaseResponse)(nil), "rpcdbproto.SnapshotReleaseResponse")
 }
 
 func init() { proto.RegisterFile("rpcdb.proto", fileDescriptor_af52f4b90339c3f4) }
 
 var fileDescriptor_af52f4b90339c3f4 = []byte{
-	// 654 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5d, 0x4f, 0xd4, 0x40,
-	0x14, 0x0d, 0xbb, 0xcb, 0xd7, 0xd9, 0x0f, 0x60, 0x5c, 0xf9, 0xa8, 0xf2, 0x35, 0x88, 0x41, 0x1f,
-	0x88, 0x82, 0xc1, 0x98, 0x90, 0x18, 0x01, 0x03, 0xc6, 0x84, 0x60, 0x21, 0x21, 0x31, 0xbe, 0x0c,
-	0xec, 0x10, 0x1a, 0x97, 0x6d, 0x6d, 0x67, 0x15, 0xdf, 0x7d, 0xf1, 0x5f, 0x9b, 0x4e, 0x6f, 0xdb,
-	0x99, 0xdd, 0x76, 0xd5, 0xb7, 0xb9, 0x73, 0xcf, 0x39, 0x73, 0xe7, 0xce, 0x3d, 0x83, 0x7a, 0x18,
-	0x5c, 0x77, 0xae, 0xb6, 0x83, 0xd0, 0x57, 0x3e, 0x83, 0x0e, 0xf4, 0x9a, 0xaf, 0x00, 0x27, 0x22,
-	0x72, 0xe5, 0xb7, 0xbe, 0x8c, 0x14, 0x9b, 0x45, 0xf5, 0xab, 0xfc, 0xb9, 0x38, 0xb6, 0x36, 0xb6,
-	0xd5, 0x70, 0xe3, 0x25, 0x5f, 0x45, 0x5d, 0xe7, 0xa3, 0xc0, 0xef, 0x45, 0x32, 0x06, 0xdc, 0x8a,
-	0x48, 0x03, 0xa6, 0xdc, 0x78, 0x19, 0x0b, 0x1c, 0x4b, 0x55, 0x2e, 0xb0, 0x81, 0xba, 0xce, 0x93,
-	0x40, 0x1b, 0xe3, 0xdf, 0x45, 0xb7, 0x2f, 0x09, 0x92, 0x04, 0xfc, 0x15, 0x70, 0xd6, 0x2f, 0x17,
-	0xc9, 0x59, 0x15, 0x93, 0xd5, 0x44, 0x5d, 0xb3, 0x12, 0x69, 0xbe, 0x8e, 0xe6, 0x91, 0xec, 0x4a,
-	0x25, 0xcb, 0x8b, 0x99, 0x45, 0x2b, 0x85, 0x10, 0xe9, 0x19, 0xea, 0xe7, 0x4a, 0x64, 0x47, 0x3b,
-	0x98, 0x0a, 0x42, 0x3f, 0x90, 0xa1, 0x4a, 0x78, 0xd3, 0x6e, 0x16, 0x73, 0x8e, 0x46, 0x02, 0xa5,
-	0xab, 0x30, 0xd4, 0x22, 0x25, 0x14, 0xe1, 0xf4, 0x9a, 0xef, 0xa3, 0x75, 0xe8, 0xdf, 0x05, 0xe2,
-	0x3a, 0x53, 0x6c, 0x63, 0x3c, 0x52, 0x22, 0x54, 0xe9, 0x85, 0x75, 0x10, 0xef, 0x76, 0xbd, 0x3b,
-	0x4f, 0xa5, 0x17, 0xd2, 0x01, 0x9f, 0xc3, 0x4c, 0xc6, 0xa6, 0xfa, 0x5a, 0x68, 0x1c, 0x76, 0xfd,
-	0x28, 0xbd, 0x13, 0x9f, 0x41, 0x93, 0x62, 0x02, 0x28, 0xcc, 0x5d, 0x86, 0x9e, 0x92, 0x07, 0x42,
-	0x5d, 0xdf, 0xa6, 0x87, 0x3e, 0x47, 0x2d, 0xe8, 0xab, 0xf8, 0x9d, 0xaa, 0x5b, 0xf5, 0x9d, 0xf9,
-	0xed, 0xfc, 0xc1, 0xb7, 0xf3, 0x3e, 0xbb, 0x1a, 0xc3, 0x76, 0x31, 0xd9, 0xd1, 0x3d, 0x89, 0x16,
-	0x2b, 0x1a, 0xbe, 0x64, 0xc2, 0xad, 0x8e, 0xba, 0x29, 0x92, 0xb7, 0xc1, 0xcc, 0x53, 0xa9, 0x96,
-	0x36, 0xd8, 0xa9, 0xfc, 0xf1, 0x41, 0xc9, 0x50, 0x28, 0x3f, 0x4c, 0x4b, 0xbe, 0xc0, 0x13, 0x63,
-	0xf7, 0xd2, 0x53, 0xb7, 0xe7, 0x71, 0x0f, 0xde, 0xf5, 0x3a, 0x67, 0xa1, 0xbc, 0xf1, 0xee, 0x47,
-	0x77, 0x6a, 0x1e, 0x13, 0x81, 0x86, 0x51, 0xab, 0x28, 0xe2, 0xaf, 0xb1, 0xf9, 0x17, 0x55, 0x7a,
-	0xa6, 0x16, 0x2a, 0x5e, 0x47, 0x6b, 0xd6, 0xdc, 0x8a, 0xd7, 0xe1, 0x9b, 0x78, 0x90, 0xb2, 0x4e,
-	0xe5, 0x7d, 0xf6, 0x4e, 0x83, 0xb0, 0x2f, 0x68, 0xdb, 0x30, 0x92, 0x7b, 0x8c, 0xe9, 0x1b, 0xbf,
-	0xdf, 0xeb, 0xc4, 0x9b, 0xe4, 0x83, 0x7c, 0x23, 0x1d, 0xb9, 0x4a, 0xc1, 0xe8, 0x56, 0xcd, 0xd1,
-	0x7d, 0x9a, 0xab, 0xbf, 0x0f, 0xc3, 0xac, 0x57, 0x43, 0x55, 0x2c, 0xe0, 0xe1, 0x00, 0x8e, 0x5a,
-	0xbd, 0x85, 0xf9, 0xbc, 0xcf, 0x5d, 0x29, 0xb2, 0x09, 0x19, 0x92, 0x58, 0xc2, 0xc2, 0x10, 0x32,
-	0x11, 0xd9, 0xf9, 0x3d, 0x89, 0xa9, 0x23, 0xa1, 0xc4, 0x95, 0x88, 0x24, 0xdb, 0x43, 0xf5, 0x44,
-	0x44, 0xcc, 0x1a, 0x96, 0xfc, 0x6b, 0x70, 0x16, 0x86, 0xf6, 0xa9, 0x21, 0x7b, 0xa8, 0x1e, 0x4b,
-	0x65, 0xf3, 0xf2, 0x1f, 0xc1, 0xe6, 0x99, 0x3f, 0xc1, 0x1e, 0xaa, 0x67, 0xfd, 0x01, 0x5e, 0x3e,
-	0x9c, 0x36, 0xcf, 0xb0, 0x39, 0x7b, 0x8b, 0x89, 0x64, 0x28, 0x59, 0xf9, 0xa0, 0x3a, 0x4e, 0x51,
-	0x8a, 0x04, 0xde, 0xa0, 0x16, 0xfb, 0x98, 0x59, 0x27, 0x18, 0x9f, 0x80, 0xb3, 0x38, 0x9c, 0x20,
-	0xea, 0x01, 0x26, 0xc9, 0xa0, 0xcc, 0x3a, 0xc1, 0xf6, 0xbc, 0xf3, 0xa8, 0x30, 0x47, 0x1a, 0xfb,
-	0x18, 0xd7, 0x0e, 0x66, 0xd6, 0x31, 0xa6, 0xc9, 0x9d, 0xa5, 0x82, 0x0c, 0xb1, 0x3f, 0x02, 0xb9,
-	0xf1, 0xd8, 0xb2, 0x09, 0x1c, 0xfa, 0x06, 0x9c, 0x95, 0xb2, 0x34, 0x89, 0xfd, 0x1a, 0xc3, 0xf2,
-	0x48, 0x13, 0xb1, 0x17, 0xa6, 0xc2, 0xbf, 0xb8, 0xd8, 0x79, 0xf9, 0x1f, 0x0c, 0x2a, 0xe3, 0x13,
-	0x1a, 0xa6, 0xd5, 0xd8, 0xaa, 0x29, 0x51, 0xe0, 0x55, 0x67, 0xad, 0x1c, 0x40, 0x92, 0x17, 0x68,
-	0x5a, 0xbe, 0x61, 0x85, 0x14, 0xd3, 0x7a, 0xce, 0xfa, 0x08, 0x04, 0xa9, 0x7e, 0xc6, 0xcc, 0x80,
-	0x95, 0x18, 0x2f, 0x62, 0xd9, 0x8e, 0x74, 0x36, 0x46, 0x62, 0x12, 0xed, 0xab, 0x09, 0x9d, 0xde,
-	0xfd, 0x13, 0x00, 0x00, 0xff, 0xff, 0x91, 0xec, 0x29, 0xb2, 0xaa, 0x07, 0x00, 0x00,
+	// 808 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xed, 0x4e, 0xdb, 0x48,
+	0x14, 0x55, 0x3e, 0xf8, 0x3a, 0xf9, 0x00, 0x86, 0x6c, 0x02, 0xb3, 0x0b, 0x01, 0xb3, 0xec, 0x66,
+	0xb7, 0x2d, 0x6a, 0xa1, 0xa2, 0x45, 0x42, 0x42, 0x05, 0x2a, 0xa8, 0x2a, 0xa1, 0x34, 0x20, 0x21,
+	0x55, 0xfd, 0x63, 0xc8, 0xa0, 0x44, 0x0d, 0xb1, 0x6b, 0x4f, 0x5a, 0xfa, 0xbf, 0x8f, 0xd1, 0xc7,
+	0xec, 0x03, 0x54, 0x9e, 0x8c, 0xe3, 0x19, 0xdb, 0x13, 0xd2, 0xfe, 0xf3, 0xdc, 0x7b, 0xce, 0xb9,
+	0xe3, 0x7b, 0xef, 0x1c, 0x14, 0x3c, 0xf7, 0xa6, 0x7d, 0xbd, 0xed, 0x7a, 0x0e, 0x77, 0x08, 0xc4,
+	0x41, 0x7c, 0x5b, 0x6b, 0xc0, 0x99, 0xed, 0xb7, 0xd8, 0xa7, 0x01, 0xf3, 0x39, 0x59, 0x40, 0xee,
+	0x23, 0xfb, 0xba, 0x9c, 0x59, 0xcf, 0x34, 0x8a, 0xad, 0xe0, 0xd3, 0xaa, 0xa3, 0x20, 0xf2, 0xbe,
+	0xeb, 0xf4, 0x7d, 0x16, 0x00, 0x3a, 0xb6, 0x2f, 0x00, 0xb3, 0xad, 0xe0, 0x33, 0x10, 0x38, 0x65,
+	0xdc, 0x2c, 0xb0, 0x89, 0x82, 0xc8, 0x4b, 0x81, 0x0a, 0xa6, 0x3e, 0xdb, 0xbd, 0x01, 0x93, 0x90,
+	0xe1, 0xc1, 0x7a, 0x0e, 0x34, 0x07, 0x66, 0x91, 0x88, 0x95, 0x55, 0x59, 0x25, 0x14, 0x9a, 0x83,
+	0x91, 0xb4, 0xb5, 0x81, 0xd2, 0x09, 0xeb, 0x31, 0xce, 0xcc, 0x97, 0x59, 0x40, 0x39, 0x84, 0x48,
+	0xd2, 0x7f, 0x28, 0x5c, 0x70, 0x7b, 0x54, 0x9a, 0x62, 0xd6, 0xf5, 0x1c, 0x97, 0x79, 0x7c, 0xc8,
+	0x9b, 0x6b, 0x8d, 0xce, 0x96, 0x85, 0xe2, 0x10, 0x2a, 0x7f, 0x85, 0x20, 0xef, 0x73, 0x9b, 0x4b,
+	0x9c, 0xf8, 0xb6, 0x0e, 0x50, 0x3e, 0x76, 0xee, 0x5c, 0xfb, 0x66, 0xa4, 0x58, 0xc1, 0x94, 0xcf,
+	0x6d, 0x8f, 0x87, 0x3f, 0x2c, 0x0e, 0x41, 0xb4, 0xd7, 0xbd, 0xeb, 0xf2, 0xf0, 0x87, 0xc4, 0xc1,
+	0x5a, 0xc4, 0xfc, 0x88, 0x2d, 0xef, 0x57, 0x46, 0xf1, 0xb8, 0xe7, 0xf8, 0xe1, 0x3f, 0x59, 0xf3,
+	0x28, 0xc9, 0xb3, 0x04, 0x70, 0x2c, 0x5e, 0x79, 0x5d, 0xce, 0x8e, 0x6c, 0x7e, 0xd3, 0x09, 0x8b,
+	0xfe, 0x8f, 0xbc, 0x3b, 0xe0, 0xc1, 0x9c, 0x72, 0x8d, 0xc2, 0x4e, 0x75, 0x3b, 0x1a, 0xf8, 0x76,
+	0xd4, 0xe7, 0x96, 0xc0, 0x90, 0x5d, 0xcc, 0xb4, 0x45, 0x4f, 0xfc, 0xe5, 0xac, 0x80, 0xaf, 0xa8,
+	0x70, 0xad, 0xa3, 0xad, 0x10, 0x69, 0x55, 0x40, 0xd4, 0xaa, 0xf2, 0x2e, 0x15, 0x90, 0x73, 0xf6,
+	0xe5, 0x0d, 0x67, 0x9e, 0xcd, 0x1d, 0x2f, 0xbc, 0xf2, 0x25, 0xfe, 0x56, 0xa2, 0x57, 0x5d, 0xde,
+	0xb9, 0x08, 0x7a, 0xf0, 0xaa, 0xdf, 0x6e, 0x7a, 0xec, 0xb6, 0x7b, 0x3f, 0xbe, 0x53, 0x55, 0x4c,
+	0xbb, 0x02, 0x26, 0x5b, 0x25, 0x4f, 0xd6, 0x0b, 0x6c, 0x3d, 0xa0, 0x2a, 0xc7, 0x54, 0x46, 0xb6,
+	0xdb, 0x16, 0x9a, 0xf9, 0x56, 0xb6, 0xdb, 0xb6, 0xb6, 0xb0, 0x14, 0xb2, 0xce, 0xd9, 0xfd, 0x68,
+	0x4e, 0x71, 0xd8, 0x07, 0x54, 0x74, 0x98, 0x94, 0xfb, 0x0b, 0x73, 0xb7, 0xce, 0xa0, 0xdf, 0x0e,
+	0x82, 0xf2, 0x1d, 0x44, 0x81, 0x70, 0xe5, 0xb2, 0x29, 0xab, 0x9b, 0x53, 0x57, 0xf7, 0x9f, 0x48,
+	0xfd, 0xb5, 0xe7, 0x39, 0x9e, 0xe9, 0x16, 0x35, 0xfc, 0x11, 0xc3, 0xc9, 0x56, 0x37, 0x50, 0x8d,
+	0xfa, 0xdc, 0x63, 0xb6, 0xcf, 0x4c, 0x12, 0x2b, 0xa8, 0x25, 0x90, 0xda, 0xbc, 0x2e, 0xfa, 0xb6,
+	0xeb, 0x77, 0x9c, 0xb0, 0x13, 0x41, 0x83, 0xb4, 0xa8, 0xa1, 0x8f, 0x7b, 0x20, 0x21, 0x46, 0x71,
+	0x90, 0x18, 0x2a, 0xd9, 0x10, 0xeb, 0x5f, 0x2c, 0x69, 0x3c, 0xa3, 0xb3, 0x28, 0x05, 0x14, 0x87,
+	0x79, 0xb8, 0xc0, 0x23, 0x2c, 0x69, 0xbc, 0xb1, 0xce, 0xd3, 0xc3, 0xe3, 0x10, 0x3c, 0xd1, 0x92,
+	0xc6, 0xcb, 0x8f, 0x96, 0x36, 0x9b, 0xbe, 0xb4, 0x39, 0x6d, 0x69, 0x0f, 0xf1, 0x64, 0xc2, 0x6a,
+	0x86, 0xa6, 0x37, 0x50, 0x8d, 0x06, 0xf3, 0xd0, 0xd8, 0x13, 0xc8, 0xa1, 0xe8, 0xce, 0x0f, 0x60,
+	0xf6, 0xc4, 0xe6, 0xf6, 0xb5, 0xed, 0x33, 0xb2, 0x87, 0xdc, 0x99, 0xed, 0x13, 0xcd, 0x23, 0xa2,
+	0x79, 0xd2, 0x5a, 0x22, 0x2e, 0x6f, 0xb6, 0x87, 0xdc, 0x29, 0xe3, 0x3a, 0x2f, 0x1a, 0x13, 0xad,
+	0x25, 0xe2, 0x11, 0xaf, 0x39, 0xe0, 0xc4, 0xe0, 0x49, 0xb4, 0x96, 0x88, 0x4b, 0xde, 0x21, 0xa6,
+	0x87, 0x5e, 0x44, 0xcc, 0xfe, 0x44, 0x69, 0x5a, 0x4a, 0x0a, 0xec, 0x23, 0x1f, 0xd8, 0x37, 0xd1,
+	0x2a, 0x28, 0xde, 0x4f, 0x97, 0x93, 0x09, 0x49, 0x3d, 0xc2, 0x8c, 0xf4, 0x65, 0xa2, 0x55, 0xd0,
+	0xad, 0x9e, 0xfe, 0x99, 0x9a, 0x93, 0x1a, 0x07, 0x98, 0x12, 0xc6, 0x4d, 0xb4, 0x32, 0xaa, 0xb7,
+	0xd3, 0x95, 0x94, 0x8c, 0x64, 0xbf, 0x05, 0x22, 0xbf, 0x25, 0xab, 0x2a, 0x30, 0xe1, 0xfe, 0x74,
+	0xcd, 0x94, 0x96, 0x62, 0xdf, 0x32, 0x58, 0x1d, 0xbb, 0x7e, 0xe4, 0xa9, 0xaa, 0x30, 0xc9, 0xbb,
+	0xa0, 0xcf, 0x7e, 0x81, 0x21, 0xaf, 0xf1, 0x0e, 0x45, 0xd5, 0x61, 0x49, 0x5d, 0x95, 0x48, 0xb1,
+	0x68, 0xba, 0x6e, 0x06, 0x48, 0xc9, 0x4b, 0x94, 0x34, 0xbb, 0x24, 0xa9, 0x14, 0xd5, 0x71, 0xe9,
+	0xc6, 0x18, 0x84, 0x54, 0x7d, 0x8f, 0xf9, 0x98, 0x83, 0x12, 0x2b, 0x8d, 0xa5, 0xbf, 0x48, 0xba,
+	0x39, 0x16, 0x23, 0xb5, 0xcf, 0x51, 0x50, 0xcc, 0x96, 0xac, 0xc5, 0xda, 0x18, 0xf3, 0x66, 0x5a,
+	0x37, 0xe6, 0x23, 0x3d, 0xc5, 0x5d, 0x75, 0xbd, 0xa4, 0x5d, 0xd3, 0xba, 0x31, 0x9f, 0xd4, 0x0b,
+	0x9e, 0x7b, 0xaa, 0x9e, 0xf2, 0xec, 0xeb, 0xc6, 0xbc, 0xd4, 0xfb, 0x9e, 0xc1, 0xd6, 0x44, 0x16,
+	0x48, 0x5e, 0xa6, 0x49, 0x4d, 0xb4, 0x8b, 0xfb, 0xbf, 0xc1, 0x8c, 0x46, 0x1d, 0x73, 0x4d, 0x7d,
+	0xd4, 0xe9, 0xe6, 0x4b, 0x37, 0xc7, 0x62, 0x86, 0xda, 0xd7, 0xd3, 0x22, 0xbd, 0xfb, 0x73, 0x00,
+	0xe6, 0xec, 0xe1, 0x19, 0x8c, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1074,6 +1508,11 @@ type DatabaseClient interface {
 	IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error)
 	IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error)
 	IteratorRelease(ctx context.Context, in *IteratorReleaseRequest, opts ...grpc.CallOption) (*IteratorReleaseResponse, error)
+	NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error)
+	SnapshotHas(ctx context.Context, in *SnapshotHasRequest, opts ...grpc.CallOption) (*SnapshotHasResponse, error)
+	SnapshotGet(ctx context.Context, in *SnapshotGetRequest, opts ...grpc.CallOption) (*SnapshotGetResponse, error)
+	SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, in *SnapshotNewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*SnapshotNewIteratorWithStartAndPrefixResponse, error)
+	SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error)
 }
 
 type databaseClient struct {
@@ -1192,6 +1631,51 @@ func (c *databaseClient) IteratorRelease(ctx context.Context, in *IteratorReleas
 	return out, nil
 }
 
+func (c *databaseClient) NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error) {
+	out := new(NewSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/NewSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotHas(ctx context.Context, in *SnapshotHasRequest, opts ...grpc.CallOption) (*SnapshotHasResponse, error) {
+	out := new(SnapshotHasResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotHas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotGet(ctx context.Context, in *SnapshotGetRequest, opts ...grpc.CallOption) (*SnapshotGetResponse, error) {
+	out := new(SnapshotGetResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, in *SnapshotNewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*SnapshotNewIteratorWithStartAndPrefixResponse, error) {
+	out := new(SnapshotNewIteratorWithStartAndPrefixResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotNewIteratorWithStartAndPrefix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error) {
+	out := new(SnapshotReleaseResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatabaseServer is the server API for Database service.
 type DatabaseServer interface {
 	Has(context.Context, *HasRequest) (*HasResponse, error)
@@ -1206,6 +1690,11 @@ type DatabaseServer interface {
 	IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error)
 	IteratorError(context.Context, *IteratorErrorRequest) (*IteratorErrorResponse, error)
 	IteratorRelease(context.Context, *IteratorReleaseRequest) (*IteratorReleaseResponse, error)
+	NewSnapshot(context.Context, *NewSnapshotRequest) (*NewSnapshotResponse, error)
+	SnapshotHas(context.Context, *SnapshotHasRequest) (*SnapshotHasResponse, error)
+	SnapshotGet(context.Context, *SnapshotGetRequest) (*SnapshotGetResponse, error)
+	SnapshotNewIteratorWithStartAndPrefix(context.Context, *SnapshotNewIteratorWithStartAndPrefixRequest) (*SnapshotNewIteratorWithStartAndPrefixResponse, error)
+	SnapshotRelease(context.Context, *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error)
 }
 
 // UnimplementedDatabaseServer can be embedded to have forward compatible implementations.
@@ -1248,6 +1737,21 @@ func (*UnimplementedDatabaseServer) IteratorError(ctx context.Context, req *Iter
 func (*UnimplementedDatabaseServer) IteratorRelease(ctx context.Context, req *IteratorReleaseRequest) (*IteratorReleaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorRelease not implemented")
 }
+func (*UnimplementedDatabaseServer) NewSnapshot(ctx context.Context, req *NewSnapshotRequest) (*NewSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewSnapshot not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotHas(ctx context.Context, req *SnapshotHasRequest) (*SnapshotHasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotHas not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotGet(ctx context.Context, req *SnapshotGetRequest) (*SnapshotGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotGet not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, req *SnapshotNewIteratorWithStartAndPrefixRequest) (*SnapshotNewIteratorWithStartAndPrefixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotNewIteratorWithStartAndPrefix not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotRelease(ctx context.Context, req *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotRelease not implemented")
+}
 
 func RegisterDatabaseServer(s *grpc.Server, srv DatabaseServer) {
 	s.RegisterService(&_Database_serviceDesc, srv)
@@ -1469,6 +1973,96 @@ func _Database_IteratorRelease_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/NewSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewSnapshot(ctx, req.(*NewSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotHas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotHasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotHas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotHas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotHas(ctx, req.(*SnapshotHasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotGet(ctx, req.(*SnapshotGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotNewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotNewIteratorWithStartAndPrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithStartAndPrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotNewIteratorWithStartAndPrefix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithStartAndPrefix(ctx, req.(*SnapshotNewIteratorWithStartAndPrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, req.(*SnapshotReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Database_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcdbproto.Database",
 	HandlerType: (*DatabaseServer)(nil),
@@ -1521,6 +2115,26 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IteratorRelease",
 			Handler:    _Database_IteratorRelease_Handler,
 		},
+		{
+			MethodName: "NewSnapshot",
+			Handler:    _Database_NewSnapshot_Handler,
+		},
+		{
+			MethodName: "SnapshotHas",
+			Handler:    _Database_SnapshotHas_Handler,
+		},
+		{
+			MethodName: "SnapshotGet",
+			Handler:    _Database_SnapshotGet_Handler,
+		},
+		{
+			MethodName: "SnapshotNewIteratorWithStartAndPrefix",
+			Handler:    _Database_SnapshotNewIteratorWithStartAndPrefix_Handler,
+		},
+		{
+			MethodName: "SnapshotRelease",
+			Handler:    _Database_SnapshotRelease_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpcdb.proto",
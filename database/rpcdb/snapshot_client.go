@@ -0,0 +1,90 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcdb
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/nodb"
+	"github.com/ava-labs/gecko/database/rpcdb/rpcdbproto"
+)
+
+// Snapshot takes a snapshot of the remote database
+func (db *DatabaseClient) Snapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &rpcdbproto.NewSnapshotRequest{})
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db: db,
+		id: resp.Id,
+	}, nil
+}
+
+// snapshot is a snapshot of the remote database, referred to by its ID
+type snapshot struct {
+	db *DatabaseClient
+	id uint64
+}
+
+// Has returns if the key was set when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	resp, err := s.db.client.SnapshotHas(context.Background(), &rpcdbproto.SnapshotHasRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return false, updateError(err)
+	}
+	return resp.Has, nil
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	resp, err := s.db.client.SnapshotGet(context.Background(), &rpcdbproto.SnapshotGetRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return resp.Value, nil
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns an iterator over the snapshot
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	resp, err := s.db.client.SnapshotNewIteratorWithStartAndPrefix(context.Background(), &rpcdbproto.SnapshotNewIteratorWithStartAndPrefixRequest{
+		Id:     s.id,
+		Start:  start,
+		Prefix: prefix,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: updateError(err)}
+	}
+	return &iterator{
+		db: s.db,
+		id: resp.Id,
+	}
+}
+
+// Release releases the snapshot on the remote database
+func (s *snapshot) Release() {
+	s.db.client.SnapshotRelease(context.Background(), &rpcdbproto.SnapshotReleaseRequest{
+		Id: s.id,
+	})
+}
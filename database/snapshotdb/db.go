@@ -0,0 +1,162 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshotdb
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/nodb"
+)
+
+var (
+	errReadOnly = errors.New("snapshot database is read-only")
+)
+
+// Database is a read-only database that reads from a snapshot. It allows code
+// written against database.Database to read a point in time view of a
+// database. Writes return an error.
+type Database struct {
+	lock     sync.RWMutex
+	snapshot database.Snapshot
+}
+
+// New returns a database that reads from [snapshot]. Closing the database
+// releases the snapshot.
+func New(snapshot database.Snapshot) *Database { return &Database{snapshot: snapshot} }
+
+// Has implements the Database interface
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return false, database.ErrClosed
+	}
+	return db.snapshot.Has(key)
+}
+
+// Get implements the Database interface
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return nil, database.ErrClosed
+	}
+	return db.snapshot.Get(key)
+}
+
+// Put returns an error
+func (db *Database) Put(key, value []byte) error { return errReadOnly }
+
+// Delete returns an error
+func (db *Database) Delete(key []byte) error { return errReadOnly }
+
+// NewBatch returns a batch that can't be written
+func (db *Database) NewBatch() database.Batch { return &batch{} }
+
+// NewIterator implements the Database interface
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Database interface
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Database interface
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Database interface
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.snapshot.NewIteratorWithStartAndPrefix(start, prefix)
+}
+
+// Stat implements the Database interface
+func (db *Database) Stat(string) (string, error) { return "", database.ErrNotFound }
+
+// Compact implements the Database interface
+func (db *Database) Compact(_, _ []byte) error { return nil }
+
+// DeleteRange returns an error
+func (db *Database) DeleteRange(_, _ []byte) error { return errReadOnly }
+
+// DeletePrefix returns an error
+func (db *Database) DeletePrefix([]byte) error { return errReadOnly }
+
+// ApproximateSize implements the Database interface
+func (db *Database) ApproximateSize(prefix []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return 0, database.ErrClosed
+	}
+	return database.SizeByIteration(db.snapshot, prefix)
+}
+
+// Snapshot implements the Database interface. As the database never changes,
+// the snapshot reads from the database itself.
+func (db *Database) Snapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return nil, database.ErrClosed
+	}
+	return &snapshot{Database: db}, nil
+}
+
+// Close releases the snapshot
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.snapshot == nil {
+		return database.ErrClosed
+	}
+	db.snapshot.Release()
+	db.snapshot = nil
+	return nil
+}
+
+// snapshot of a database that never changes. Releasing it doesn't release the
+// database.
+type snapshot struct{ *Database }
+
+func (*snapshot) Release() {}
+
+// batch returns an error when it's written
+type batch struct{ size int }
+
+func (b *batch) Put(_, value []byte) error {
+	b.size += len(value)
+	return nil
+}
+
+func (b *batch) Delete([]byte) error {
+	b.size++
+	return nil
+}
+
+func (b *batch) ValueSize() int { return b.size }
+
+func (b *batch) Write() error { return errReadOnly }
+
+func (b *batch) Reset() { b.size = 0 }
+
+func (b *batch) Replay(database.KeyValueWriter) error { return nil }
+
+func (b *batch) Inner() database.Batch { return b }
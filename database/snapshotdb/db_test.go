@@ -0,0 +1,88 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshotdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/prefixdb"
+)
+
+func TestReads(t *testing.T) {
+	baseDB := memdb.New()
+	key := []byte("hello")
+	value := []byte("world")
+	if err := prefixdb.New([]byte("prefix"), baseDB).Put(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := baseDB.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := New(snapshot)
+
+	if err := baseDB.Put(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	// Prefixing the snapshot database reads the same keys as prefixing the
+	// base database
+	prefixedDB := prefixdb.New([]byte("prefix"), db)
+	if v, err := prefixedDB.Get(key); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(v, value) {
+		t.Fatalf("Get returned 0x%x ; Expected: 0x%x", v, value)
+	}
+	if has, err := db.Has(key); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("Writes after the snapshot was taken shouldn't be visible")
+	}
+	if keys, err := database.CountKeys(db, nil); err != nil {
+		t.Fatal(err)
+	} else if keys != 1 {
+		t.Fatalf("CountKeys returned %d keys ; Expected: %d", keys, 1)
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := prefixedDB.Get(key); err != database.ErrClosed {
+		t.Fatalf("Expected %s on Get after Close", database.ErrClosed)
+	}
+	if err := db.Close(); err != database.ErrClosed {
+		t.Fatalf("Expected %s on Close after Close", database.ErrClosed)
+	}
+}
+
+func TestWrites(t *testing.T) {
+	snapshot, err := memdb.New().Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := New(snapshot)
+	defer db.Close()
+
+	key := []byte("hello")
+	if err := db.Put(key, nil); err == nil {
+		t.Fatalf("Put should have errored")
+	}
+	if err := db.Delete(key); err == nil {
+		t.Fatalf("Delete should have errored")
+	}
+	if err := db.DeletePrefix(nil); err == nil {
+		t.Fatalf("DeletePrefix should have errored")
+	}
+	batch := db.NewBatch()
+	if err := batch.Put(key, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Write(); err == nil {
+		t.Fatalf("Writing a batch should have errored")
+	}
+}
@@ -28,6 +28,14 @@ var (
 		TestIteratorClosed,
 		TestStatNoPanic,
 		TestCompactNoPanic,
+		TestDeleteRange,
+		TestDeletePrefix,
+		TestApproximateSize,
+		TestRangeClosed,
+		TestSnapshot,
+		TestSnapshotIterator,
+		TestSnapshotRelease,
+		TestSnapshotClosed,
 	}
 )
 
@@ -765,3 +773,280 @@ func TestCompactNoPanic(t *testing.T, db Database) {
 
 	db.Compact(nil, nil)
 }
+
+// putRangeKeys puts keys in [db] that surround the range ["b", "c") and the
+// prefix "b"
+func putRangeKeys(t *testing.T, db Database) [][]byte {
+	keys := [][]byte{
+		[]byte("a"),
+		[]byte("b"),
+		[]byte("b\x00"),
+		[]byte("ba"),
+		[]byte("b\xff"),
+		[]byte("c"),
+		[]byte("c\x00"),
+	}
+	for _, key := range keys {
+		if err := db.Put(key, []byte("value")); err != nil {
+			t.Fatalf("Unexpected error on db.Put: %s", err)
+		}
+	}
+	return keys
+}
+
+// checkKeys fails the test if the keys of [db] aren't [expected]
+func checkKeys(t *testing.T, db Database, expected [][]byte) {
+	iterator := db.NewIterator()
+	defer iterator.Release()
+
+	keys := [][]byte(nil)
+	for iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	if err := iterator.Error(); err != nil {
+		t.Fatalf("Unexpected error on iterator.Error: %s", err)
+	}
+	if len(keys) != len(expected) {
+		t.Fatalf("Returned keys: %q ; Expected: %q", keys, expected)
+	}
+	for i, key := range keys {
+		if !bytes.Equal(key, expected[i]) {
+			t.Fatalf("Returned keys: %q ; Expected: %q", keys, expected)
+		}
+	}
+}
+
+// TestDeleteRange ...
+func TestDeleteRange(t *testing.T, db Database) {
+	keys := putRangeKeys(t, db)
+
+	if err := db.DeleteRange([]byte("b\x00"), []byte("c")); err != nil {
+		t.Fatalf("Unexpected error on db.DeleteRange: %s", err)
+	}
+	checkKeys(t, db, [][]byte{keys[0], keys[1], keys[5], keys[6]})
+
+	if err := db.DeleteRange([]byte("c"), nil); err != nil {
+		t.Fatalf("Unexpected error on db.DeleteRange: %s", err)
+	}
+	checkKeys(t, db, [][]byte{keys[0], keys[1]})
+
+	if err := db.DeleteRange(nil, nil); err != nil {
+		t.Fatalf("Unexpected error on db.DeleteRange: %s", err)
+	}
+	checkKeys(t, db, nil)
+}
+
+// TestDeletePrefix ...
+func TestDeletePrefix(t *testing.T, db Database) {
+	keys := putRangeKeys(t, db)
+
+	if err := db.DeletePrefix([]byte("b")); err != nil {
+		t.Fatalf("Unexpected error on db.DeletePrefix: %s", err)
+	}
+	checkKeys(t, db, [][]byte{keys[0], keys[5], keys[6]})
+
+	if err := db.DeletePrefix([]byte("d")); err != nil {
+		t.Fatalf("Unexpected error on db.DeletePrefix: %s", err)
+	}
+	checkKeys(t, db, [][]byte{keys[0], keys[5], keys[6]})
+
+	if err := db.DeletePrefix(nil); err != nil {
+		t.Fatalf("Unexpected error on db.DeletePrefix: %s", err)
+	}
+	checkKeys(t, db, nil)
+}
+
+// TestApproximateSize ...
+func TestApproximateSize(t *testing.T, db Database) {
+	putRangeKeys(t, db)
+
+	// Sizes are estimates, which may not include data that hasn't been
+	// flushed yet, but a prefix can't take up more than the whole database
+	prefixSize, err := db.ApproximateSize([]byte("b"))
+	if err != nil {
+		t.Fatalf("Unexpected error on db.ApproximateSize: %s", err)
+	}
+	totalSize, err := db.ApproximateSize(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error on db.ApproximateSize: %s", err)
+	}
+	if prefixSize > totalSize {
+		t.Fatalf("db.ApproximateSize returned %d bytes for a prefix of a database of %d bytes", prefixSize, totalSize)
+	}
+
+	if keys, err := CountKeys(db, []byte("b")); err != nil {
+		t.Fatalf("Unexpected error on CountKeys: %s", err)
+	} else if keys != 4 {
+		t.Fatalf("CountKeys returned %d keys ; Expected: %d", keys, 4)
+	}
+	if keys, err := CountKeys(db, nil); err != nil {
+		t.Fatalf("Unexpected error on CountKeys: %s", err)
+	} else if keys != 7 {
+		t.Fatalf("CountKeys returned %d keys ; Expected: %d", keys, 7)
+	}
+
+	if err := db.DeletePrefix([]byte("b")); err != nil {
+		t.Fatalf("Unexpected error on db.DeletePrefix: %s", err)
+	}
+
+	if keys, err := CountKeys(db, []byte("b")); err != nil {
+		t.Fatalf("Unexpected error on CountKeys: %s", err)
+	} else if keys != 0 {
+		t.Fatalf("CountKeys returned %d keys ; Expected: %d", keys, 0)
+	}
+}
+
+// TestRangeClosed ...
+func TestRangeClosed(t *testing.T, db Database) {
+	putRangeKeys(t, db)
+
+	if err := db.Close(); err != nil {
+		t.Fatalf("Unexpected error on db.Close: %s", err)
+	}
+
+	if err := db.DeleteRange(nil, nil); err != ErrClosed {
+		t.Fatalf("Expected %s on db.DeleteRange", ErrClosed)
+	} else if err := db.DeletePrefix(nil); err != ErrClosed {
+		t.Fatalf("Expected %s on db.DeletePrefix", ErrClosed)
+	} else if _, err := db.ApproximateSize(nil); err != ErrClosed {
+		t.Fatalf("Expected %s on db.ApproximateSize", ErrClosed)
+	}
+}
+
+// TestSnapshot ...
+func TestSnapshot(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	value1Updated := []byte("world1 updated")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := db.Snapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.Snapshot: %s", err)
+	}
+	defer snapshot.Release()
+
+	if err := db.Put(key1, value1Updated); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	if v, err := snapshot.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(v, value1) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value1)
+	} else if has, err := snapshot.Has(key2); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Has: %s", err)
+	} else if has {
+		t.Fatalf("snapshot.Has unexpectedly returned true on key %s", key2)
+	} else if v, err := snapshot.Get(key2); err != ErrNotFound {
+		t.Fatalf("Expected %s on snapshot.Get for missing key %s. Returned 0x%x", ErrNotFound, key2, v)
+	}
+
+	if err := db.Delete(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	}
+
+	if has, err := snapshot.Has(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Has: %s", err)
+	} else if !has {
+		t.Fatalf("snapshot.Has unexpectedly returned false on key %s", key1)
+	}
+
+	if v, err := db.Get(key1); err != ErrNotFound {
+		t.Fatalf("Expected %s on db.Get for missing key %s. Returned 0x%x", ErrNotFound, key1, v)
+	} else if v, err := db.Get(key2); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(v, value2) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value2)
+	}
+}
+
+// TestSnapshotIterator ...
+func TestSnapshotIterator(t *testing.T, db Database) {
+	keys := putRangeKeys(t, db)
+
+	snapshot, err := db.Snapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.Snapshot: %s", err)
+	}
+	defer snapshot.Release()
+
+	if err := db.DeletePrefix([]byte("b")); err != nil {
+		t.Fatalf("Unexpected error on db.DeletePrefix: %s", err)
+	} else if err := db.Put([]byte("bb"), []byte("value")); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := snapshot.NewIteratorWithPrefix([]byte("b"))
+	defer iterator.Release()
+
+	for _, key := range keys[1:5] {
+		if !iterator.Next() {
+			t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+		} else if k := iterator.Key(); !bytes.Equal(k, key) {
+			t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", k, key)
+		}
+	}
+	if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
+// TestSnapshotRelease ...
+func TestSnapshotRelease(t *testing.T, db Database) {
+	key := []byte("hello")
+	value := []byte("world")
+
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := db.Snapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.Snapshot: %s", err)
+	}
+	snapshot.Release()
+	snapshot.Release()
+
+	if _, err := snapshot.Has(key); err != ErrClosed {
+		t.Fatalf("Expected %s on snapshot.Has after release", ErrClosed)
+	} else if _, err := snapshot.Get(key); err != ErrClosed {
+		t.Fatalf("Expected %s on snapshot.Get after release", ErrClosed)
+	}
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != ErrClosed {
+		t.Fatalf("Expected %s on iterator.Error", ErrClosed)
+	}
+
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(v, value) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
+
+// TestSnapshotClosed ...
+func TestSnapshotClosed(t *testing.T, db Database) {
+	if err := db.Close(); err != nil {
+		t.Fatalf("Unexpected error on db.Close: %s", err)
+	}
+
+	if _, err := db.Snapshot(); err != ErrClosed {
+		t.Fatalf("Expected %s on db.Snapshot", ErrClosed)
+	}
+}
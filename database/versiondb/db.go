@@ -115,26 +115,7 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.mem == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
-
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
-	}
-	sort.Strings(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, 0, len(keys))
-	for _, key := range keys {
-		values = append(values, db.mem[key])
-	}
-
-	return &iterator{
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
-		keys:     keys,
-		values:   values,
-	}
+	return newIterator(db.mem, db.db, start, prefix)
 }
 
 // Stat implements the database.Database interface
@@ -159,6 +140,48 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(start, limit)
 }
 
+// DeleteRange implements the Database interface. The deletions are kept in
+// memory until they are committed.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	return database.DeleteRangeByIteration(db, start, limit)
+}
+
+// DeletePrefix implements the Database interface. The deletions are kept in
+// memory until they are committed.
+func (db *Database) DeletePrefix(prefix []byte) error {
+	return database.DeleteRangeByIteration(db, prefix, database.PrefixLimit(prefix))
+}
+
+// ApproximateSize implements the Database interface
+func (db *Database) ApproximateSize(prefix []byte) (uint64, error) {
+	return database.SizeByIteration(db, prefix)
+}
+
+// Snapshot implements the database.Database interface. The snapshot copies the
+// uncommitted changes and reads everything else from a snapshot of the
+// underlying database.
+func (db *Database) Snapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
+	}
+
+	dbSnapshot, err := db.db.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	mem := make(map[string]valueDelete, len(db.mem))
+	for key, value := range db.mem {
+		mem[key] = value
+	}
+	return &snapshot{
+		mem: mem,
+		db:  dbSnapshot,
+	}, nil
+}
+
 // SetDatabase changes the underlying database to the specified database
 func (db *Database) SetDatabase(newDB database.Database) error {
 	db.lock.Lock()
@@ -319,6 +342,30 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 // Inner returns itself
 func (b *batch) Inner() database.Batch { return b }
 
+// newIterator returns an iterator over the keys of [mem] and [db] that start
+// with [prefix] and are at least [start]
+func newIterator(mem map[string]valueDelete, db database.Iteratee, start, prefix []byte) *iterator {
+	startString := string(start)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if strings.HasPrefix(key, prefixString) && key >= startString {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys) // Keys need to be in sorted order
+	values := make([]valueDelete, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, mem[key])
+	}
+
+	return &iterator{
+		Iterator: db.NewIteratorWithStartAndPrefix(start, prefix),
+		keys:     keys,
+		values:   values,
+	}
+}
+
 // iterator walks over both the in memory database and the underlying database
 // at the same time.
 type iterator struct {
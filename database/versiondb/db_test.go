@@ -9,6 +9,7 @@ import (
 
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/utils"
 )
 
 func TestInterface(t *testing.T) {
@@ -363,3 +364,46 @@ func TestSetDatabaseClosed(t *testing.T) {
 		t.Fatalf("Unexpected database from db.GetDatabase")
 	}
 }
+
+func TestDeletePrefixCommit(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	// Spans several batches of deletions
+	numKeys := 2500
+	prefix := []byte("prefix")
+	for i := 0; i < numKeys; i++ {
+		key := append(utils.CopyBytes(prefix), byte(i>>8), byte(i))
+		if err := baseDB.Put(key, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := baseDB.Put([]byte("other"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.DeletePrefix(prefix); err != nil {
+		t.Fatal(err)
+	}
+
+	if keys, err := database.CountKeys(db, prefix); err != nil {
+		t.Fatal(err)
+	} else if keys != 0 {
+		t.Fatalf("Expected no keys with the prefix but found %d", keys)
+	}
+	if keys, err := database.CountKeys(baseDB, prefix); err != nil {
+		t.Fatal(err)
+	} else if keys != uint64(numKeys) {
+		t.Fatalf("Deletions shouldn't have been written before committing")
+	}
+
+	if err := db.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if keys, err := database.CountKeys(baseDB, nil); err != nil {
+		t.Fatal(err)
+	} else if keys != 1 {
+		t.Fatalf("Expected 1 key to be left but found %d", keys)
+	}
+}
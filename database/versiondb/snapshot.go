@@ -0,0 +1,88 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package versiondb
+
+import (
+	"sync"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/nodb"
+	"github.com/ava-labs/gecko/utils"
+)
+
+// snapshot is a read-only view of the database. It reads the changes that
+// weren't committed when it was taken from [mem], and everything else from a
+// snapshot of the underlying database.
+type snapshot struct {
+	lock sync.RWMutex
+	mem  map[string]valueDelete
+	db   database.Snapshot
+}
+
+// Has implements the database.Snapshot interface
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.db.Has(key)
+}
+
+// Get implements the database.Snapshot interface
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return utils.CopyBytes(val.value), nil
+	}
+	return s.db.Get(key)
+}
+
+// NewIterator implements the database.Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the database.Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the database.Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the database.Snapshot interface
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(s.mem, s.db, start, prefix)
+}
+
+// Release implements the database.Snapshot interface
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.mem == nil {
+		return
+	}
+	s.mem = nil
+	s.db.Release()
+}
@@ -4,7 +4,10 @@
 package genesis
 
 import (
+	"time"
+
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/units"
 )
 
 // Note that since an AVA network has exactly one Platform Chain,
@@ -13,11 +16,18 @@ import (
 // state of the Platform Chain is the same as defining the genesis
 // state of the network.
 
-// Config contains the genesis addresses used to construct a genesis
+// Config contains the genesis addresses used to construct a genesis, and the
+// parameters every node of the network must agree on
 type Config struct {
 	MintAddresses, FundedAddresses, StakerIDs                   []string
 	ParsedMintAddresses, ParsedFundedAddresses, ParsedStakerIDs []ids.ShortID
 	EVMBytes                                                    []byte
+
+	// AVMTxFee is the amount of AVA burned by every AVM transaction verified
+	// at or after AVMTxFeeActivation. Transactions accepted before then didn't
+	// burn a fee.
+	AVMTxFee           uint64
+	AVMTxFeeActivation time.Time
 }
 
 func (c *Config) init() error {
@@ -165,7 +175,10 @@ var (
 			0x30, 0x30, 0x30, 0x30, 0x30, 0x30, 0x30, 0x22,
 			0x7d,
 		},
+		AVMTxFee:           units.MilliAva,
+		AVMTxFeeActivation: time.Date(2020, time.September, 1, 0, 0, 0, 0, time.UTC),
 	}
+	// Local networks don't charge AVM transaction fees
 	DefaultConfig = Config{
 		MintAddresses: []string{},
 		FundedAddresses: []string{
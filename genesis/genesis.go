@@ -233,7 +233,7 @@ func AVAAssetID(networkID uint32) (ids.ID, error) {
 		return ids.ID{}, err
 	}
 
-	c := codec.NewDefault()
+	c := codec.NewDefaultManager(avm.CodecVersion)
 	errs := wrappers.Errs{}
 	errs.Add(
 		c.RegisterType(&avm.BaseTx{}),
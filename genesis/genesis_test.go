@@ -7,12 +7,26 @@ import (
 	"testing"
 
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/vms/avm"
 	"github.com/ava-labs/gecko/vms/platformvm"
 	"github.com/ava-labs/gecko/vms/spchainvm"
 	"github.com/ava-labs/gecko/vms/spdagvm"
 )
 
+func TestNetworkHRP(t *testing.T) {
+	tests := map[uint32]string{
+		MainnetID: formatting.MainnetHRP,
+		CascadeID: formatting.CascadeHRP,
+		LocalID:   formatting.LocalHRP,
+	}
+	for networkID, expected := range tests {
+		if hrp := formatting.NetworkHRP(networkID); hrp != expected {
+			t.Fatalf("Network %d has human readable part %s ; Expected: %s", networkID, hrp, expected)
+		}
+	}
+}
+
 func TestNetworkName(t *testing.T) {
 	if name := NetworkName(MainnetID); name != MainnetName {
 		t.Fatalf("NetworkID was incorrectly named. Result: %s ; Expected: %s", name, MainnetName)
@@ -0,0 +1,161 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// envPrefix is prepended to the upper snake case name of a flag to get the
+	// environment variable that sets it. For example, GECKO_HTTP_PORT sets
+	// http-port.
+	envPrefix = "GECKO_"
+
+	configFileKey = "config-file"
+	dumpConfigKey = "dump-config"
+)
+
+// flagSources tracks where the value of each flag came from, so that errors
+// about a value can point the user at the place it was set
+type flagSources map[string]string
+
+// describe returns [name] along with where its value was set
+func (s flagSources) describe(name string) string {
+	if source, ok := s[name]; ok {
+		return fmt.Sprintf("%s (set by %s)", name, source)
+	}
+	return name
+}
+
+// envVarName returns the environment variable that sets the flag [name]
+func envVarName(name string) string {
+	return envPrefix + strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
+
+// applyConfigSources sets the flags of [fs] that weren't set on the command
+// line. A flag is set from its GECKO_ environment variable if it exists, and
+// otherwise from the config file, if one was provided. Flags set nowhere keep
+// their defaults.
+//
+// Assumes [fs] has already parsed the command line.
+func applyConfigSources(fs *flag.FlagSet, lookupEnv func(string) (string, bool)) (flagSources, error) {
+	sources := flagSources{}
+	fs.Visit(func(f *flag.Flag) { sources[f.Name] = "command line" })
+
+	set := func(name, value, source string) error {
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid value %q for %s from %s: %w", value, name, source, err)
+		}
+		sources[name] = source
+		return nil
+	}
+
+	// The config file can itself be provided through the environment
+	if _, ok := sources[configFileKey]; !ok {
+		envVar := envVarName(configFileKey)
+		if value, ok := lookupEnv(envVar); ok {
+			if err := set(configFileKey, value, "environment variable "+envVar); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	fileValues := map[string]string{}
+	if configFile := fs.Lookup(configFileKey).Value.String(); configFile != "" {
+		values, err := readConfigFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+		for name := range values {
+			if name == configFileKey || fs.Lookup(name) == nil {
+				return nil, fmt.Errorf("config file %s: unknown option %q", configFile, name)
+			}
+		}
+		fileValues = values
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if _, ok := sources[f.Name]; ok || err != nil {
+			return
+		}
+		envVar := envVarName(f.Name)
+		if value, ok := lookupEnv(envVar); ok {
+			err = set(f.Name, value, "environment variable "+envVar)
+		} else if value, ok := fileValues[f.Name]; ok {
+			err = set(f.Name, value, "config file "+fs.Lookup(configFileKey).Value.String())
+		}
+	})
+	return sources, err
+}
+
+// readConfigFile returns the flag values in the JSON or YAML file at [path],
+// keyed by flag name. Lists are joined with commas, so that options such as
+// bootstrap-ips can be written as lists.
+func readConfigFile(path string) (map[string]string, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read config file: %w", err)
+	}
+
+	raw := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(contents, &raw)
+	default:
+		err = yaml.Unmarshal(contents, &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for name, value := range raw {
+		str, err := configValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("config file %s: option %q: %w", path, name, err)
+		}
+		values[name] = str
+	}
+	return values, nil
+}
+
+// configValue returns the flag representation of a value in a config file
+func configValue(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case int:
+		return strconv.Itoa(value), nil
+	case uint64:
+		return strconv.FormatUint(value, 10), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case []interface{}:
+		elems := make([]string, len(value))
+		for i, elem := range value {
+			str, err := configValue(elem)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = str
+		}
+		return strings.Join(elems, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
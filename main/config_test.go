@@ -0,0 +1,210 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestFlagSet returns a flag set with a few of the node's flags, parsed
+// from [args]
+func newTestFlagSet(t *testing.T, args ...string) (*flag.FlagSet, *uint, *string) {
+	fs := flag.NewFlagSet("gecko", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.String(configFileKey, "", "")
+	httpPort := fs.Uint("http-port", 9650, "")
+	logLevel := fs.String("log-level", "info", "")
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return fs, httpPort, logLevel
+}
+
+// newTestDir returns a new temporary directory, which the caller must remove
+func newTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "gecko-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// writeConfigFile writes [contents] to a file named [name] in [dir] and
+// returns the file's path
+func writeConfigFile(t *testing.T, dir, name, contents string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// lookupEnv returns a lookup function for the environment [env]
+func lookupEnv(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+func TestApplyConfigSourcesPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		env        map[string]string
+		file       string
+		httpPort   uint
+		portSource string
+	}{
+		{
+			name:     "defaults",
+			httpPort: 9650,
+		},
+		{
+			name:       "config file",
+			file:       "http-port: 1",
+			httpPort:   1,
+			portSource: "config file",
+		},
+		{
+			name:       "environment over config file",
+			env:        map[string]string{"GECKO_HTTP_PORT": "2"},
+			file:       "http-port: 1",
+			httpPort:   2,
+			portSource: "environment variable GECKO_HTTP_PORT",
+		},
+		{
+			name:       "command line over environment and config file",
+			args:       []string{"--http-port=3"},
+			env:        map[string]string{"GECKO_HTTP_PORT": "2"},
+			file:       "http-port: 1",
+			httpPort:   3,
+			portSource: "command line",
+		},
+	}
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := test.args
+			if test.file != "" {
+				args = append(args, "--config-file="+writeConfigFile(t, dir, "config.yaml", test.file))
+			}
+			fs, httpPort, logLevel := newTestFlagSet(t, args...)
+
+			sources, err := applyConfigSources(fs, lookupEnv(test.env))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *httpPort != test.httpPort {
+				t.Fatalf("http-port should be %d, got %d", test.httpPort, *httpPort)
+			}
+			source, set := sources["http-port"]
+			switch {
+			case test.portSource == "" && set:
+				t.Fatalf("http-port shouldn't have been set, but was set by %q", source)
+			case !strings.HasPrefix(source, test.portSource):
+				t.Fatalf("http-port should be set by %q, got %q", test.portSource, source)
+			}
+			if *logLevel != "info" {
+				t.Fatalf("log-level should keep its default, got %q", *logLevel)
+			}
+		})
+	}
+}
+
+func TestApplyConfigSourcesConfigFileFromEnvironment(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	path := writeConfigFile(t, dir, "config.json", `{"log-level": "debug", "http-port": 4}`)
+	fs, httpPort, logLevel := newTestFlagSet(t)
+
+	sources, err := applyConfigSources(fs, lookupEnv(map[string]string{"GECKO_CONFIG_FILE": path}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *httpPort != 4 || *logLevel != "debug" {
+		t.Fatalf("Config file values weren't applied: http-port=%d, log-level=%q", *httpPort, *logLevel)
+	}
+	if source := sources.describe("log-level"); source != "log-level (set by config file "+path+")" {
+		t.Fatalf("Wrong description of log-level: %q", source)
+	}
+}
+
+func TestApplyConfigSourcesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		file string // written to config.yaml if not empty
+		args []string
+
+		// Substrings the error must contain
+		errContains []string
+	}{
+		{
+			name:        "unknown key",
+			file:        "http-prot: 1",
+			errContains: []string{"config file", "unknown option \"http-prot\""},
+		},
+		{
+			name:        "config file can't set the config file",
+			file:        "config-file: other.yaml",
+			errContains: []string{"unknown option \"config-file\""},
+		},
+		{
+			name:        "invalid value in config file",
+			file:        "http-port: many",
+			errContains: []string{"http-port", "config file", "config.yaml"},
+		},
+		{
+			name:        "unsupported value in config file",
+			file:        "http-port: {port: 1}",
+			errContains: []string{"option \"http-port\"", "unsupported value"},
+		},
+		{
+			name:        "invalid value in environment",
+			env:         map[string]string{"GECKO_HTTP_PORT": "many"},
+			errContains: []string{"http-port", "environment variable GECKO_HTTP_PORT"},
+		},
+		{
+			name:        "missing config file",
+			args:        []string{"--config-file=" + filepath.Join(os.TempDir(), "gecko-missing", "config.yaml")},
+			errContains: []string{"couldn't read config file"},
+		},
+		{
+			name:        "malformed config file",
+			file:        "http-port: [1",
+			errContains: []string{"couldn't parse config file", "config.yaml"},
+		},
+	}
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := test.args
+			if test.file != "" {
+				args = append(args, "--config-file="+writeConfigFile(t, dir, "config.yaml", test.file))
+			}
+			fs, _, _ := newTestFlagSet(t, args...)
+
+			_, err := applyConfigSources(fs, lookupEnv(test.env))
+			if err == nil {
+				t.Fatalf("Should have errored")
+			}
+			for _, substr := range test.errContains {
+				if !strings.Contains(err.Error(), substr) {
+					t.Fatalf("Error %q should contain %q", err, substr)
+				}
+			}
+		})
+	}
+}
@@ -4,10 +4,10 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"path"
 
-	"github.com/ava-labs/gecko/nat"
 	"github.com/ava-labs/gecko/node"
 	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/logging"
@@ -22,6 +22,16 @@ func main() {
 		return
 	}
 
+	if DumpConfig {
+		configJSON, err := json.MarshalIndent(&Config, "", "    ")
+		if err != nil {
+			fmt.Printf("couldn't marshal the config: %s\n", err)
+			return
+		}
+		fmt.Println(string(configJSON))
+		return
+	}
+
 	config := Config.LoggingConfig
 	config.Directory = path.Join(config.Directory, "node")
 	factory := logging.NewFactory(config)
@@ -38,7 +48,14 @@ func main() {
 
 	defer log.Stop()
 	defer log.StopOnPanic()
-	defer Config.DB.Close()
+
+	// Once the node is initialized, it closes the database when it shuts down
+	nodeInitialized := false
+	defer func() {
+		if !nodeInitialized {
+			Config.DB.Close()
+		}
+	}()
 
 	if Config.StakingIP.IsZero() {
 		log.Warn("NAT traversal has failed. If this node becomes a staker, it may lose its reward due to being unreachable.")
@@ -65,12 +82,6 @@ func main() {
 		log.Warn("assertions are enabled. This may slow down execution")
 	}
 
-	mapper := nat.NewDefaultMapper(log, Config.Nat, nat.TCP, "gecko")
-	defer mapper.UnmapAllPorts()
-
-	mapper.MapPort(Config.StakingIP.Port, Config.StakingIP.Port)
-	mapper.MapPort(Config.HTTPPort, Config.HTTPPort)
-
 	log.Debug("initializing node state")
 	// MainNode is a global variable in the node.go file
 	if err := node.MainNode.Initialize(&Config, log, factory); err != nil {
@@ -84,6 +95,7 @@ func main() {
 		return
 	}
 
+	nodeInitialized = true
 	defer node.MainNode.Shutdown()
 
 	log.Debug("Dispatching node handlers")
@@ -12,6 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ava-labs/gecko/database/leveldb"
 	"github.com/ava-labs/gecko/database/memdb"
@@ -35,6 +36,7 @@ const (
 // Results of parsing the CLI
 var (
 	Config                 = node.Config{}
+	DumpConfig             bool
 	Err                    error
 	defaultDbDir           = os.ExpandEnv(filepath.Join("$HOME", ".gecko", "db"))
 	defaultStakingKeyPath  = os.ExpandEnv(filepath.Join("$HOME", ".gecko", "staking", "staker.key"))
@@ -43,6 +45,8 @@ var (
 
 var (
 	errBootstrapMismatch = errors.New("more bootstrap IDs provided than bootstrap IPs")
+	errAuthPassword      = errors.New("api-auth-password must be set when api-auth-required is true")
+	errManualNoIP        = errors.New("public-ip must be set when nat is manual")
 )
 
 // GetIPs returns the default IPs for each network
@@ -73,6 +77,10 @@ func init() {
 
 	fs := flag.NewFlagSet("gecko", flag.ContinueOnError)
 
+	// Config:
+	fs.String(configFileKey, "", "Path to a JSON or YAML file of flag values, keyed by flag name. Values set on the command line or through "+envPrefix+"* environment variables take precedence")
+	fs.BoolVar(&DumpConfig, dumpConfigKey, false, "If true, print the effective node configuration as JSON and exit")
+
 	// NetworkID:
 	networkName := fs.String("network-id", genesis.CascadeName, "Network ID this node will connect to")
 
@@ -88,9 +96,13 @@ func init() {
 	// Database:
 	db := fs.Bool("db-enabled", true, "Turn on persistent storage")
 	dbDir := fs.String("db-dir", defaultDbDir, "Database directory for Ava state")
+	fs.DurationVar(&Config.SlowDBThreshold, "db-slow-call-threshold", 500*time.Millisecond, "Calls to a chain's database, and iterators, that take at least this long are logged. If 0, they aren't logged")
 
 	// IP:
 	consensusIP := fs.String("public-ip", "", "Public IP of this node")
+	fs.StringVar(&Config.NATMethod, "nat", nat.Auto, "NAT traversal method. One of auto, none, upnp, pmp and manual. manual requires public-ip to be set")
+	ipResolutionService := fs.String("public-ip-resolution-service", "", "URL of a service that responds with the public IP of the caller. If set, it's used to resolve the public IP instead of the NAT router")
+	fs.DurationVar(&Config.IPResolutionFreq, "public-ip-resolution-frequency", 5*time.Minute, "Time between re-resolutions of the public IP")
 
 	// HTTP Server:
 	httpPort := fs.Uint("http-port", 9650, "Port of the HTTP server")
@@ -115,6 +127,7 @@ func init() {
 	logsDir := fs.String("log-dir", "", "Logging directory for Ava")
 	logLevel := fs.String("log-level", "info", "The log level. Should be one of {verbo, debug, info, warn, error, fatal, off}")
 	logDisplayLevel := fs.String("log-display-level", "", "The log display level. If left blank, will inherit the value of log-level. Otherwise, should be one of {verbo, debug, info, warn, error, fatal, off}")
+	logFormat := fs.String("log-format", "text", "The format of log lines. Should be one of {text, json}")
 
 	fs.IntVar(&Config.ConsensusParams.K, "snow-sample-size", 5, "Number of nodes to query for each network poll")
 	fs.IntVar(&Config.ConsensusParams.Alpha, "snow-quorum-size", 4, "Alpha value to use for required number positive results")
@@ -129,6 +142,26 @@ func init() {
 	fs.BoolVar(&Config.KeystoreAPIEnabled, "api-keystore-enabled", true, "If true, this node exposes the Keystore API")
 	fs.BoolVar(&Config.MetricsAPIEnabled, "api-metrics-enabled", true, "If true, this node exposes the Metrics API")
 	fs.BoolVar(&Config.IPCEnabled, "api-ipcs-enabled", false, "If true, IPCs can be opened")
+	fs.Uint64Var(&Config.IPCLogSize, "api-ipcs-log-size", 100000, "Number of accepted containers kept in the event log of each chain published over IPC")
+	fs.BoolVar(&Config.HealthAPIEnabled, "api-health-enabled", true, "If true, this node exposes the Health API")
+
+	// API Authorization:
+	fs.BoolVar(&Config.APIRequireAuthorization, "api-auth-required", false, "If true, API calls must carry a token issued by the auth API")
+	fs.StringVar(&Config.APIAuthPassword, "api-auth-password", "", "Password of the auth API. Prefer setting it through the "+envPrefix+"API_AUTH_PASSWORD environment variable or a config file")
+
+	// Health:
+	fs.DurationVar(&Config.HealthCheckFreq, "health-check-frequency", 30*time.Second, "Time between health checks")
+
+	// Shutdown:
+	fs.DurationVar(&Config.ShutdownTimeout, "shutdown-timeout", 10*time.Second, "Time given to in-flight API calls, and then to queued consensus messages, to finish when the node shuts down")
+
+	// Indexing:
+	fs.BoolVar(&Config.TxIndexEnabled, "tx-index-enabled", false, "If true, the AVM indexes the transactions it accepts by address. Only transactions accepted while enabled are indexed")
+
+	// Caches:
+	fs.IntVar(&Config.AVMStateCacheSize, "avm-state-cache-size", 32*1024*1024, "Number of bytes of state the AVM caches")
+	fs.IntVar(&Config.AVMIDCacheSize, "avm-id-cache-size", 10000, "Number of state IDs the AVM caches per type of state")
+	fs.IntVar(&Config.AVMTxCacheSize, "avm-tx-cache-size", 10000, "Number of parsed transactions the AVM caches")
 
 	// Throughput Server
 	throughputPort := fs.Uint("xput-server-port", 9652, "Port of the deprecated throughput test server")
@@ -146,15 +179,23 @@ func init() {
 		os.Exit(2)
 	}
 
-	networkID, err := genesis.NetworkID(*networkName)
+	sources, err := applyConfigSources(fs, os.LookupEnv)
 	if errs.Add(err); err != nil {
 		return
 	}
 
+	networkID, err := genesis.NetworkID(*networkName)
+	if err != nil {
+		errs.Add(fmt.Errorf("%s: %w", sources.describe("network-id"), err))
+		return
+	}
+
 	Config.NetworkID = networkID
 
 	// DB:
-	if *db {
+	// The database isn't opened when only dumping the config, so that the
+	// config of a running node can be inspected.
+	if *db && !DumpConfig {
 		*dbDir = os.ExpandEnv(*dbDir) // parse any env variables
 		dbPath := path.Join(*dbDir, genesis.NetworkName(Config.NetworkID), dbVersion)
 		db, err := leveldb.New(dbPath, 0, 0, 0)
@@ -167,21 +208,49 @@ func init() {
 		Config.DB = memdb.New()
 	}
 
-	Config.Nat = nat.NewRouter()
+	if err := nat.VerifyMethod(Config.NATMethod); err != nil {
+		errs.Add(fmt.Errorf("%s: %w", sources.describe("nat"), err))
+		return
+	}
+	if Config.NATMethod == nat.Manual && *consensusIP == "" {
+		errs.Add(errManualNoIP)
+		return
+	}
+
+	// Discovering a NAT router and resolving the public IP both query the
+	// network, so neither is done when only dumping the config
+	if !DumpConfig {
+		Config.Nat, err = nat.GetRouter(Config.NATMethod)
+		if err != nil {
+			errs.Add(fmt.Errorf("%s: %w", sources.describe("nat"), err))
+			return
+		}
+
+		// The public IP is re-resolved periodically, unless it was given
+		// explicitly or the NAT traversal method can't resolve it
+		switch {
+		case *ipResolutionService != "":
+			Config.IPResolver = nat.NewHTTPResolver(*ipResolutionService)
+		case *consensusIP == "" && Config.NATMethod != nat.None:
+			Config.IPResolver = Config.Nat
+		}
+	}
 
 	var ip net.IP
-	// If public IP is not specified, get it using shell command dig
-	if *consensusIP == "" {
-		ip, err = Config.Nat.IP()
+	switch {
+	case *consensusIP != "":
+		ip = net.ParseIP(*consensusIP)
+	case Config.IPResolver != nil:
+		ip, err = Config.IPResolver.IP()
 		if err != nil {
 			ip = net.IPv4zero // Couldn't get my IP...set to 0.0.0.0
 		}
-	} else {
-		ip = net.ParseIP(*consensusIP)
+	default:
+		ip = net.IPv4zero
 	}
 
 	if ip == nil {
-		errs.Add(fmt.Errorf("Invalid IP Address %s", *consensusIP))
+		errs.Add(fmt.Errorf("%s: Invalid IP Address %s", sources.describe("public-ip"), *consensusIP))
 		return
 	}
 
@@ -198,7 +267,7 @@ func init() {
 		if ip != "" {
 			addr, err := utils.ToIPDesc(ip)
 			if err != nil {
-				errs.Add(fmt.Errorf("couldn't parse ip: %w", err))
+				errs.Add(fmt.Errorf("%s: couldn't parse ip: %w", sources.describe("bootstrap-ips"), err))
 				return
 			}
 			Config.BootstrapPeers = append(Config.BootstrapPeers, &node.Peer{
@@ -221,12 +290,12 @@ func init() {
 			if id != "" {
 				err = cb58.FromString(id)
 				if err != nil {
-					errs.Add(fmt.Errorf("couldn't parse bootstrap peer id to bytes: %w", err))
+					errs.Add(fmt.Errorf("%s: couldn't parse bootstrap peer id to bytes: %w", sources.describe("bootstrap-ids"), err))
 					return
 				}
 				peerID, err := ids.ToShortID(cb58.Bytes)
 				if err != nil {
-					errs.Add(fmt.Errorf("couldn't parse bootstrap peer id: %w", err))
+					errs.Add(fmt.Errorf("%s: couldn't parse bootstrap peer id: %w", sources.describe("bootstrap-ids"), err))
 					return
 				}
 				if len(Config.BootstrapPeers) <= i {
@@ -260,6 +329,8 @@ func init() {
 			errs.Add(fmt.Errorf("couldn't find staking certificate at %s", Config.StakingCertFile))
 			return
 		}
+	case DumpConfig:
+		// Dumping the config shouldn't create files
 	default:
 		// Only creates staking key/cert if [stakingKeyPath] doesn't exist
 		if err := staking.GenerateStakingKeyCert(Config.StakingKeyFile, Config.StakingCertFile); err != nil {
@@ -271,12 +342,18 @@ func init() {
 	// HTTP:
 	Config.HTTPPort = uint16(*httpPort)
 
+	if Config.APIRequireAuthorization && Config.APIAuthPassword == "" {
+		errs.Add(errAuthPassword)
+		return
+	}
+
 	// Logging:
 	if *logsDir != "" {
 		loggingConfig.Directory = *logsDir
 	}
 	logFileLevel, err := logging.ToLevel(*logLevel)
-	if errs.Add(err); err != nil {
+	if err != nil {
+		errs.Add(fmt.Errorf("%s: %w", sources.describe("log-level"), err))
 		return
 	}
 	loggingConfig.LogLevel = logFileLevel
@@ -285,11 +362,21 @@ func init() {
 		*logDisplayLevel = *logLevel
 	}
 	displayLevel, err := logging.ToLevel(*logDisplayLevel)
-	if errs.Add(err); err != nil {
+	if err != nil {
+		errs.Add(fmt.Errorf("%s: %w", sources.describe("log-display-level"), err))
 		return
 	}
 	loggingConfig.DisplayLevel = displayLevel
 
+	switch *logFormat {
+	case "text":
+	case "json":
+		loggingConfig.JSONFormat = true
+	default:
+		errs.Add(fmt.Errorf("%s: unknown log format: %s", sources.describe("log-format"), *logFormat))
+		return
+	}
+
 	Config.LoggingConfig = loggingConfig
 
 	// Throughput:
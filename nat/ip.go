@@ -0,0 +1,172 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/utils/timer"
+)
+
+const (
+	// resolverTimeout is how long a resolver service has to respond
+	resolverTimeout = 10 * time.Second
+
+	// maxResolverResponseSize is the largest response read from a resolver
+	// service
+	maxResolverResponseSize = 1024
+)
+
+// IPResolver resolves the public IP of this node. Routers are IPResolvers.
+type IPResolver interface {
+	IP() (net.IP, error)
+}
+
+// httpResolver resolves the public IP through a service that responds to GET
+// requests with the IP of the caller, such as https://api.ipify.org
+type httpResolver struct {
+	url    string
+	client http.Client
+}
+
+// NewHTTPResolver returns a resolver that asks the service at [url] for the
+// public IP of this node. The service must respond with the IP as plain text.
+func NewHTTPResolver(url string) IPResolver {
+	return &httpResolver{
+		url:    url,
+		client: http.Client{Timeout: resolverTimeout},
+	}
+}
+
+func (r *httpResolver) IP() (net.IP, error) {
+	resp, err := r.client.Get(r.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("resolver service responded with status %d", resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, resp.Body, maxResolverResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, fmt.Errorf("resolver service responded with invalid IP %q", body)
+	}
+	return ip, nil
+}
+
+// DynamicIP tracks the public IP of this node, re-resolving it periodically
+// so that a change of IP is noticed
+type DynamicIP struct {
+	log      logging.Logger
+	resolver IPResolver
+	clock    timer.Clock
+	repeater *timer.Repeater
+
+	lock sync.Mutex
+	ip   net.IP
+	// lastResolved is when the IP was last resolved successfully
+	lastResolved time.Time
+	// lastErr is the error of the last resolution, if it failed
+	lastErr error
+	// onChange are called with the new IP when it changes
+	onChange []func(net.IP)
+}
+
+// NewDynamicIP returns a tracker of the public IP of this node, which is
+// initially [ip], that re-resolves it with [resolver] every [frequency] once
+// dispatched
+func NewDynamicIP(log logging.Logger, resolver IPResolver, ip net.IP, frequency time.Duration) *DynamicIP {
+	d := &DynamicIP{
+		log:      log,
+		resolver: resolver,
+		ip:       ip,
+	}
+	d.repeater = timer.NewRepeater(d.Resolve, frequency)
+	return d
+}
+
+// OnChange registers [f] to be called with the new IP whenever it changes
+func (d *DynamicIP) OnChange(f func(net.IP)) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.onChange = append(d.onChange, f)
+}
+
+// Dispatch re-resolves the IP periodically until Stop is called
+func (d *DynamicIP) Dispatch() { d.repeater.Dispatch() }
+
+// Stop re-resolving the IP
+func (d *DynamicIP) Stop() { d.repeater.Stop() }
+
+// Resolve the IP, and notify the registered functions if it changed
+func (d *DynamicIP) Resolve() {
+	ip, err := d.resolver.IP()
+
+	d.lock.Lock()
+	d.lastErr = err
+	if err != nil {
+		d.lock.Unlock()
+		d.log.Warn("couldn't resolve the public IP: %s", err)
+		return
+	}
+	d.lastResolved = d.clock.Time()
+	if ip.Equal(d.ip) {
+		d.lock.Unlock()
+		return
+	}
+	oldIP := d.ip
+	d.ip = ip
+	onChange := d.onChange
+	d.lock.Unlock()
+
+	d.log.Info("public IP changed from %s to %s", oldIP, ip)
+	for _, f := range onChange {
+		f(ip)
+	}
+}
+
+// IP returns the current public IP of this node
+func (d *DynamicIP) IP() net.IP {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.ip
+}
+
+// Status returns the current public IP, when it was last resolved, and the
+// error of the last resolution, if it failed
+func (d *DynamicIP) Status() (net.IP, time.Time, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.ip, d.lastResolved, d.lastErr
+}
+
+// Status describes the NAT traversal of this node
+type Status struct {
+	// Method is the NAT traversal method
+	Method string
+	// IP is the current public IP
+	IP net.IP
+	// LastResolved is when the public IP was last resolved, or the zero time
+	// if it never was
+	LastResolved time.Time
+	// Err is the error of the last resolution of the public IP, if it failed
+	Err error
+	// Mappings are the ports mapped on the NAT router
+	Mappings []Mapping
+}
@@ -0,0 +1,135 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/gecko/utils/logging"
+)
+
+// resolverService is a local stand-in for a public IP resolution service
+type resolverService struct {
+	lock   sync.Mutex
+	ip     string
+	status int
+}
+
+func (s *resolverService) set(ip string, status int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.ip = ip
+	s.status = status
+}
+
+func (s *resolverService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	w.WriteHeader(s.status)
+	w.Write([]byte(s.ip + "\n"))
+}
+
+func TestHTTPResolver(t *testing.T) {
+	service := &resolverService{}
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	resolver := NewHTTPResolver(server.URL)
+
+	service.set("1.2.3.4", http.StatusOK)
+	if ip, err := resolver.IP(); err != nil {
+		t.Fatal(err)
+	} else if !ip.Equal(net.IPv4(1, 2, 3, 4)) {
+		t.Fatalf("Resolved %s, expected 1.2.3.4", ip)
+	}
+
+	service.set("not an ip", http.StatusOK)
+	if _, err := resolver.IP(); err == nil {
+		t.Fatalf("Should have failed to parse the response")
+	}
+
+	service.set("1.2.3.4", http.StatusInternalServerError)
+	if _, err := resolver.IP(); err == nil {
+		t.Fatalf("Should have failed due to the response status")
+	}
+}
+
+func TestDynamicIP(t *testing.T) {
+	service := &resolverService{}
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	d := NewDynamicIP(logging.NoLog{}, NewHTTPResolver(server.URL), net.IPv4(1, 2, 3, 4), time.Hour)
+
+	changes := []net.IP(nil)
+	d.OnChange(func(ip net.IP) { changes = append(changes, ip) })
+
+	service.set("1.2.3.4", http.StatusOK)
+	d.Resolve()
+	if len(changes) != 0 {
+		t.Fatalf("Notified of a change when the IP didn't change")
+	}
+	ip, lastResolved, err := d.Status()
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case !ip.Equal(net.IPv4(1, 2, 3, 4)):
+		t.Fatalf("Wrong IP %s", ip)
+	case lastResolved.IsZero():
+		t.Fatalf("Resolution time should have been recorded")
+	}
+
+	service.set("5.6.7.8", http.StatusOK)
+	d.Resolve()
+	if len(changes) != 1 || !changes[0].Equal(net.IPv4(5, 6, 7, 8)) {
+		t.Fatalf("Should have been notified of the change to 5.6.7.8, got %v", changes)
+	}
+	if ip := d.IP(); !ip.Equal(net.IPv4(5, 6, 7, 8)) {
+		t.Fatalf("Wrong IP %s", ip)
+	}
+
+	service.set("", http.StatusServiceUnavailable)
+	d.Resolve()
+	if len(changes) != 1 {
+		t.Fatalf("Notified of a change when resolving failed")
+	}
+	ip, _, err = d.Status()
+	if err == nil {
+		t.Fatalf("Failed resolution should have been recorded")
+	}
+	if !ip.Equal(net.IPv4(5, 6, 7, 8)) {
+		t.Fatalf("IP shouldn't change when resolving fails, got %s", ip)
+	}
+}
+
+func TestGetRouter(t *testing.T) {
+	if _, err := GetRouter("carrier pigeon"); !errors.Is(err, errUnknownMethod) {
+		t.Fatalf("Should have failed with %s, got %v", errUnknownMethod, err)
+	}
+	if err := VerifyMethod("carrier pigeon"); !errors.Is(err, errUnknownMethod) {
+		t.Fatalf("Should have failed with %s, got %v", errUnknownMethod, err)
+	}
+	for _, method := range []string{Auto, None, UPnP, PMP, Manual} {
+		if err := VerifyMethod(method); err != nil {
+			t.Fatalf("%s should be a valid method, got %s", method, err)
+		}
+	}
+	for _, method := range []string{None, Manual} {
+		router, err := GetRouter(method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := router.IP(); err == nil {
+			t.Fatalf("%s router shouldn't resolve an IP", method)
+		}
+	}
+}
@@ -20,6 +20,21 @@ const (
 type Mapper interface {
 	MapPort(newInternalPort, newExternalPort uint16) error
 	UnmapAllPorts() error
+
+	// Mappings returns the status of the ports this mapper maps
+	Mappings() []Mapping
+}
+
+// Mapping is the status of a port mapping
+type Mapping struct {
+	InternalPort uint16
+	ExternalPort uint16
+	// Mapped is true if the last attempt to map the port succeeded
+	Mapped bool
+	// LastUpdated is when the port was last attempted to be mapped
+	LastUpdated time.Time
+	// Err is the error of the last attempt to map the port, if it failed
+	Err error
 }
 
 type mapper struct {
@@ -34,6 +49,9 @@ type mapper struct {
 	wg      sync.WaitGroup
 	errLock sync.Mutex
 	errs    wrappers.Errs
+
+	mappingsLock sync.Mutex
+	mappings     []*Mapping
 }
 
 // NewMapper returns a new mapper that can map ports on a router
@@ -77,12 +95,24 @@ func NewDefaultMapper(
 // MapPort maps a local port to a port on the router until UnmapAllPorts is
 // called.
 func (m *mapper) MapPort(newInternalPort, newExternalPort uint16) error {
+	mapping := &Mapping{
+		InternalPort: newInternalPort,
+		ExternalPort: newExternalPort,
+	}
+
+	m.mappingsLock.Lock()
+	m.mappings = append(m.mappings, mapping)
+	m.mappingsLock.Unlock()
+
 	m.wg.Add(1)
-	go m.mapPort(newInternalPort, newExternalPort)
+	go m.mapPort(mapping)
 	return nil
 }
 
-func (m *mapper) mapPort(newInternalPort, newExternalPort uint16) {
+func (m *mapper) mapPort(mapping *Mapping) {
+	newInternalPort := mapping.InternalPort
+	newExternalPort := mapping.ExternalPort
+
 	// duration is set to 0 here so that the select case will execute
 	// immediately
 	updateTimer := time.NewTimer(0)
@@ -96,6 +126,10 @@ func (m *mapper) mapPort(newInternalPort, newExternalPort uint16) {
 			newExternalPort))
 		m.errLock.Unlock()
 
+		m.mappingsLock.Lock()
+		mapping.Mapped = false
+		m.mappingsLock.Unlock()
+
 		m.log.Debug("Unmapped external port %d to internal port %d",
 			newExternalPort,
 			newInternalPort)
@@ -113,6 +147,12 @@ func (m *mapper) mapPort(newInternalPort, newExternalPort uint16) {
 				m.mappingNames,
 				m.mappingTimeout)
 
+			m.mappingsLock.Lock()
+			mapping.Mapped = err == nil
+			mapping.LastUpdated = time.Now()
+			mapping.Err = err
+			m.mappingsLock.Unlock()
+
 			if err != nil {
 				m.errLock.Lock()
 				m.errs.Add(err)
@@ -141,3 +181,14 @@ func (m *mapper) UnmapAllPorts() error {
 	m.wg.Wait()
 	return m.errs.Err
 }
+
+func (m *mapper) Mappings() []Mapping {
+	m.mappingsLock.Lock()
+	defer m.mappingsLock.Unlock()
+
+	mappings := make([]Mapping, len(m.mappings))
+	for i, mapping := range m.mappings {
+		mappings[i] = *mapping
+	}
+	return mappings
+}
@@ -6,6 +6,8 @@
 package nat
 
 import (
+	"errors"
+	"fmt"
 	"net"
 	"time"
 )
@@ -19,6 +21,26 @@ const (
 	UDP NetworkProtocol = "UDP"
 )
 
+// Methods of NAT traversal a node can be configured with
+const (
+	// Auto uses UPnP or NAT-PMP, whichever is discovered first
+	Auto = "auto"
+	// None doesn't use a NAT router
+	None = "none"
+	// UPnP uses a UPnP router
+	UPnP = "upnp"
+	// PMP uses a NAT-PMP router
+	PMP = "pmp"
+	// Manual doesn't use a NAT router, and relies on the public IP the node
+	// was configured with
+	Manual = "manual"
+)
+
+var (
+	errUnknownMethod = errors.New("unknown NAT traversal method")
+	errNotDiscovered = errors.New("couldn't discover a NAT router")
+)
+
 // Router provides a standard NAT router functions. Specifically, allowing the
 // fetching of public IPs and port forwarding to this computer.
 type Router interface {
@@ -45,6 +67,43 @@ type Router interface {
 	IP() (net.IP, error)
 }
 
+// VerifyMethod returns an error if [method] isn't one of auto, none, upnp, pmp
+// and manual. Unlike GetRouter, it doesn't query the network.
+func VerifyMethod(method string) error {
+	switch method {
+	case Auto, None, UPnP, PMP, Manual:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errUnknownMethod, method)
+	}
+}
+
+// GetRouter returns the router used by the NAT traversal [method], which is
+// one of auto, none, upnp, pmp and manual. The none and manual methods, and
+// the auto method if no router is discovered, use a router that can't map
+// ports or resolve IPs.
+func GetRouter(method string) (Router, error) {
+	if err := VerifyMethod(method); err != nil {
+		return nil, err
+	}
+
+	var router Router
+	switch method {
+	case Auto:
+		return NewRouter(), nil
+	case None, Manual:
+		return noRouter{}, nil
+	case UPnP:
+		router = getUPnPRouter()
+	default:
+		router = getPMPRouter()
+	}
+	if router == nil {
+		return nil, fmt.Errorf("%w using %s", errNotDiscovered, method)
+	}
+	return router, nil
+}
+
 // NewRouter returns a new router discovered on the local network
 func NewRouter() Router {
 	routers := make(chan Router)
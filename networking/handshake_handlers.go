@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -37,6 +38,7 @@ import (
 	"github.com/ava-labs/gecko/utils/logging"
 	"github.com/ava-labs/gecko/utils/random"
 	"github.com/ava-labs/gecko/utils/timer"
+	"github.com/ava-labs/gecko/utils/wrappers"
 )
 
 /*
@@ -100,6 +102,9 @@ const (
 	// ReconnectTimeout is the amount of time to wait to reconnect to a staker
 	// before giving up
 	ReconnectTimeout = 10 * time.Minute
+	// ClockSkewSamples is the number of the most recent peer clock
+	// differences that are used to estimate this node's clock skew
+	ClockSkewSamples = 64
 )
 
 // Manager is the struct that will be accessed on event calls
@@ -120,13 +125,16 @@ type Handshake struct {
 
 	log           logging.Logger
 	vdrs          validators.Set         // set of current validators in the AVAnet
-	myAddr        salticidae.NetAddr     // IP I communicate to peers
 	myID          ids.ShortID            // ID that identifies myself as a staker or not
 	net           salticidae.PeerNetwork // C messaging network
 	enableStaking bool                   // Should only be false for local tests
 
 	clock timer.Clock
 
+	// IP I communicate to peers, which changes if my public IP changes
+	myAddrLock sync.RWMutex
+	myAddr     salticidae.NetAddr
+
 	// Connections that I have added by IP, but haven't gotten an ID from
 	requestedLock    sync.Mutex
 	requested        map[string]struct{}
@@ -147,6 +155,12 @@ type Handshake struct {
 	// If any chain is blocked on connecting to peers, track these blockers here
 	awaitingLock sync.Mutex
 	awaiting     []*networking.AwaitingConnections
+
+	// The differences, in seconds, between the clocks of the most recent peers
+	// to send a version message and this node's clock
+	clockSkewLock  sync.Mutex
+	clockSkews     []float64
+	clockSkewIndex int
 }
 
 // Initialize to the c networking library. This should only be done once during
@@ -357,6 +371,40 @@ func (nm *Handshake) gossipPeerList() {
 // connected to this node.
 func (nm *Handshake) Connections() Connections { return nm.connections }
 
+// ClockSkew returns the median difference between the clocks of recently
+// handshaked peers and this node's clock, along with the number of peers the
+// estimate is based on. A positive skew means this node's clock is behind.
+func (nm *Handshake) ClockSkew() (time.Duration, int) {
+	nm.clockSkewLock.Lock()
+	skews := append([]float64(nil), nm.clockSkews...)
+	nm.clockSkewLock.Unlock()
+
+	if len(skews) == 0 {
+		return 0, 0
+	}
+
+	sort.Float64s(skews)
+	median := skews[len(skews)/2]
+	if len(skews)%2 == 0 {
+		median = (median + skews[len(skews)/2-1]) / 2
+	}
+	return time.Duration(median * float64(time.Second)), len(skews)
+}
+
+// recordClockSkew tracks that a peer's clock was [skew] seconds ahead of this
+// node's clock
+func (nm *Handshake) recordClockSkew(skew float64) {
+	nm.clockSkewLock.Lock()
+	defer nm.clockSkewLock.Unlock()
+
+	if len(nm.clockSkews) < ClockSkewSamples {
+		nm.clockSkews = append(nm.clockSkews, skew)
+		return
+	}
+	nm.clockSkews[nm.clockSkewIndex] = skew
+	nm.clockSkewIndex = (nm.clockSkewIndex + 1) % ClockSkewSamples
+}
+
 // Shutdown the network
 func (nm *Handshake) Shutdown() {
 	nm.versionTimeout.Stop()
@@ -376,7 +424,7 @@ func (nm *Handshake) SendGetVersion(peer salticidae.PeerID) {
 // SendVersion to the requested peer
 func (nm *Handshake) SendVersion(peer salticidae.PeerID) error {
 	build := Builder{}
-	v, err := build.Version(nm.networkID, nm.clock.Unix(), toIPDesc(nm.myAddr), ClientVersion)
+	v, err := build.Version(nm.networkID, nm.clock.Unix(), toIPDesc(nm.getMyAddr()), ClientVersion)
 	if err != nil {
 		return fmt.Errorf("packing version failed due to: %w", err)
 	}
@@ -385,6 +433,39 @@ func (nm *Handshake) SendVersion(peer salticidae.PeerID) error {
 	return nil
 }
 
+// SetIP changes the IP I communicate to peers to [ip], and sends my connected
+// peers a Version message so that they learn of the change
+func (nm *Handshake) SetIP(ip utils.IPDesc) error {
+	cErr := salticidae.NewError()
+	addr := salticidae.NewNetAddrFromIPPortString(ip.String(), true, &cErr)
+	if code := cErr.GetCode(); code != 0 {
+		return fmt.Errorf("couldn't parse %s: %s", ip, salticidae.StrError(code))
+	}
+
+	nm.myAddrLock.Lock()
+	nm.myAddr = addr
+	nm.myAddrLock.Unlock()
+
+	peers := nm.connections.PeerIDs()
+	nm.log.Info("advertising %s as my IP to %d peer(s)", ip, len(peers))
+
+	errs := wrappers.Errs{}
+	for _, peer := range peers {
+		errs.Add(nm.SendVersion(peer))
+	}
+	return errs.Err
+}
+
+// IP returns the IP I communicate to peers
+func (nm *Handshake) IP() utils.IPDesc { return toIPDesc(nm.getMyAddr()) }
+
+func (nm *Handshake) getMyAddr() salticidae.NetAddr {
+	nm.myAddrLock.RLock()
+	defer nm.myAddrLock.RUnlock()
+
+	return nm.myAddr
+}
+
 // SendPeerList to the requested peer
 func (nm *Handshake) SendPeerList(peers ...salticidae.PeerID) error {
 	if len(peers) == 0 {
@@ -691,6 +772,12 @@ func version(_msg *C.struct_msg_t, _conn *C.struct_msgnetwork_conn_t, _ unsafe.P
 
 	id, exists := HandshakeNet.pending.GetID(peer)
 	if !exists {
+		if id, exists := HandshakeNet.connections.GetID(peer); exists {
+			// A connected peer sends another Version message when its IP
+			// changes
+			updateIP(peer, id, msg)
+			return
+		}
 		HandshakeNet.log.Debug("dropping Version message because the peer isn't pending")
 		return
 	}
@@ -713,7 +800,9 @@ func version(_msg *C.struct_msg_t, _conn *C.struct_msgnetwork_conn_t, _ unsafe.P
 	}
 
 	myTime := float64(HandshakeNet.clock.Unix())
-	if peerTime := float64(pMsg.Get(MyTime).(uint64)); math.Abs(peerTime-myTime) > MaxClockDifference.Seconds() {
+	peerTime := float64(pMsg.Get(MyTime).(uint64))
+	HandshakeNet.recordClockSkew(peerTime - myTime)
+	if math.Abs(peerTime-myTime) > MaxClockDifference.Seconds() {
 		HandshakeNet.log.Debug("peer's clock is too far out of sync with mine. Peer's = %d, Ours = %d (seconds)", uint64(peerTime), uint64(myTime))
 
 		HandshakeNet.net.DelPeer(peer)
@@ -759,6 +848,30 @@ func version(_msg *C.struct_msg_t, _conn *C.struct_msgnetwork_conn_t, _ unsafe.P
 	}
 }
 
+// updateIP records the IP in a Version message sent by an already connected
+// peer
+func updateIP(peer salticidae.PeerID, id ids.ShortID, msg salticidae.Msg) {
+	build := Builder{}
+	pMsg, err := build.Parse(Version, msg.GetPayloadByMove())
+	if err != nil {
+		HandshakeNet.log.Debug("failed to parse Version message")
+		return
+	}
+
+	if networkID := pMsg.Get(NetworkID).(uint32); networkID != HandshakeNet.networkID {
+		HandshakeNet.log.Debug("peer's network ID doesn't match our networkID: Peer's = %d ; Ours = %d", networkID, HandshakeNet.networkID)
+
+		HandshakeNet.net.DelPeer(peer)
+		return
+	}
+
+	ip := pMsg.Get(IP).(utils.IPDesc)
+
+	HandshakeNet.log.Debug("peer %s advertised %s as its IP", id, ip)
+
+	HandshakeNet.connections.Add(peer, id, ip)
+}
+
 // getPeerList handles the recept of a getPeerList message
 //export getPeerList
 func getPeerList(_ *C.struct_msg_t, _conn *C.struct_msgnetwork_conn_t, _ unsafe.Pointer) {
@@ -790,7 +903,7 @@ func peerList(_msg *C.struct_msg_t, _conn *C.struct_msgnetwork_conn_t, _ unsafe.
 	for _, ip := range ips {
 		addr := salticidae.NewNetAddrFromIPPortString(ip.String(), true, &cErr)
 
-		if cErr.GetCode() != 0 || HandshakeNet.myAddr.IsEq(addr) {
+		if cErr.GetCode() != 0 || HandshakeNet.getMyAddr().IsEq(addr) {
 			// Make sure not to connect to myself
 			continue
 		}
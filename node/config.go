@@ -4,6 +4,8 @@
 package node
 
 import (
+	"time"
+
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/nat"
 	"github.com/ava-labs/gecko/snow/consensus/avalanche"
@@ -15,7 +17,15 @@ import (
 // Config contains all of the configurations of an Ava node.
 type Config struct {
 	// protocol to use for opening the network interface
-	Nat nat.Router
+	Nat nat.Router `json:"-"`
+
+	// NATMethod is the NAT traversal method Nat was chosen by
+	NATMethod string
+
+	// IPResolver re-resolves the public IP of this node every
+	// IPResolutionFreq. If nil, the public IP never changes.
+	IPResolver       nat.IPResolver `json:"-"`
+	IPResolutionFreq time.Duration
 
 	// ID of the network this node should connect to
 	NetworkID uint32
@@ -30,7 +40,11 @@ type Config struct {
 	EnableCrypto bool
 
 	// Database to use for the node
-	DB database.Database
+	DB database.Database `json:"-"`
+
+	// SlowDBThreshold is how long a call to the database of a chain takes
+	// before it's logged. If not positive, slow calls aren't logged.
+	SlowDBThreshold time.Duration
 
 	// Staking configuration
 	StakingIP       utils.IPDesc
@@ -47,10 +61,18 @@ type Config struct {
 	HTTPSKeyFile  string
 	HTTPSCertFile string
 
+	// API authorization configuration
+	APIRequireAuthorization bool
+	APIAuthPassword         string `json:"-"`
+
 	// Enable/Disable APIs
 	AdminAPIEnabled    bool
 	KeystoreAPIEnabled bool
 	MetricsAPIEnabled  bool
+	HealthAPIEnabled   bool
+
+	// Health check configuration
+	HealthCheckFreq time.Duration
 
 	// Logging configuration
 	LoggingConfig logging.Config
@@ -68,6 +90,23 @@ type Config struct {
 	// IPCEnabled configuration
 	IPCEnabled bool
 
+	// IPCLogSize is the most accepted containers kept in the event log of
+	// each published chain
+	IPCLogSize uint64
+
+	// TxIndexEnabled configuration
+	TxIndexEnabled bool
+
+	// AVM cache configuration. The state cache is bounded in bytes, and the
+	// ID and transaction caches in entries.
+	AVMStateCacheSize int
+	AVMIDCacheSize    int
+	AVMTxCacheSize    int
+
+	// ShutdownTimeout is how long in-flight API calls, and then the messages
+	// queued for the chains, are given to finish when the node shuts down
+	ShutdownTimeout time.Duration
+
 	// Router that is used to handle incoming consensus messages
-	ConsensusRouter router.Router
+	ConsensusRouter router.Router `json:"-"`
 }
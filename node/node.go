@@ -14,15 +14,19 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/ava-labs/salticidae-go"
 
 	"github.com/ava-labs/gecko/api"
 	"github.com/ava-labs/gecko/api/admin"
+	"github.com/ava-labs/gecko/api/auth"
+	"github.com/ava-labs/gecko/api/health"
 	"github.com/ava-labs/gecko/api/ipcs"
 	"github.com/ava-labs/gecko/api/keystore"
 	"github.com/ava-labs/gecko/api/metrics"
@@ -32,6 +36,7 @@ import (
 	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/genesis"
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/nat"
 	"github.com/ava-labs/gecko/networking"
 	"github.com/ava-labs/gecko/networking/xputtest"
 	"github.com/ava-labs/gecko/snow/triggers"
@@ -58,6 +63,13 @@ const (
 
 var (
 	genesisHashKey = []byte("genesisID")
+	healthCheckKey = []byte("healthCheck")
+
+	errNoConnectedPeers  = errors.New("not connected to any peers")
+	errClockSkew         = errors.New("clock is out of sync with the clocks of peers")
+	errNotBootstrapped   = errors.New("not all chains have finished bootstrapping")
+	errPluginNotFound    = errors.New("plugin VM binary isn't a file")
+	errDatabaseReadWrite = errors.New("database returned a different value than was written")
 )
 
 // MainNode is the reference for node callbacks
@@ -111,6 +123,11 @@ type Node struct {
 	// current validators of the network
 	vdrs validators.Manager
 
+	// Maps the ports of this node on the NAT router
+	mapper nat.Mapper
+	// Tracks the public IP of this node. nil if the public IP never changes.
+	publicIP *nat.DynamicIP
+
 	// APIs that handle client messages
 	// TODO: Remove
 	Issuer     *xputtest.Issuer
@@ -119,6 +136,12 @@ type Node struct {
 	// Handles HTTP API calls
 	APIServer api.Server
 
+	// Issues and checks the tokens that authorize API calls
+	auth auth.Auth
+
+	// Periodically checks the health of the node's subsystems
+	health health.Health
+
 	// This node's configuration
 	Config *Config
 
@@ -226,6 +249,46 @@ func (n *Node) initValidatorNet() error {
 	return nil
 }
 
+// initNAT maps the staking and HTTP ports on the NAT router, and starts
+// re-resolving the public IP, if a resolver is configured, so that changes to
+// it are advertised to peers
+// Assumes n.ValidatorAPI already initialized
+func (n *Node) initNAT() {
+	n.mapper = nat.NewDefaultMapper(n.Log, n.Config.Nat, nat.TCP, "gecko")
+	if n.Config.NATMethod != nat.None && n.Config.NATMethod != nat.Manual {
+		n.mapper.MapPort(n.Config.StakingIP.Port, n.Config.StakingIP.Port)
+		n.mapper.MapPort(n.Config.HTTPPort, n.Config.HTTPPort)
+	}
+
+	if n.Config.IPResolver == nil {
+		return
+	}
+	n.publicIP = nat.NewDynamicIP(n.Log, n.Config.IPResolver, n.Config.StakingIP.IP, n.Config.IPResolutionFreq)
+	n.publicIP.OnChange(func(ip net.IP) {
+		err := n.ValidatorAPI.SetIP(utils.IPDesc{
+			IP:   ip,
+			Port: n.Config.StakingIP.Port,
+		})
+		if err != nil {
+			n.Log.Warn("couldn't advertise %s as the public IP: %s", ip, err)
+		}
+	})
+	go n.Log.RecoverAndPanic(n.publicIP.Dispatch)
+}
+
+// NATStatus returns the status of the NAT traversal of this node
+func (n *Node) NATStatus() nat.Status {
+	status := nat.Status{
+		Method:   n.Config.NATMethod,
+		IP:       n.Config.StakingIP.IP,
+		Mappings: n.mapper.Mappings(),
+	}
+	if n.publicIP != nil {
+		status.IP, status.LastResolved, status.Err = n.publicIP.Status()
+	}
+	return status
+}
+
 func (n *Node) initConsensusNet() {
 	vdrs, ok := n.vdrs.GetValidatorSet(platformvm.DefaultSubnetID)
 	n.Log.AssertTrue(ok, "should have initialize the validator set already")
@@ -381,6 +444,8 @@ func (n *Node) initVMManager() error {
 		return err
 	}
 
+	genesisConfig := genesis.GetConfig(n.Config.NetworkID)
+
 	n.vmManager = vms.NewManager(&n.APIServer, n.HTTPLog)
 
 	errs := wrappers.Errs{}
@@ -388,6 +453,15 @@ func (n *Node) initVMManager() error {
 		n.vmManager.RegisterVMFactory(avm.ID, &avm.Factory{
 			AVA:      avaAssetID,
 			Platform: ids.Empty,
+			IndexTxs: n.Config.TxIndexEnabled,
+
+			// The fee is a network-wide parameter
+			Fee:           genesisConfig.AVMTxFee,
+			FeeActivation: genesisConfig.AVMTxFeeActivation,
+
+			StateCacheSize: n.Config.AVMStateCacheSize,
+			IDCacheSize:    n.Config.AVMIDCacheSize,
+			TxCacheSize:    n.Config.AVMTxCacheSize,
 		}),
 		n.vmManager.RegisterVMFactory(genesis.EVMID, &rpcchainvm.Factory{Path: path.Join(n.Config.PluginDir, "evm")}),
 		n.vmManager.RegisterVMFactory(spdagvm.ID, &spdagvm.Factory{TxFee: n.Config.AvaTxFee}),
@@ -474,24 +548,43 @@ func (n *Node) initChains() error {
 }
 
 // initAPIServer initializes the server that handles HTTP calls
-func (n *Node) initAPIServer() {
+func (n *Node) initAPIServer() error {
 	n.Log.Info("Initializing API server")
 
 	n.APIServer.Initialize(n.Log, n.LogFactory, n.Config.HTTPPort)
 
+	if n.Config.APIRequireAuthorization {
+		n.Log.Info("API calls require authorization")
+		authDB := prefixdb.New([]byte("auth"), n.DB)
+		if err := n.auth.Initialize(n.Log, authDB, n.Config.APIAuthPassword); err != nil {
+			return err
+		}
+		n.APIServer.RequireAuthorization(&n.auth)
+		if err := n.APIServer.AddRoute(n.auth.CreateHandler(), &sync.RWMutex{}, auth.Endpoint, "", n.HTTPLog); err != nil {
+			return err
+		}
+	}
+
 	go n.Log.RecoverAndPanic(func() {
 		if n.Config.EnableHTTPS {
 			n.Log.Debug("Initializing API server with TLS Enabled")
 			err := n.APIServer.DispatchTLS(n.Config.HTTPSCertFile, n.Config.HTTPSKeyFile)
+			if err == nil {
+				return // The API server was shut down
+			}
 			n.Log.Warn("Secure API server initialization failed with %s, attempting to create insecure API server", err)
 		}
 
 		n.Log.Debug("Initializing API server")
 		err := n.APIServer.Dispatch()
+		if err == nil {
+			return // The API server was shut down
+		}
 
 		n.Log.Fatal("API server initialization failed with %s", err)
 		n.TCall.AsyncCall(salticidae.ThreadCallCallback(C.onTerm), nil)
 	})
+	return nil
 }
 
 // Assumes n.DB, n.vdrs all initialized (non-nil)
@@ -507,6 +600,8 @@ func (n *Node) initChainManager() {
 		n.Config.ConsensusRouter,
 		&networking.VotingNet,
 		n.Config.ConsensusParams,
+		n.Config.ShutdownTimeout,
+		n.Config.SlowDBThreshold,
 		n.vdrs,
 		n.ID,
 		n.Config.NetworkID,
@@ -554,21 +649,131 @@ func (n *Node) initMetricsAPI() {
 func (n *Node) initAdminAPI() {
 	if n.Config.AdminAPIEnabled {
 		n.Log.Info("initializing Admin API")
-		service := admin.NewService(n.ID, n.Config.NetworkID, n.Log, n.chainManager, n.ValidatorAPI.Connections(), &n.APIServer)
+		service := admin.NewService(n.ID, n.Config.NetworkID, n.Log, n.LogFactory, n.chainManager, n.ValidatorAPI.Connections(), n, &n.APIServer)
 		n.APIServer.AddRoute(service, &sync.RWMutex{}, "admin", "", n.HTTPLog)
 	}
 }
 
 // initIPCAPI initializes the IPC API service
-// Assumes n.log and n.chainManager already initialized
+// Assumes n.log, n.DB and n.chainManager already initialized
 func (n *Node) initIPCAPI() {
 	if n.Config.IPCEnabled {
 		n.Log.Info("initializing IPC API")
-		service := ipcs.NewService(n.Log, n.chainManager, n.DecisionDispatcher, &n.APIServer)
+		ipcsDB := prefixdb.New([]byte("ipcs"), n.DB)
+		service := ipcs.NewService(n.Log, n.chainManager, n.DecisionDispatcher, n.ConsensusDispatcher, ipcsDB, n.Config.IPCLogSize, &n.APIServer)
 		n.APIServer.AddRoute(service, &sync.RWMutex{}, "ipcs", "", n.HTTPLog)
 	}
 }
 
+// initHealthAPI registers the health checks of the node's subsystems, starts
+// running them and exposes their results through the Health API
+// Assumes n.DB, n.chainManager and n.ValidatorAPI already initialized
+func (n *Node) initHealthAPI() error {
+	if !n.Config.HealthAPIEnabled {
+		return nil
+	}
+
+	n.Log.Info("initializing Health API")
+	n.health.Initialize(n.Log, n.Config.HealthCheckFreq)
+
+	healthDB := prefixdb.New([]byte("health"), n.DB)
+	errs := wrappers.Errs{}
+	errs.Add(
+		n.health.RegisterLivenessCheck("database", func() (interface{}, error) {
+			return nil, checkDatabase(healthDB)
+		}),
+		n.health.RegisterReadinessCheck("network.peers", n.checkPeers),
+		n.health.RegisterReadinessCheck("network.clockSkew", n.checkClockSkew),
+		n.health.RegisterReadinessCheck("chains.bootstrapped", n.checkBootstrapped),
+		n.health.RegisterReadinessCheck("vms.plugins", n.checkPlugins),
+	)
+	if errs.Errored() {
+		return errs.Err
+	}
+
+	for extension, handler := range n.health.CreateHandlers() {
+		// Health probes are called by load balancers and orchestrators, which
+		// can't be issued auth tokens
+		errs.Add(n.APIServer.AddPublicRoute(handler, &sync.RWMutex{}, "health", extension, n.HTTPLog))
+	}
+	errs.Add(n.health.Start())
+	return errs.Err
+}
+
+// checkDatabase returns an error if a value can't be written to, read from and
+// deleted from [db]
+func checkDatabase(db database.Database) error {
+	value := []byte(time.Now().String())
+	if err := db.Put(healthCheckKey, value); err != nil {
+		return err
+	}
+	read, err := db.Get(healthCheckKey)
+	if err != nil {
+		return err
+	}
+	if string(read) != string(value) {
+		return errDatabaseReadWrite
+	}
+	return db.Delete(healthCheckKey)
+}
+
+// checkPeers returns an error if this node should be connected to peers but
+// isn't connected to any
+func (n *Node) checkPeers() (interface{}, error) {
+	connected := n.ValidatorAPI.Connections().Len()
+	details := map[string]int{"connectedPeers": connected}
+	if connected == 0 && len(n.Config.BootstrapPeers) > 0 {
+		return details, errNoConnectedPeers
+	}
+	return details, nil
+}
+
+// checkClockSkew returns an error if this node's clock is so far from the
+// clocks of its peers that they would refuse to connect to it
+func (n *Node) checkClockSkew() (interface{}, error) {
+	skew, samples := n.ValidatorAPI.ClockSkew()
+	details := map[string]interface{}{
+		"medianSkew": skew.String(),
+		"samples":    samples,
+	}
+	if skew > networking.MaxClockDifference || skew < -networking.MaxClockDifference {
+		return details, errClockSkew
+	}
+	return details, nil
+}
+
+// checkBootstrapped returns an error if any chain hasn't finished
+// bootstrapping
+func (n *Node) checkBootstrapped() (interface{}, error) {
+	details := make(map[string]bool)
+	bootstrapped := true
+	for _, chainID := range n.chainManager.Chains() {
+		name := chainID.String()
+		if aliases := n.chainManager.Aliases(chainID); len(aliases) > 0 {
+			name = aliases[0]
+		}
+		details[name] = n.chainManager.IsBootstrapped(chainID)
+		bootstrapped = bootstrapped && details[name]
+	}
+	if !bootstrapped {
+		return details, errNotBootstrapped
+	}
+	return details, nil
+}
+
+// checkPlugins returns an error if the binary of a plugin VM is missing
+func (n *Node) checkPlugins() (interface{}, error) {
+	pluginPath := path.Join(n.Config.PluginDir, "evm")
+	info, err := os.Stat(pluginPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%w: %s", errPluginNotFound, pluginPath)
+	}
+	return map[string]string{"evm": pluginPath}, nil
+}
+
 // Give chains and VMs aliases as specified by the genesis information
 func (n *Node) initAliases() error {
 	n.Log.Info("initializing aliases")
@@ -629,7 +834,9 @@ func (n *Node) Initialize(Config *Config, logger logging.Logger, logFactory logg
 	}
 
 	// Start HTTP APIs
-	n.initAPIServer()   // Start the API Server
+	if err := n.initAPIServer(); err != nil { // Start the API Server
+		return fmt.Errorf("problem initializing the API server: %w", err)
+	}
 	n.initKeystoreAPI() // Start the Keystore API
 	n.initMetricsAPI()  // Start the Metrics API
 
@@ -637,6 +844,8 @@ func (n *Node) Initialize(Config *Config, logger logging.Logger, logFactory logg
 	if err := n.initValidatorNet(); err != nil { // Set up the validator handshake + authentication
 		return fmt.Errorf("problem initializing validator network: %w", err)
 	}
+	n.initNAT() // Map ports and track the public IP
+
 	if err := n.initVMManager(); err != nil { // Set up the vm manager
 		return fmt.Errorf("problem initializing the VM manager: %w", err)
 	}
@@ -656,14 +865,54 @@ func (n *Node) Initialize(Config *Config, logger logging.Logger, logFactory logg
 	if err := n.initAliases(); err != nil { // Set up aliases
 		return err
 	}
-	return n.initChains() // Start the Platform chain
+	if err := n.initChains(); err != nil { // Start the Platform chain
+		return err
+	}
+	return n.initHealthAPI() // Start the Health API
 }
 
-// Shutdown this node
+// Shutdown this node. API and network traffic are stopped first, then the
+// chains process the messages queued for them and their VMs shut down, and
+// finally the database is closed. Chains that can't process their queued
+// messages within the shutdown timeout drop them, so that no chain is still
+// running when the database is closed.
 func (n *Node) Shutdown() {
 	n.Log.Info("shutting down the node")
-	n.ValidatorAPI.Shutdown()
-	n.ConsensusAPI.Shutdown()
-	n.chainManager.Shutdown()
+	start := time.Now()
+
+	n.shutdownStep("API server", func() error { return n.APIServer.Shutdown(n.Config.ShutdownTimeout) })
+	n.shutdownStep("health checks", func() error { n.health.Stop(); return nil })
+	n.shutdownStep("NAT traversal", func() error {
+		if n.publicIP != nil {
+			n.publicIP.Stop()
+		}
+		// Unmapping fails if no router was discovered, which isn't a problem
+		// worth warning about
+		if err := n.mapper.UnmapAllPorts(); err != nil {
+			n.Log.Debug("unmapping ports failed with: %s", err)
+		}
+		return nil
+	})
+	n.shutdownStep("network", func() error {
+		n.ValidatorAPI.Shutdown()
+		n.ConsensusAPI.Shutdown()
+		return nil
+	})
+	n.shutdownStep("chains", func() error { n.chainManager.Shutdown(); return nil })
+	n.shutdownStep("database", n.DB.Close)
 	utils.ClearSignals(n.nodeCloser)
+
+	n.Log.Info("node shut down in %s", time.Since(start))
+}
+
+// shutdownStep runs [shutdown], which shuts down [name], and logs how long it
+// took
+func (n *Node) shutdownStep(name string, shutdown func() error) {
+	n.Log.Info("shutting down the %s", name)
+	start := time.Now()
+	if err := shutdown(); err != nil {
+		n.Log.Warn("shutting down the %s failed after %s with: %s", name, time.Since(start), err)
+		return
+	}
+	n.Log.Info("shut down the %s in %s", name, time.Since(start))
 }
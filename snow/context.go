@@ -8,9 +8,12 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/triggers"
+	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/logging"
 )
 
@@ -22,6 +25,10 @@ type Callable interface {
 // Keystore ...
 type Keystore interface {
 	GetDatabase(username, password string) (database.Database, error)
+
+	// NewKey returns a new key of the user. If the user holds a mnemonic, the
+	// key is derived from it.
+	NewKey(username, password string) (*crypto.PrivateKeySECP256K1R, error)
 }
 
 // SharedMemory ...
@@ -40,6 +47,8 @@ type AliasLookup interface {
 // [NetworkID] is the ID of the network this context exists within.
 // [ChainID] is the ID of the chain this context exists within.
 // [NodeID] is the ID of this node
+// [Namespace] is the namespace of the metrics the chain registers with [Metrics].
+// If [Metrics] is nil, the chain doesn't register metrics.
 type Context struct {
 	NetworkID           uint32
 	ChainID             ids.ID
@@ -52,6 +61,8 @@ type Context struct {
 	Keystore            Keystore
 	SharedMemory        SharedMemory
 	BCLookup            AliasLookup
+	Namespace           string
+	Metrics             prometheus.Registerer
 }
 
 // DefaultContextTest ...
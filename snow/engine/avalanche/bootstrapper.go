@@ -23,6 +23,8 @@ type BootstrapConfig struct {
 
 	State State
 	VM    DAGVM
+
+	Bootstrapped func()
 }
 
 type bootstrapper struct {
@@ -221,6 +223,10 @@ func (b *bootstrapper) finish() {
 	// Start consensus
 	b.onFinished()
 	b.finished = true
+
+	if b.Bootstrapped != nil {
+		b.Bootstrapped()
+	}
 }
 
 func (b *bootstrapper) executeAll(jobs *queue.Jobs, numBlocked prometheus.Gauge) {
@@ -4,7 +4,11 @@
 package common
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"sync"
 )
 
 // LockOption allows the vm to specify their lock option based on their endpoint
@@ -22,3 +26,51 @@ type HTTPHandler struct {
 	LockOptions LockOption
 	Handler     http.Handler
 }
+
+// NewMethodLockHandler returns a handler that serves JSON RPC requests to
+// [handler]. Requests for one of [noLockMethods], such as "avm.getBalance",
+// are served without holding [lock]; every other request holds [lock] for
+// writing. The returned handler is registered with NoLock, so methods that
+// don't hold [lock] must be safe to call concurrently.
+func NewMethodLockHandler(lock *sync.RWMutex, handler http.Handler, noLockMethods ...string) *HTTPHandler {
+	methods := make(map[string]struct{}, len(noLockMethods))
+	for _, method := range noLockMethods {
+		methods[method] = struct{}{}
+	}
+	return &HTTPHandler{
+		LockOptions: NoLock,
+		Handler: &methodLockHandler{
+			lock:          lock,
+			handler:       handler,
+			noLockMethods: methods,
+		},
+	}
+}
+
+type methodLockHandler struct {
+	lock          *sync.RWMutex
+	handler       http.Handler
+	noLockMethods map[string]struct{}
+}
+
+func (h *methodLockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err == nil {
+		request := struct {
+			Method string `json:"method"`
+		}{}
+		if err := json.Unmarshal(body, &request); err == nil {
+			if _, ok := h.noLockMethods[request.Method]; ok {
+				h.handler.ServeHTTP(w, r)
+				return
+			}
+		}
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.handler.ServeHTTP(w, r)
+}
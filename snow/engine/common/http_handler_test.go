@@ -0,0 +1,68 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMethodLockHandler(t *testing.T) {
+	lock := sync.RWMutex{}
+	locked := false
+	body := ""
+	acquired := make(chan struct{})
+	handler := NewMethodLockHandler(&lock, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The lock can only be grabbed while the request is served if the
+		// handler doesn't hold it
+		acquired = make(chan struct{})
+		go func(acquired chan struct{}) {
+			lock.Lock()
+			lock.Unlock()
+			close(acquired)
+		}(acquired)
+		select {
+		case <-acquired:
+			locked = false
+		case <-time.After(50 * time.Millisecond):
+			locked = true
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = string(b)
+	}), "test.read")
+
+	if handler.LockOptions != NoLock {
+		t.Fatalf("Handler should be registered without a lock")
+	}
+
+	tests := []struct {
+		body   string
+		locked bool
+	}{
+		{`{"jsonrpc":"2.0","method":"test.read","params":[{}],"id":1}`, false},
+		{`{"jsonrpc":"2.0","method":"test.write","params":[{}],"id":1}`, true},
+		{`{"jsonrpc":"2.0","method":"test.Read","params":[{}],"id":1}`, true},
+		{`not json`, true},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(test.body))
+		handler.Handler.ServeHTTP(httptest.NewRecorder(), r)
+		// The lock is grabbed once the handler releases it
+		<-acquired
+		if locked != test.locked {
+			t.Fatalf("Serving %q: locked = %v ; Expected: %v", test.body, locked, test.locked)
+		}
+		if body != test.body {
+			t.Fatalf("Handler read %q ; Expected: %q", body, test.body)
+		}
+	}
+}
@@ -55,7 +55,7 @@ func newConfig(t *testing.T) (BootstrapConfig, ids.ShortID, *common.SenderTest,
 
 	handler.Initialize(engine, make(chan common.Message), 1)
 	timeouts.Initialize(0)
-	router.Initialize(ctx.Log, timeouts, time.Hour)
+	router.Initialize(ctx.Log, timeouts, time.Hour, time.Second)
 
 	blocker, _ := queue.New(db)
 
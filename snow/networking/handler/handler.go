@@ -5,6 +5,7 @@ package handler
 
 import (
 	"sync"
+	"time"
 
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
@@ -18,6 +19,11 @@ type Handler struct {
 	wg      sync.WaitGroup
 	engine  common.Engine
 	msgChan <-chan common.Message
+
+	// closing is closed when the dispatcher should shut down its engine
+	// without processing the messages still queued
+	closing   chan struct{}
+	closeOnce sync.Once
 }
 
 // Initialize this consensus handler
@@ -25,6 +31,7 @@ func (h *Handler) Initialize(engine common.Engine, msgChan <-chan common.Message
 	h.msgs = make(chan message, bufferSize)
 	h.engine = engine
 	h.msgChan = msgChan
+	h.closing = make(chan struct{})
 
 	h.wg.Add(1)
 }
@@ -38,7 +45,18 @@ func (h *Handler) Dispatch() {
 	defer h.wg.Done()
 
 	for {
+		// Closing takes priority over the queued messages
+		select {
+		case <-h.closing:
+			h.dispatchMsg(message{messageType: shutdownMsg})
+			return
+		default:
+		}
+
 		select {
+		case <-h.closing:
+			h.dispatchMsg(message{messageType: shutdownMsg})
+			return
 		case msg := <-h.msgs:
 			if !h.dispatchMsg(msg) {
 				return
@@ -236,8 +254,43 @@ func (h *Handler) QueryFailed(validatorID ids.ShortID, requestID uint32) {
 // Gossip passes a gossip request to the consensus engine
 func (h *Handler) Gossip() { h.msgs <- message{messageType: gossipMsg} }
 
-// Shutdown shuts down the dispatcher
-func (h *Handler) Shutdown() { h.msgs <- message{messageType: shutdownMsg}; h.wg.Wait() }
+// Shutdown tells the dispatcher to shut down its engine once the messages
+// already queued are processed, and waits up to [timeout] for it to do so. If
+// the queue isn't drained in time, the remaining messages are dropped and the
+// engine is shut down once the message being processed is done. Either way,
+// the engine has been shut down when Shutdown returns. Returns false if
+// queued messages were dropped.
+func (h *Handler) Shutdown(timeout time.Duration) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+
+	drained := true
+	select {
+	case h.msgs <- message{messageType: shutdownMsg}:
+		select {
+		case <-done:
+		case <-deadline.C:
+			drained = false
+		}
+	case <-deadline.C:
+		drained = false
+	}
+
+	if !drained {
+		h.closeOnce.Do(func() { close(h.closing) })
+		<-done
+	}
+	return drained
+}
+
+// Pending returns the number of messages queued for the dispatcher
+func (h *Handler) Pending() int { return len(h.msgs) }
 
 // Notify ...
 func (h *Handler) Notify(msg common.Message) {
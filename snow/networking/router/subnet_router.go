@@ -24,6 +24,10 @@ type ChainRouter struct {
 	chains   map[[32]byte]*handler.Handler
 	timeouts *timeout.Manager
 	gossiper *timer.Repeater
+
+	// shutdownTimeout is how long chains are given to process the messages
+	// queued for them when the router shuts down
+	shutdownTimeout time.Duration
 }
 
 // Initialize the router.
@@ -34,8 +38,14 @@ type ChainRouter struct {
 //
 // This router also fires a gossip event every [gossipFrequency] to the engine,
 // notifying the engine it should gossip it's accepted set.
-func (sr *ChainRouter) Initialize(log logging.Logger, timeouts *timeout.Manager, gossipFrequency time.Duration) {
+//
+// When this router shuts down, its chains are given [shutdownTimeout], in
+// total, to process the messages queued for them. Messages still queued after
+// that are dropped, and every chain's engine is shut down before Shutdown
+// returns.
+func (sr *ChainRouter) Initialize(log logging.Logger, timeouts *timeout.Manager, gossipFrequency, shutdownTimeout time.Duration) {
 	sr.log = log
+	sr.shutdownTimeout = shutdownTimeout
 	sr.chains = make(map[[32]byte]*handler.Handler)
 	sr.timeouts = timeouts
 	sr.gossiper = timer.NewRepeater(sr.Gossip, gossipFrequency)
@@ -61,7 +71,9 @@ func (sr *ChainRouter) RemoveChain(chainID ids.ID) {
 	defer sr.lock.Unlock()
 
 	if chain, exists := sr.chains[chainID.Key()]; exists {
-		chain.Shutdown()
+		if !chain.Shutdown(sr.shutdownTimeout) {
+			sr.log.Warn("chain %s dropped its queued messages to shut down after %s", chainID, sr.shutdownTimeout)
+		}
 		delete(sr.chains, chainID.Key())
 	} else {
 		sr.log.Debug("message referenced a chain, %s, this node doesn't validate", chainID)
@@ -263,10 +275,20 @@ func (sr *ChainRouter) Shutdown() {
 }
 
 func (sr *ChainRouter) shutdown() {
+	// Stop gossiping first so that no more messages are queued for the chains
+	sr.gossiper.Stop()
+
+	deadline := time.Now().Add(sr.shutdownTimeout)
 	for _, chain := range sr.chains {
-		chain.Shutdown()
+		chainID := chain.Context().ChainID
+		start := time.Now()
+		pending := chain.Pending()
+		if chain.Shutdown(time.Until(deadline)) {
+			sr.log.Info("chain %s shut down in %s after processing %d queued messages", chainID, time.Since(start), pending)
+		} else {
+			sr.log.Warn("chain %s dropped %d queued messages to shut down by the deadline", chainID, chain.Pending())
+		}
 	}
-	sr.gossiper.Stop()
 }
 
 // Gossip accepted containers
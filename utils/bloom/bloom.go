@@ -0,0 +1,92 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/gecko/utils/hashing"
+)
+
+const (
+	// MaxBytes is the largest filter, in bytes
+	MaxBytes = 1 << 16
+
+	// MaxHashes is the most hash functions a filter can use
+	MaxHashes = 16
+)
+
+var (
+	errInvalidSize   = errors.New("bloom filter size must be positive and at most 64 KiB")
+	errInvalidHashes = errors.New("number of bloom filter hashes must be positive and at most 16")
+)
+
+// Filter is a bloom filter. A key is added by setting [hashes] of the bits of
+// the filter, where the i-th bit is h1 + i*h2 modulo the number of bits, and
+// h1 and h2 are the first and second big endian uint64s of the sha256 hash of
+// the key. Clients can reimplement it to build filters the node checks.
+type Filter struct {
+	bits   []byte
+	hashes int
+}
+
+// New returns an empty filter of [numBytes] bytes that uses [hashes] hash
+// functions
+func New(numBytes, hashes int) (*Filter, error) {
+	return Parse(make([]byte, numBytes), hashes)
+}
+
+// Parse returns the filter with the bits [bits] that uses [hashes] hash
+// functions. The filter keeps a reference to [bits].
+func Parse(bits []byte, hashes int) (*Filter, error) {
+	switch {
+	case len(bits) == 0 || len(bits) > MaxBytes:
+		return nil, errInvalidSize
+	case hashes <= 0 || hashes > MaxHashes:
+		return nil, errInvalidHashes
+	}
+	return &Filter{
+		bits:   bits,
+		hashes: hashes,
+	}, nil
+}
+
+// Add [key] to the filter
+func (f *Filter) Add(key []byte) {
+	f.each(key, func(byteIndex int, mask byte) bool {
+		f.bits[byteIndex] |= mask
+		return true
+	})
+}
+
+// Check returns true if [key] may have been added to the filter, and false if
+// it definitely wasn't
+func (f *Filter) Check(key []byte) bool {
+	return f.each(key, func(byteIndex int, mask byte) bool {
+		return f.bits[byteIndex]&mask != 0
+	})
+}
+
+// Bytes returns the bits of the filter
+func (f *Filter) Bytes() []byte { return f.bits }
+
+// Hashes returns the number of hash functions the filter uses
+func (f *Filter) Hashes() int { return f.hashes }
+
+// each calls [fn] with the location of each of the bits of [key], until [fn]
+// returns false. Returns false if [fn] did.
+func (f *Filter) each(key []byte, fn func(byteIndex int, mask byte) bool) bool {
+	hash := hashing.ComputeHash256(key)
+	h1 := binary.BigEndian.Uint64(hash[:8])
+	h2 := binary.BigEndian.Uint64(hash[8:16])
+	numBits := uint64(len(f.bits)) * 8
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % numBits
+		if !fn(int(bit/8), 1<<(bit%8)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bloom
+
+import (
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	f, err := New(256, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added := [][]byte{{1}, {2, 3}, []byte("address")}
+	for _, key := range added {
+		f.Add(key)
+	}
+	for _, key := range added {
+		if !f.Check(key) {
+			t.Fatalf("Filter should contain %v", key)
+		}
+	}
+	if f.Check([]byte("not added")) {
+		t.Fatalf("Filter shouldn't contain a key that wasn't added")
+	}
+
+	parsed, err := Parse(f.Bytes(), f.Hashes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Check([]byte("address")) {
+		t.Fatalf("Parsed filter should contain the keys of the original")
+	}
+}
+
+func TestFilterParams(t *testing.T) {
+	if _, err := New(0, 1); err != errInvalidSize {
+		t.Fatalf("Should have errored with %s, got %v", errInvalidSize, err)
+	}
+	if _, err := New(MaxBytes+1, 1); err != errInvalidSize {
+		t.Fatalf("Should have errored with %s, got %v", errInvalidSize, err)
+	}
+	if _, err := New(1, 0); err != errInvalidHashes {
+		t.Fatalf("Should have errored with %s, got %v", errInvalidHashes, err)
+	}
+	if _, err := New(1, MaxHashes+1); err != errInvalidHashes {
+		t.Fatalf("Should have errored with %s, got %v", errInvalidHashes, err)
+	}
+}
@@ -0,0 +1,94 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1"
+)
+
+// HardenedKeyStart is the index of the first hardened child of an extended key
+const HardenedKeyStart uint32 = 1 << 31
+
+var (
+	bip32SeedKey = []byte("Bitcoin seed")
+
+	errInvalidSeedLen     = errors.New("seed must be 16 to 64 bytes")
+	errInvalidExtendedKey = errors.New("derived key is invalid")
+)
+
+// ExtendedKeySECP256K1R is a BIP32 extended private key, from which a tree of
+// child keys is derived
+type ExtendedKeySECP256K1R struct {
+	key       secp256k1.ModNScalar
+	chainCode [32]byte
+}
+
+// NewMasterKeySECP256K1R returns the root of the BIP32 tree of keys of [seed]
+func NewMasterKeySECP256K1R(seed []byte) (*ExtendedKeySECP256K1R, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, errInvalidSeedLen
+	}
+	mac := hmac.New(sha512.New, bip32SeedKey)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	k := &ExtendedKeySECP256K1R{}
+	if overflow := k.key.SetByteSlice(sum[:32]); overflow || k.key.IsZero() {
+		return nil, errInvalidExtendedKey
+	}
+	copy(k.chainCode[:], sum[32:])
+	return k, nil
+}
+
+// Child returns the child of [k] at [index]. Children at indices of at least
+// HardenedKeyStart are hardened.
+// Returns an error, with negligible probability, if the child is invalid. In
+// that case, the next index should be used instead.
+func (k *ExtendedKeySECP256K1R) Child(index uint32) (*ExtendedKeySECP256K1R, error) {
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	if index >= HardenedKeyStart {
+		keyBytes := k.key.Bytes()
+		mac.Write([]byte{0})
+		mac.Write(keyBytes[:])
+	} else {
+		mac.Write(secp256k1.NewPrivateKey(&k.key).PubKey().SerializeCompressed())
+	}
+	indexBytes := [4]byte{}
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+	mac.Write(indexBytes[:])
+	sum := mac.Sum(nil)
+
+	child := &ExtendedKeySECP256K1R{}
+	if overflow := child.key.SetByteSlice(sum[:32]); overflow {
+		return nil, errInvalidExtendedKey
+	}
+	if child.key.Add(&k.key).IsZero() {
+		return nil, errInvalidExtendedKey
+	}
+	copy(child.chainCode[:], sum[32:])
+	return child, nil
+}
+
+// Derive returns the descendant of [k] along [path], where each element of
+// [path] is the index of a child
+func (k *ExtendedKeySECP256K1R) Derive(path []uint32) (*ExtendedKeySECP256K1R, error) {
+	for _, index := range path {
+		child, err := k.Child(index)
+		if err != nil {
+			return nil, err
+		}
+		k = child
+	}
+	return k, nil
+}
+
+// PrivateKey returns the private key of [k]
+func (k *ExtendedKeySECP256K1R) PrivateKey() *PrivateKeySECP256K1R {
+	return &PrivateKeySECP256K1R{sk: secp256k1.NewPrivateKey(&k.key)}
+}
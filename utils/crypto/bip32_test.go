@@ -0,0 +1,91 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// Test vector 1 of BIP32
+func TestExtendedKeyDerive(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKeySECP256K1R(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path      []uint32
+		key       string
+		chainCode string
+	}{
+		{
+			path:      nil,
+			key:       "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+			chainCode: "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508",
+		},
+		{
+			path:      []uint32{HardenedKeyStart},
+			key:       "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+			chainCode: "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+		},
+		{
+			path:      []uint32{HardenedKeyStart, 1},
+			key:       "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+			chainCode: "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19",
+		},
+		{
+			path: []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2},
+			key:  "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+		},
+	}
+	for _, test := range tests {
+		k, err := master.Derive(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key := hex.EncodeToString(k.PrivateKey().Bytes()); key != test.key {
+			t.Fatalf("Key at %v is %s ; Expected: %s", test.path, key, test.key)
+		}
+		if test.chainCode == "" {
+			continue
+		}
+		if chainCode := hex.EncodeToString(k.chainCode[:]); chainCode != test.chainCode {
+			t.Fatalf("Chain code at %v is %s ; Expected: %s", test.path, chainCode, test.chainCode)
+		}
+	}
+}
+
+func TestExtendedKeySign(t *testing.T) {
+	seed := bytes.Repeat([]byte{1}, 32)
+	master, err := NewMasterKeySECP256K1R(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := master.Child(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := child.PrivateKey()
+	msg := []byte("hello")
+	sig, err := key.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.PublicKey().Verify(msg, sig) {
+		t.Fatalf("Signature of a derived key should have verified")
+	}
+}
+
+func TestNewMasterKeyInvalidSeed(t *testing.T) {
+	if _, err := NewMasterKeySECP256K1R(make([]byte, 15)); err != errInvalidSeedLen {
+		t.Fatalf("Expected %s, got %v", errInvalidSeedLen, err)
+	}
+	if _, err := NewMasterKeySECP256K1R(make([]byte, 65)); err != errInvalidSeedLen {
+		t.Fatalf("Expected %s, got %v", errInvalidSeedLen, err)
+	}
+}
@@ -0,0 +1,119 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// MnemonicEntropyLen is the number of bytes of entropy encoded by a
+	// mnemonic from NewMnemonic, which has 24 words
+	MnemonicEntropyLen = 32
+
+	// SeedLen is the number of bytes in a seed derived from a mnemonic
+	SeedLen = 64
+
+	bip39WordCount     = 2048
+	bip39BitsPerWord   = 11
+	bip39SeedRounds    = 2048
+	bip39SeedSaltLabel = "mnemonic"
+)
+
+var (
+	errInvalidEntropyLen  = errors.New("entropy must be 16 to 32 bytes, in multiples of 4")
+	errInvalidMnemonicLen = errors.New("mnemonic must have 12 to 24 words, in multiples of 3")
+	errMnemonicChecksum   = errors.New("invalid mnemonic checksum")
+)
+
+// bip39Indices maps each word of bip39English to its index
+var bip39Indices = make(map[string]int, bip39WordCount)
+
+func init() {
+	for i, word := range bip39English {
+		bip39Indices[word] = i
+	}
+}
+
+// NewMnemonic returns a random BIP39 mnemonic of 24 words
+func NewMnemonic() (string, error) {
+	entropy := make([]byte, MnemonicEntropyLen)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return EntropyToMnemonic(entropy)
+}
+
+// EntropyToMnemonic returns the BIP39 mnemonic that encodes [entropy]
+func EntropyToMnemonic(entropy []byte) (string, error) {
+	if len(entropy) < 16 || len(entropy) > 32 || len(entropy)%4 != 0 {
+		return "", errInvalidEntropyLen
+	}
+
+	// The entropy is followed by the first len(entropy)/4 bits of its hash
+	hash := sha256.Sum256(entropy)
+	bits := append(append([]byte{}, entropy...), hash[0])
+
+	words := make([]string, (len(entropy)*8+len(entropy)/4)/bip39BitsPerWord)
+	for i := range words {
+		index := 0
+		for j := 0; j < bip39BitsPerWord; j++ {
+			bit := i*bip39BitsPerWord + j
+			index = index<<1 | int(bits[bit/8]>>(7-bit%8)&1)
+		}
+		words[i] = bip39English[index]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy returns the entropy encoded by [mnemonic]. Returns an
+// error if [mnemonic] isn't a valid BIP39 mnemonic.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, errInvalidMnemonicLen
+	}
+
+	numBits := len(words) * bip39BitsPerWord
+	checksumBits := numBits / 33
+	bits := make([]byte, (numBits+7)/8)
+	for i, word := range words {
+		index, ok := bip39Indices[word]
+		if !ok {
+			return nil, fmt.Errorf("%q isn't a mnemonic word", word)
+		}
+		for j := 0; j < bip39BitsPerWord; j++ {
+			if index>>(bip39BitsPerWord-1-j)&1 == 1 {
+				bit := i*bip39BitsPerWord + j
+				bits[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+
+	entropy := bits[:(numBits-checksumBits)/8]
+	hash := sha256.Sum256(entropy)
+	mask := byte(0xff) << (8 - checksumBits)
+	if bits[len(entropy)]&mask != hash[0]&mask {
+		return nil, errMnemonicChecksum
+	}
+	return entropy, nil
+}
+
+// MnemonicToSeed returns the seed of [mnemonic], protected by [passphrase],
+// which is the root of the BIP32 tree of keys of the mnemonic.
+// The passphrase is used as given. It isn't normalized.
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	if _, err := MnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(normalized), []byte(bip39SeedSaltLabel+passphrase), bip39SeedRounds, SeedLen, sha512.New), nil
+}
@@ -0,0 +1,96 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// Test vectors of BIP39, whose seeds use the passphrase "TREZOR"
+var bip39Tests = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		entropy:  "00000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		entropy:  "0000000000000000000000000000000000000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		seed:     "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+	},
+}
+
+func TestMnemonic(t *testing.T) {
+	for _, test := range bip39Tests {
+		entropy, _ := hex.DecodeString(test.entropy)
+		mnemonic, err := EntropyToMnemonic(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mnemonic != test.mnemonic {
+			t.Fatalf("Mnemonic of %s is %q ; Expected: %q", test.entropy, mnemonic, test.mnemonic)
+		}
+
+		decoded, err := MnemonicToEntropy(mnemonic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded, entropy) {
+			t.Fatalf("Mnemonic %q encodes %x ; Expected: %s", mnemonic, decoded, test.entropy)
+		}
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(seed) != test.seed {
+			t.Fatalf("Seed of %q is %x ; Expected: %s", mnemonic, seed, test.seed)
+		}
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if words := strings.Fields(mnemonic); len(words) != 24 {
+		t.Fatalf("Mnemonic should have 24 words but has %d", len(words))
+	}
+	entropy, err := MnemonicToEntropy(mnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entropy) != MnemonicEntropyLen {
+		t.Fatalf("Mnemonic should encode %d bytes but encodes %d", MnemonicEntropyLen, len(entropy))
+	}
+}
+
+func TestMnemonicToEntropyInvalid(t *testing.T) {
+	// The checksum of this mnemonic is "about"
+	if _, err := MnemonicToEntropy(strings.Repeat("abandon ", 12)); err != errMnemonicChecksum {
+		t.Fatalf("Expected %s, got %v", errMnemonicChecksum, err)
+	}
+	if _, err := MnemonicToEntropy(strings.Repeat("abandon ", 11)); err != errInvalidMnemonicLen {
+		t.Fatalf("Expected %s, got %v", errInvalidMnemonicLen, err)
+	}
+	if _, err := MnemonicToEntropy(strings.Repeat("abandon ", 11) + "gecko"); err == nil {
+		t.Fatalf("Should have errored due to an unknown word")
+	}
+	if _, err := MnemonicToSeed(strings.Repeat("abandon ", 12), ""); err == nil {
+		t.Fatalf("Should have errored due to an invalid checksum")
+	}
+}
@@ -0,0 +1,69 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package formatting
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AddressSep separates the chain alias of an address from the rest of it
+const AddressSep = "-"
+
+// Human readable parts of the addresses of each network. The network IDs are
+// the ones hardcoded in the genesis package.
+const (
+	MainnetHRP  = "ava"
+	CascadeHRP  = "cascade"
+	LocalHRP    = "local"
+	FallbackHRP = "custom"
+)
+
+var (
+	errNoChainAlias = errors.New("address is missing the chain alias")
+
+	networkHRPs = map[uint32]string{
+		1:     MainnetHRP,
+		2:     CascadeHRP,
+		12345: LocalHRP,
+	}
+)
+
+// NetworkHRP returns the human readable part of the addresses of the network
+// with ID [networkID]
+func NetworkHRP(networkID uint32) string {
+	if hrp, ok := networkHRPs[networkID]; ok {
+		return hrp
+	}
+	return FallbackHRP
+}
+
+// FormatAddress returns the Bech32 address "<chainAlias>-<hrp>1<data>" of
+// [addr] on the chain [chainAlias] of the network with human readable part
+// [hrp]
+func FormatAddress(chainAlias, hrp string, addr []byte) (string, error) {
+	if chainAlias == "" {
+		return "", errNoChainAlias
+	}
+	bech32, err := FormatBech32(hrp, addr)
+	if err != nil {
+		return "", err
+	}
+	return chainAlias + AddressSep + bech32, nil
+}
+
+// ParseAddress returns the chain alias, human readable part and address of the
+// Bech32 address [addrStr], which is formatted as by FormatAddress
+func ParseAddress(addrStr string) (string, string, []byte, error) {
+	addressParts := strings.SplitN(addrStr, AddressSep, 2)
+	if len(addressParts) != 2 || addressParts[0] == "" {
+		return "", "", nil, errNoChainAlias
+	}
+	hrp, addr, err := ParseBech32(addressParts[1])
+	if err != nil {
+		return "", "", nil, fmt.Errorf("couldn't parse %q: %w", addrStr, err)
+	}
+	return addressParts[0], hrp, addr, nil
+}
@@ -0,0 +1,180 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package formatting
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// bech32Charset maps 5 bit values to the characters they're encoded as
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// bech32Separator separates the human readable part from the data
+	bech32Separator = '1'
+
+	// bech32ChecksumLen is the number of characters the checksum takes up
+	bech32ChecksumLen = 6
+
+	// bech32MaxLen is the length of the longest Bech32 string
+	bech32MaxLen = 90
+)
+
+var (
+	// ErrBech32Checksum is returned when a string is formatted as a Bech32
+	// string, but its checksum doesn't match. This is almost always caused by
+	// a typo.
+	ErrBech32Checksum = errors.New("invalid bech32 checksum")
+
+	errBech32Length    = fmt.Errorf("bech32 string must be at most %d characters", bech32MaxLen)
+	errBech32MixedCase = errors.New("bech32 string can't mix upper and lower case")
+	errBech32Separator = errors.New("bech32 string is missing the separator or checksum")
+	errBech32HRP       = errors.New("bech32 human readable part must be 1 to 83 printable characters")
+	errBech32Padding   = errors.New("bech32 data has invalid padding")
+
+	bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+)
+
+// FormatBech32 returns the Bech32 encoding, as specified by BIP 173, of
+// [payload] with the human readable part [hrp]
+func FormatBech32(hrp string, payload []byte) (string, error) {
+	hrp = strings.ToLower(hrp)
+	if err := verifyHRP(hrp); err != nil {
+		return "", err
+	}
+	data, err := convertBits(payload, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	if len(hrp)+1+len(data)+bech32ChecksumLen > bech32MaxLen {
+		return "", errBech32Length
+	}
+
+	checksum := bech32Checksum(hrp, data)
+
+	sb := strings.Builder{}
+	sb.Grow(len(hrp) + 1 + len(data) + len(checksum))
+	sb.WriteString(hrp)
+	sb.WriteByte(bech32Separator)
+	for _, b := range data {
+		sb.WriteByte(bech32Charset[b])
+	}
+	for _, b := range checksum {
+		sb.WriteByte(bech32Charset[b])
+	}
+	return sb.String(), nil
+}
+
+// ParseBech32 returns the human readable part and the payload of the Bech32
+// string [str]
+func ParseBech32(str string) (string, []byte, error) {
+	if len(str) > bech32MaxLen {
+		return "", nil, errBech32Length
+	}
+	lower := strings.ToLower(str)
+	if lower != str && strings.ToUpper(str) != str {
+		return "", nil, errBech32MixedCase
+	}
+
+	separator := strings.LastIndexByte(lower, bech32Separator)
+	if separator < 0 || separator+1+bech32ChecksumLen > len(lower) {
+		return "", nil, errBech32Separator
+	}
+	hrp := lower[:separator]
+	if err := verifyHRP(hrp); err != nil {
+		return "", nil, err
+	}
+
+	data := make([]byte, len(lower)-separator-1)
+	for i := range data {
+		value := strings.IndexByte(bech32Charset, lower[separator+1+i])
+		if value < 0 {
+			return "", nil, fmt.Errorf("invalid bech32 character %q", lower[separator+1+i])
+		}
+		data[i] = byte(value)
+	}
+	if bech32Polymod(hrp, data) != 1 {
+		return "", nil, ErrBech32Checksum
+	}
+
+	payload, err := convertBits(data[:len(data)-bech32ChecksumLen], 5, 8, false)
+	return hrp, payload, err
+}
+
+func verifyHRP(hrp string) error {
+	if len(hrp) == 0 || len(hrp) > 83 {
+		return errBech32HRP
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return errBech32HRP
+		}
+	}
+	return nil
+}
+
+// bech32Polymod returns the checksum state after consuming [hrp] and [data]
+func bech32Polymod(hrp string, data []byte) uint32 {
+	chk := uint32(1)
+	step := func(value byte) {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(value)
+		for i, generator := range bech32Generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator
+			}
+		}
+	}
+	for i := 0; i < len(hrp); i++ {
+		step(hrp[i] >> 5)
+	}
+	step(0)
+	for i := 0; i < len(hrp); i++ {
+		step(hrp[i] & 31)
+	}
+	for _, value := range data {
+		step(value)
+	}
+	return chk
+}
+
+// bech32Checksum returns the 5 bit values of the checksum of [hrp] and [data]
+func bech32Checksum(hrp string, data []byte) []byte {
+	padded := make([]byte, len(data)+bech32ChecksumLen)
+	copy(padded, data)
+	polymod := bech32Polymod(hrp, padded) ^ 1
+
+	checksum := make([]byte, bech32ChecksumLen)
+	for i := range checksum {
+		checksum[i] = byte(polymod>>uint(5*(bech32ChecksumLen-1-i))) & 31
+	}
+	return checksum
+}
+
+// convertBits regroups [data], made of [fromBits] bit values, into [toBits]
+// bit values. If [pad] is true, the last value is padded with zeros.
+// Otherwise, the leftover bits must be fewer than [fromBits] and zero.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxValue := uint32(1)<<toBits - 1
+	converted := make([]byte, 0, (uint(len(data))*fromBits+toBits-1)/toBits)
+	for _, value := range data {
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			converted = append(converted, byte(acc>>bits&maxValue))
+		}
+	}
+	switch {
+	case pad && bits > 0:
+		converted = append(converted, byte(acc<<(toBits-bits)&maxValue))
+	case !pad && (bits >= fromBits || acc<<(toBits-bits)&maxValue != 0):
+		return nil, errBech32Padding
+	}
+	return converted, nil
+}
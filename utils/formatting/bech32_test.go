@@ -0,0 +1,116 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package formatting
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBech32(t *testing.T) {
+	payload := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+	result, err := FormatBech32("ava", payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "ava1qqqsyqcyq5rqwzqfpg9scrgwpugpzysn7dkewd"
+	if result != expected {
+		t.Fatalf("Expected %s, got %s", expected, result)
+	}
+
+	hrp, parsed, err := ParseBech32(strings.ToUpper(result))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hrp != "ava" {
+		t.Fatalf("Expected human readable part %s, got %s", "ava", hrp)
+	}
+	if !bytes.Equal(parsed, payload) {
+		t.Fatalf("Expected 0x%x, got 0x%x", payload, parsed)
+	}
+}
+
+func TestParseBech32Vectors(t *testing.T) {
+	// Valid checksums from BIP 173
+	tests := []struct {
+		str     string
+		hrp     string
+		payload []byte
+	}{
+		{"A12UEL5L", "a", []byte{}},
+		{"a12uel5l", "a", []byte{}},
+		{
+			"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+			"abcdef",
+			[]byte{0x00, 0x44, 0x32, 0x14, 0xc7, 0x42, 0x54, 0xb6, 0x35, 0xcf, 0x84, 0x65, 0x3a, 0x56, 0xd7, 0xc6, 0x75, 0xbe, 0x77, 0xdf},
+		},
+	}
+	for _, test := range tests {
+		hrp, payload, err := ParseBech32(test.str)
+		if err != nil {
+			t.Fatalf("Parsing %s errored: %s", test.str, err)
+		}
+		if hrp != test.hrp {
+			t.Fatalf("Parsing %s returned human readable part %s ; Expected: %s", test.str, hrp, test.hrp)
+		}
+		if !bytes.Equal(payload, test.payload) {
+			t.Fatalf("Parsing %s returned 0x%x ; Expected: 0x%x", test.str, payload, test.payload)
+		}
+	}
+}
+
+func TestParseBech32Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"pzry9x0s0muk",  // no separator
+		"1pzry9x0s0muk", // empty human readable part
+		"x1b4n0q5v",     // invalid character
+		"li1dgmt3",      // checksum too short
+		"A1G7SGD8",      // checksum calculated with upper case
+		"a12UEL5L",      // mixed case
+		"ava1qqqsyqcyq5rqwzqfpg9scrgwpugpzysn7dkewe",                           // typo in the checksum
+		"ava1qqqsyqcyq5rqwzqfpg9scrgwpugpzysn7dkewd" + strings.Repeat("q", 50), // too long
+	}
+	for _, test := range tests {
+		if _, _, err := ParseBech32(test); err == nil {
+			t.Fatalf("Parsing %q should have errored", test)
+		}
+	}
+
+	if _, _, err := ParseBech32("ava1qqqsyqcyq5rqwzqfpg9scrgwpugpzysn7dkewe"); err != ErrBech32Checksum {
+		t.Fatalf("Expected %s, got %s", ErrBech32Checksum, err)
+	}
+}
+
+func TestAddress(t *testing.T) {
+	addr := make([]byte, 20)
+	addrStr, err := FormatAddress("X", NetworkHRP(12345), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "X-local1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqljssag"
+	if addrStr != expected {
+		t.Fatalf("Expected %s, got %s", expected, addrStr)
+	}
+
+	chainAlias, hrp, parsed, err := ParseAddress(addrStr)
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case chainAlias != "X":
+		t.Fatalf("Expected chain alias %s, got %s", "X", chainAlias)
+	case hrp != LocalHRP:
+		t.Fatalf("Expected human readable part %s, got %s", LocalHRP, hrp)
+	case !bytes.Equal(parsed, addr):
+		t.Fatalf("Expected 0x%x, got 0x%x", addr, parsed)
+	}
+
+	if _, _, _, err := ParseAddress("local1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqljssag"); err == nil {
+		t.Fatalf("Parsing an address without a chain alias should have errored")
+	}
+	if hrp := NetworkHRP(4294967295); hrp != FallbackHRP {
+		t.Fatalf("Expected human readable part %s, got %s", FallbackHRP, hrp)
+	}
+}
@@ -0,0 +1,157 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/bloom"
+	"github.com/ava-labs/gecko/utils/formatting"
+)
+
+const (
+	// Maximum number of addresses, or asset IDs, a filter can list
+	maxFilterSize = 1024
+)
+
+var (
+	errFilterTooLarge = fmt.Errorf("filters can list at most %d addresses and %d asset IDs", maxFilterSize, maxFilterSize)
+	errEmptyFilter    = errors.New("filter must list addresses or asset IDs, or provide a bloom filter")
+)
+
+// Filterable is implemented by published values that subscriptions can filter
+// on. Values that don't implement it are only sent to unfiltered
+// subscriptions.
+type Filterable interface {
+	// FilterAddresses returns the addresses the value involves
+	FilterAddresses() [][]byte
+
+	// FilterAssetIDs returns the assets the value involves
+	FilterAssetIDs() []ids.ID
+}
+
+// AddressParser parses the addresses that subscriptions filter on into their
+// byte representation
+type AddressParser interface {
+	Parse(address string) ([]byte, error)
+}
+
+// FilterArgs is the filter a subscription can provide to only be sent the
+// values of a channel that are relevant to it
+type FilterArgs struct {
+	// Addresses matches the values that involve any of the addresses
+	Addresses []string `json:"addresses"`
+
+	// AssetIDs matches the values that involve any of the assets
+	AssetIDs []string `json:"assetIDs"`
+
+	// Bloom matches the values that involve an address in the bloom filter.
+	// Light clients use it to subscribe without revealing their addresses.
+	Bloom *BloomArgs `json:"bloom"`
+}
+
+// BloomArgs is a bloom filter of addresses. See utils/bloom for how addresses
+// are added to it.
+type BloomArgs struct {
+	Filter formatting.CB58 `json:"filter"`
+	Hashes Uint32          `json:"hashes"`
+}
+
+// filter is the parsed form of FilterArgs. A value matches the filter if it
+// involves a listed address, or an address in the bloom filter, and a listed
+// asset. Criteria that aren't provided match every value.
+type filter struct {
+	addresses map[string]struct{}
+	bloom     *bloom.Filter
+	assetIDs  ids.Set
+}
+
+// newFilter returns the filter described by [args], parsing addresses with
+// [parser]
+func newFilter(args *FilterArgs, parser AddressParser) (*filter, error) {
+	if len(args.Addresses) > maxFilterSize || len(args.AssetIDs) > maxFilterSize {
+		return nil, errFilterTooLarge
+	}
+	if len(args.Addresses) == 0 && len(args.AssetIDs) == 0 && args.Bloom == nil {
+		return nil, errEmptyFilter
+	}
+
+	f := &filter{}
+	if len(args.Addresses) > 0 {
+		f.addresses = make(map[string]struct{}, len(args.Addresses))
+		for _, addrStr := range args.Addresses {
+			addr, err := parser.Parse(addrStr)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+			}
+			f.addresses[string(addr)] = struct{}{}
+		}
+	}
+	if args.Bloom != nil {
+		bloomFilter, err := bloom.Parse(args.Bloom.Filter.Bytes, int(args.Bloom.Hashes))
+		if err != nil {
+			return nil, err
+		}
+		f.bloom = bloomFilter
+	}
+	for _, assetIDStr := range args.AssetIDs {
+		assetID, err := ids.FromString(assetIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse asset ID %q: %w", assetIDStr, err)
+		}
+		f.assetIDs.Add(assetID)
+	}
+	return f, nil
+}
+
+// matches returns true if [value] should be sent to the subscription
+func (f *filter) matches(value interface{}) bool {
+	if f == nil {
+		return true
+	}
+	filterable, ok := value.(Filterable)
+	if !ok {
+		return false
+	}
+	return f.matchesAddresses(filterable.FilterAddresses()) && f.matchesAssetIDs(filterable.FilterAssetIDs())
+}
+
+func (f *filter) matchesAddresses(addrs [][]byte) bool {
+	if f.addresses == nil && f.bloom == nil {
+		return true
+	}
+	for _, addr := range addrs {
+		if _, ok := f.addresses[string(addr)]; ok {
+			return true
+		}
+		if f.bloom != nil && f.bloom.Check(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *filter) matchesAssetIDs(assetIDs []ids.ID) bool {
+	if f.assetIDs.Len() == 0 {
+		return true
+	}
+	for _, assetID := range assetIDs {
+		if f.assetIDs.Contains(assetID) {
+			return true
+		}
+	}
+	return false
+}
+
+// cb58Parser parses addresses as CB58 strings. It's used by servers that
+// weren't given an AddressParser.
+type cb58Parser struct{}
+
+func (cb58Parser) Parse(address string) ([]byte, error) {
+	cb58 := formatting.CB58{}
+	err := cb58.FromString(address)
+	return cb58.Bytes, err
+}
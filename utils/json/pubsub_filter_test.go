@@ -0,0 +1,90 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/bloom"
+	"github.com/ava-labs/gecko/utils/formatting"
+)
+
+type testValue struct {
+	addrs    [][]byte
+	assetIDs []ids.ID
+}
+
+func (v testValue) FilterAddresses() [][]byte { return v.addrs }
+func (v testValue) FilterAssetIDs() []ids.ID  { return v.assetIDs }
+
+func TestFilterMatches(t *testing.T) {
+	addr := []byte{1, 2, 3}
+	otherAddr := []byte{4, 5, 6}
+	assetID := ids.NewID([32]byte{1})
+	otherAssetID := ids.NewID([32]byte{2})
+
+	f, err := newFilter(&FilterArgs{
+		Addresses: []string{formatting.CB58{Bytes: addr}.String()},
+		AssetIDs:  []string{assetID.String()},
+	}, cb58Parser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !f.matches(testValue{addrs: [][]byte{otherAddr, addr}, assetIDs: []ids.ID{assetID}}) {
+		t.Fatalf("Filter should match a value with a listed address and asset")
+	}
+	if f.matches(testValue{addrs: [][]byte{addr}, assetIDs: []ids.ID{otherAssetID}}) {
+		t.Fatalf("Filter shouldn't match a value without a listed asset")
+	}
+	if f.matches(testValue{addrs: [][]byte{otherAddr}, assetIDs: []ids.ID{assetID}}) {
+		t.Fatalf("Filter shouldn't match a value without a listed address")
+	}
+	if f.matches(assetID) {
+		t.Fatalf("Filter shouldn't match a value that isn't filterable")
+	}
+
+	var unfiltered *filter
+	if !unfiltered.matches(assetID) {
+		t.Fatalf("A nil filter should match every value")
+	}
+}
+
+func TestFilterBloom(t *testing.T) {
+	addr := []byte{1, 2, 3}
+	b, err := bloom.New(64, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Add(addr)
+
+	f, err := newFilter(&FilterArgs{
+		Bloom: &BloomArgs{
+			Filter: formatting.CB58{Bytes: b.Bytes()},
+			Hashes: Uint32(b.Hashes()),
+		},
+	}, cb58Parser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.matches(testValue{addrs: [][]byte{addr}}) {
+		t.Fatalf("Filter should match an address in the bloom filter")
+	}
+	if f.matches(testValue{addrs: [][]byte{{4, 5, 6}}}) {
+		t.Fatalf("Filter shouldn't match an address not in the bloom filter")
+	}
+}
+
+func TestFilterInvalid(t *testing.T) {
+	if _, err := newFilter(&FilterArgs{}, cb58Parser{}); err != errEmptyFilter {
+		t.Fatalf("Should have errored with %s, got %v", errEmptyFilter, err)
+	}
+	if _, err := newFilter(&FilterArgs{Addresses: make([]string, maxFilterSize+1)}, cb58Parser{}); err != errFilterTooLarge {
+		t.Fatalf("Should have errored with %s, got %v", errFilterTooLarge, err)
+	}
+	if _, err := newFilter(&FilterArgs{AssetIDs: []string{"not an ID"}}, cb58Parser{}); err == nil {
+		t.Fatalf("Should have errored on an invalid asset ID")
+	}
+}
@@ -30,8 +30,9 @@ const (
 	// Send pings to peer with this period. Must be less than pongWait.
 	pingPeriod = (pongWait * 9) / 10
 
-	// Maximum message size allowed from peer.
-	maxMessageSize = 512 // bytes
+	// Maximum message size allowed from peer. Large enough for a subscription
+	// with a full filter.
+	maxMessageSize = 256 * 1024 // bytes
 
 	// Maximum number of pending messages to send to a peer.
 	maxPendingMessages = 256 // messages
@@ -49,18 +50,26 @@ var (
 
 // PubSubServer maintains the set of active clients and sends messages to the clients.
 type PubSubServer struct {
-	ctx *snow.Context
+	ctx    *snow.Context
+	parser AddressParser
 
-	lock     sync.Mutex
-	conns    map[*Connection]map[string]struct{}
+	lock sync.Mutex
+	// conns maps each connection to the channels it's subscribed to, along
+	// with the filter of each subscription. A nil filter matches every value.
+	conns    map[*Connection]map[string]*filter
 	channels map[string]map[*Connection]struct{}
 }
 
-// NewPubSubServer ...
-func NewPubSubServer(ctx *snow.Context) *PubSubServer {
+// NewPubSubServer returns a server whose subscriptions filter on addresses
+// parsed by [parser]. If [parser] is nil, addresses are parsed as CB58.
+func NewPubSubServer(ctx *snow.Context, parser AddressParser) *PubSubServer {
+	if parser == nil {
+		parser = cb58Parser{}
+	}
 	return &PubSubServer{
 		ctx:      ctx,
-		conns:    make(map[*Connection]map[string]struct{}),
+		parser:   parser,
+		conns:    make(map[*Connection]map[string]*filter),
 		channels: make(map[string]map[*Connection]struct{}),
 	}
 }
@@ -92,6 +101,9 @@ func (s *PubSubServer) Publish(channel string, msg interface{}) {
 	}
 
 	for conn := range conns {
+		if !s.conns[conn][channel].matches(msg) {
+			continue
+		}
 		select {
 		case conn.send <- pubMsg:
 		default:
@@ -116,7 +128,7 @@ func (s *PubSubServer) Register(channel string) error {
 func (s *PubSubServer) addConnection(conn *Connection) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.conns[conn] = make(map[string]struct{})
+	s.conns[conn] = make(map[string]*filter)
 
 	go conn.writePump()
 	go conn.readPump()
@@ -137,7 +149,9 @@ func (s *PubSubServer) removeConnection(conn *Connection) {
 	}
 }
 
-func (s *PubSubServer) addChannel(conn *Connection, channel string) {
+// addChannel subscribes [conn] to [channel], replacing the filter of any
+// existing subscription with [f]
+func (s *PubSubServer) addChannel(conn *Connection, channel string, f *filter) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -151,7 +165,7 @@ func (s *PubSubServer) addChannel(conn *Connection, channel string) {
 		return
 	}
 
-	channels[channel] = struct{}{}
+	channels[channel] = f
 	conns[conn] = struct{}{}
 }
 
@@ -179,8 +193,15 @@ type publish struct {
 }
 
 type subscribe struct {
-	Channel     string `json:"channel"`
-	Unsubscribe bool   `json:"unsubscribe"`
+	Channel     string      `json:"channel"`
+	Unsubscribe bool        `json:"unsubscribe"`
+	Filter      *FilterArgs `json:"filter"`
+}
+
+// subscribeError is sent to a connection whose subscription was refused
+type subscribeError struct {
+	Channel string `json:"channel"`
+	Error   string `json:"error"`
 }
 
 // Connection is a representation of the websocket connection.
@@ -220,9 +241,22 @@ func (c *Connection) readPump() {
 		}
 		if msg.Unsubscribe {
 			c.s.removeChannel(c, msg.Channel)
-		} else {
-			c.s.addChannel(c, msg.Channel)
+			continue
+		}
+
+		var f *filter
+		if msg.Filter != nil {
+			f, err = newFilter(msg.Filter, c.s.parser)
+			if err != nil {
+				c.s.ctx.Log.Debug("refusing subscription to %s due to %s", msg.Channel, err)
+				select {
+				case c.send <- &subscribeError{Channel: msg.Channel, Error: err.Error()}:
+				default:
+				}
+				continue
+			}
 		}
+		c.s.addChannel(c, msg.Channel, f)
 	}
 }
 
@@ -19,6 +19,13 @@ type Config struct {
 	DisableLogging, DisableDisplaying, DisableContextualDisplaying, DisableFlushOnWrite, Assertions bool
 	LogLevel, DisplayLevel                                                                          Level
 	Directory, MsgPrefix                                                                            string
+
+	// JSONFormat makes every line logged a JSON object, rather than colored
+	// text, so that the logs can be parsed by log pipelines
+	JSONFormat bool
+
+	// ChainID and Subsystem identify the logger in JSON formatted lines
+	ChainID, Subsystem string
 }
 
 // DefaultConfig ...
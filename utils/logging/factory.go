@@ -5,6 +5,7 @@ package logging
 
 import (
 	"path"
+	"sync"
 
 	"github.com/ava-labs/gecko/ids"
 )
@@ -14,14 +15,39 @@ type Factory interface {
 	Make() (Logger, error)
 	MakeChain(chainID ids.ID, subdir string) (Logger, error)
 	MakeSubdir(subdir string) (Logger, error)
+
+	// Loggers returns a description of every logger made by this factory
+	Loggers() []LoggerInfo
+
+	// SetLevels sets the log and display levels of the loggers that match
+	// [chainID] and [subsystem], and returns how many loggers matched. A nil
+	// level is left unchanged. An empty [chainID] or [subsystem] matches every
+	// chain or subsystem.
+	SetLevels(chainID, subsystem string, logLevel, displayLevel *Level) int
+
 	Close()
 }
 
+// LoggerInfo describes a logger made by a factory
+type LoggerInfo struct {
+	// ChainID is the chain the logger was made for, or empty if it wasn't
+	// made for a chain
+	ChainID string
+
+	// Subsystem is the subdirectory the logger writes to, or empty if it
+	// writes to the root of its directory
+	Subsystem string
+
+	LogLevel     Level
+	DisplayLevel Level
+}
+
 // factory ...
 type factory struct {
 	config Config
 
-	loggers []Logger
+	lock    sync.Mutex
+	loggers []*Log
 }
 
 // NewFactory ...
@@ -33,40 +59,88 @@ func NewFactory(config Config) Factory {
 
 // Make ...
 func (f *factory) Make() (Logger, error) {
-	l, err := New(f.config)
-	if err == nil {
-		f.loggers = append(f.loggers, l)
-	}
-	return l, err
+	return f.make(f.config)
 }
 
 // MakeChain ...
 func (f *factory) MakeChain(chainID ids.ID, subdir string) (Logger, error) {
 	config := f.config
 	config.MsgPrefix = "chain " + chainID.String()
+	config.ChainID = chainID.String()
+	config.Subsystem = subdir
 	config.Directory = path.Join(config.Directory, "chain", chainID.String(), subdir)
-
-	log, err := New(config)
-	if err == nil {
-		f.loggers = append(f.loggers, log)
-	}
-	return log, err
+	return f.make(config)
 }
 
 // MakeSubdir ...
 func (f *factory) MakeSubdir(subdir string) (Logger, error) {
 	config := f.config
 	config.Directory = path.Join(config.Directory, subdir)
+	config.Subsystem = subdir
+	return f.make(config)
+}
 
+// make returns a new logger with [config] and tracks it
+func (f *factory) make(config Config) (Logger, error) {
 	log, err := New(config)
-	if err == nil {
-		f.loggers = append(f.loggers, log)
+	if err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.loggers = append(f.loggers, log)
+	return log, nil
+}
+
+// Loggers ...
+func (f *factory) Loggers() []LoggerInfo {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	infos := make([]LoggerInfo, len(f.loggers))
+	for i, log := range f.loggers {
+		infos[i] = log.info()
 	}
-	return log, err
+	return infos
+}
+
+// SetLevels ...
+func (f *factory) SetLevels(chainID, subsystem string, logLevel, displayLevel *Level) int {
+	return f.apply(chainID, subsystem, func(log *Log) {
+		if logLevel != nil {
+			log.SetLogLevel(*logLevel)
+		}
+		if displayLevel != nil {
+			log.SetDisplayLevel(*displayLevel)
+		}
+	})
+}
+
+// apply calls [fn] on every logger that matches [chainID] and [subsystem],
+// and returns how many loggers matched
+func (f *factory) apply(chainID, subsystem string, fn func(*Log)) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	matched := 0
+	for _, log := range f.loggers {
+		info := log.info()
+		if (chainID != "" && info.ChainID != chainID) || (subsystem != "" && info.Subsystem != subsystem) {
+			continue
+		}
+		fn(log)
+		matched++
+	}
+	return matched
 }
 
 // Close ...
 func (f *factory) Close() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
 	for _, log := range f.loggers {
 		log.Stop()
 	}
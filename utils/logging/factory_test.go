@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+func TestFactorySetLevels(t *testing.T) {
+	dir, err := ioutil.TempDir("", "factory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config, err := DefaultConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Directory = dir
+	config.LogLevel = Info
+	config.DisplayLevel = Info
+	config.DisableDisplaying = true
+
+	f := NewFactory(config)
+	defer f.Close()
+
+	chainX := ids.NewID([32]byte{1})
+	chainY := ids.NewID([32]byte{2})
+	if _, err := f.Make(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.MakeSubdir("http"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.MakeChain(chainX, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.MakeChain(chainX, "http"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.MakeChain(chainY, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if loggers := f.Loggers(); len(loggers) != 5 {
+		t.Fatalf("Factory should have made 5 loggers, has %d", len(loggers))
+	}
+
+	verbo, debug := Verbo, Debug
+	if updated := f.SetLevels(chainX.String(), "", &verbo, nil); updated != 2 {
+		t.Fatalf("Should have updated the 2 loggers of chain X, updated %d", updated)
+	}
+	if updated := f.SetLevels("", "http", nil, &debug); updated != 2 {
+		t.Fatalf("Should have updated the 2 http loggers, updated %d", updated)
+	}
+
+	for _, info := range f.Loggers() {
+		expectedLogLevel := Info
+		if info.ChainID == chainX.String() {
+			expectedLogLevel = Verbo
+		}
+		expectedDisplayLevel := Info
+		if info.Subsystem == "http" {
+			expectedDisplayLevel = Debug
+		}
+		if info.LogLevel != expectedLogLevel || info.DisplayLevel != expectedDisplayLevel {
+			t.Fatalf("Wrong levels for logger %+v", info)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package logging
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
@@ -120,7 +121,7 @@ func (l *Log) Stop() {
 }
 
 // Should only be called from [Level] functions.
-func (l *Log) log(level Level, format string, args ...interface{}) {
+func (l *Log) log(level Level, fields []interface{}, format string, args ...interface{}) {
 	if l == nil {
 		return
 	}
@@ -135,7 +136,7 @@ func (l *Log) log(level Level, format string, args ...interface{}) {
 		return
 	}
 
-	output := l.format(level, format, args...)
+	output := l.format(level, fields, format, args...)
 
 	if shouldLog {
 		l.flushLock.Lock()
@@ -146,15 +147,18 @@ func (l *Log) log(level Level, format string, args ...interface{}) {
 	}
 
 	if shouldDisplay {
-		if l.config.DisableContextualDisplaying {
-			fmt.Println(fmt.Sprintf(format, args...))
-		} else {
+		switch {
+		case l.config.JSONFormat:
+			fmt.Print(output)
+		case l.config.DisableContextualDisplaying:
+			fmt.Println(fmt.Sprintf(format, args...) + formatFields(fields))
+		default:
 			fmt.Print(level.Color().Wrap(output))
 		}
 	}
 }
 
-func (l *Log) format(level Level, format string, args ...interface{}) string {
+func (l *Log) format(level Level, fields []interface{}, format string, args ...interface{}) string {
 	loc := "?"
 	if _, file, no, ok := runtime.Caller(3); ok {
 		loc = fmt.Sprintf("%s#%d", file, no)
@@ -162,7 +166,11 @@ func (l *Log) format(level Level, format string, args ...interface{}) string {
 	if i := strings.Index(loc, "gecko/"); i != -1 {
 		loc = loc[i+5:]
 	}
-	text := fmt.Sprintf("%s: %s", loc, fmt.Sprintf(format, args...))
+	msg := fmt.Sprintf(format, args...)
+	if l.config.JSONFormat {
+		return l.formatJSON(level, loc, msg, fields)
+	}
+	text := fmt.Sprintf("%s: %s%s", loc, msg, formatFields(fields))
 
 	prefix := ""
 	if l.config.MsgPrefix != "" {
@@ -176,28 +184,95 @@ func (l *Log) format(level Level, format string, args ...interface{}) string {
 		text)
 }
 
+// formatJSON returns a line containing a JSON object describing the message
+func (l *Log) formatJSON(level Level, loc, msg string, fields []interface{}) string {
+	entry := make(map[string]interface{}, 6+len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		var value interface{} = "(MISSING)"
+		if i+1 < len(fields) {
+			value = fieldValue(fields[i+1])
+		}
+		entry[fmt.Sprint(fields[i])] = value
+	}
+
+	// The standard keys take precedence over the fields
+	entry["timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = strings.TrimSpace(level.String())
+	entry["caller"] = loc
+	entry["message"] = msg
+	if l.config.ChainID != "" {
+		entry["chainID"] = l.config.ChainID
+	}
+	if l.config.Subsystem != "" {
+		entry["subsystem"] = l.config.Subsystem
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		line, _ = json.Marshal(map[string]string{
+			"timestamp": entry["timestamp"].(string),
+			"level":     entry["level"].(string),
+			"caller":    loc,
+			"message":   msg,
+			"error":     fmt.Sprintf("couldn't marshal log fields: %s", err),
+		})
+	}
+	return string(line) + "\n"
+}
+
+// formatFields returns the key/value pairs in [fields] as " key=value" text
+func formatFields(fields []interface{}) string {
+	sb := strings.Builder{}
+	for i := 0; i < len(fields); i += 2 {
+		var value interface{} = "(MISSING)"
+		if i+1 < len(fields) {
+			value = fieldValue(fields[i+1])
+		}
+		sb.WriteString(fmt.Sprintf(" %v=%v", fields[i], value))
+	}
+	return sb.String()
+}
+
+// fieldValue returns the representation of a field value in a log line
+func fieldValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case error:
+		return value.Error()
+	case fmt.Stringer:
+		return value.String()
+	default:
+		return value
+	}
+}
+
 // Fatal ...
-func (l *Log) Fatal(format string, args ...interface{}) { l.log(Fatal, format, args...) }
+func (l *Log) Fatal(format string, args ...interface{}) { l.log(Fatal, nil, format, args...) }
 
 // Error ...
-func (l *Log) Error(format string, args ...interface{}) { l.log(Error, format, args...) }
+func (l *Log) Error(format string, args ...interface{}) { l.log(Error, nil, format, args...) }
 
 // Warn ...
-func (l *Log) Warn(format string, args ...interface{}) { l.log(Warn, format, args...) }
+func (l *Log) Warn(format string, args ...interface{}) { l.log(Warn, nil, format, args...) }
 
 // Info ...
-func (l *Log) Info(format string, args ...interface{}) { l.log(Info, format, args...) }
+func (l *Log) Info(format string, args ...interface{}) { l.log(Info, nil, format, args...) }
 
 // Debug ...
-func (l *Log) Debug(format string, args ...interface{}) { l.log(Debug, format, args...) }
+func (l *Log) Debug(format string, args ...interface{}) { l.log(Debug, nil, format, args...) }
 
 // Verbo ...
-func (l *Log) Verbo(format string, args ...interface{}) { l.log(Verbo, format, args...) }
+func (l *Log) Verbo(format string, args ...interface{}) { l.log(Verbo, nil, format, args...) }
+
+// LogKV logs [msg] at [level] along with the alternating keys and values in
+// [keyvals], such as "txID", txID
+func (l *Log) LogKV(level Level, msg string, keyvals ...interface{}) {
+	l.log(level, keyvals, "%s", msg)
+}
 
 // AssertNoError ...
 func (l *Log) AssertNoError(err error) {
 	if err != nil {
-		l.log(Fatal, "%s", err)
+		l.log(Fatal, nil, "%s", err)
 	}
 	if l.config.Assertions && err != nil {
 		l.Stop()
@@ -208,7 +283,7 @@ func (l *Log) AssertNoError(err error) {
 // AssertTrue ...
 func (l *Log) AssertTrue(b bool, format string, args ...interface{}) {
 	if !b {
-		l.log(Fatal, format, args...)
+		l.log(Fatal, nil, format, args...)
 	}
 	if l.config.Assertions && !b {
 		l.Stop()
@@ -221,7 +296,7 @@ func (l *Log) AssertDeferredTrue(f func() bool, format string, args ...interface
 	// Note, the logger will only be notified here if assertions are enabled
 	if l.config.Assertions && !f() {
 		err := fmt.Sprintf(format, args...)
-		l.log(Fatal, err)
+		l.log(Fatal, nil, "%s", err)
 		l.Stop()
 		panic(err)
 	}
@@ -232,7 +307,7 @@ func (l *Log) AssertDeferredNoError(f func() error) {
 	if l.config.Assertions {
 		err := f()
 		if err != nil {
-			l.log(Fatal, "%s", err)
+			l.log(Fatal, nil, "%s", err)
 		}
 		if l.config.Assertions && err != nil {
 			l.Stop()
@@ -269,6 +344,19 @@ func (l *Log) SetDisplayLevel(lvl Level) {
 	l.config.DisplayLevel = lvl
 }
 
+// info returns a description of this logger
+func (l *Log) info() LoggerInfo {
+	l.configLock.Lock()
+	defer l.configLock.Unlock()
+
+	return LoggerInfo{
+		ChainID:      l.config.ChainID,
+		Subsystem:    l.config.Subsystem,
+		LogLevel:     l.config.LogLevel,
+		DisplayLevel: l.config.DisplayLevel,
+	}
+}
+
 // SetPrefix ...
 func (l *Log) SetPrefix(prefix string) {
 	l.configLock.Lock()
@@ -0,0 +1,60 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+func TestFormatJSON(t *testing.T) {
+	l := &Log{config: Config{
+		JSONFormat: true,
+		ChainID:    "chain",
+		Subsystem:  "http",
+	}}
+	txID := ids.NewID([32]byte{1})
+	line := l.format(Warn, []interface{}{"txID", txID, "err", errors.New("oops"), "amount", 5, "dangling"}, "tx %d failed", 3)
+	if !strings.HasSuffix(line, "\n") || strings.Count(line, "\n") != 1 {
+		t.Fatalf("Expected a single line, got %q", line)
+	}
+
+	entry := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"level":     "WARN",
+		"message":   "tx 3 failed",
+		"chainID":   "chain",
+		"subsystem": "http",
+		"txID":      txID.String(),
+		"err":       "oops",
+		"amount":    float64(5),
+		"dangling":  "(MISSING)",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Fatalf("Expected %s to be %v, got %v", key, value, entry[key])
+		}
+	}
+	if _, ok := entry["timestamp"]; !ok {
+		t.Fatalf("Missing timestamp")
+	}
+	if _, ok := entry["caller"]; !ok {
+		t.Fatalf("Missing caller")
+	}
+}
+
+func TestFormatText(t *testing.T) {
+	l := &Log{}
+	line := l.format(Info, []interface{}{"txID", "abc"}, "accepted")
+	if !strings.Contains(line, "accepted txID=abc") {
+		t.Fatalf("Expected the fields to follow the message, got %q", line)
+	}
+}
@@ -30,6 +30,9 @@ type Logger interface {
 	// Log extremely detailed events that can be useful for inspecting every
 	// aspect of the program
 	Verbo(format string, args ...interface{})
+	// Log [msg] at [level] along with the alternating keys and values in
+	// [keyvals], so that fields such as a txID can be attached to the message
+	LogKV(level Level, msg string, keyvals ...interface{})
 
 	// If assertions are enabled, will result in a panic if err is non-nil
 	AssertNoError(err error)
@@ -19,5 +19,11 @@ func (NoFactory) MakeChain(ids.ID, string) (Logger, error) { return NoLog{}, nil
 // MakeSubdir ...
 func (NoFactory) MakeSubdir(string) (Logger, error) { return NoLog{}, nil }
 
+// Loggers ...
+func (NoFactory) Loggers() []LoggerInfo { return nil }
+
+// SetLevels ...
+func (NoFactory) SetLevels(string, string, *Level, *Level) int { return 0 }
+
 // Close ...
 func (NoFactory) Close() {}
@@ -34,6 +34,9 @@ func (NoLog) Debug(format string, args ...interface{}) {}
 // Verbo ...
 func (NoLog) Verbo(format string, args ...interface{}) {}
 
+// LogKV ...
+func (NoLog) LogKV(Level, string, ...interface{}) {}
+
 // AssertNoError ...
 func (NoLog) AssertNoError(error) {}
 
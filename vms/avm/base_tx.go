@@ -75,8 +75,9 @@ func (t *BaseTx) UTXOs() []*ava.UTXO {
 	return utxos
 }
 
-// SyntacticVerify that this transaction is well-formed.
-func (t *BaseTx) SyntacticVerify(ctx *snow.Context, c codec.Codec, _ int) error {
+// SyntacticVerify that this transaction is well-formed. The inputs must
+// consume enough of [txFeeAssetID] to burn [txFee].
+func (t *BaseTx) SyntacticVerify(ctx *snow.Context, c codec.Codec, txFeeAssetID ids.ID, txFee uint64, _ int) error {
 	switch {
 	case t == nil:
 		return errNilTx
@@ -87,6 +88,9 @@ func (t *BaseTx) SyntacticVerify(ctx *snow.Context, c codec.Codec, _ int) error
 	}
 
 	fc := ava.NewFlowChecker()
+	if txFee > 0 {
+		fc.Produce(txFeeAssetID, txFee)
+	}
 	for _, out := range t.Outs {
 		if err := out.Verify(); err != nil {
 			return err
@@ -107,8 +111,6 @@ func (t *BaseTx) SyntacticVerify(ctx *snow.Context, c codec.Codec, _ int) error
 		return errInputsNotSortedUnique
 	}
 
-	// TODO: Add the Tx fee to the produced side
-
 	if err := fc.Verify(); err != nil {
 		return err
 	}
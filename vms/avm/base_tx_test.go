@@ -210,16 +210,59 @@ func TestBaseTxSyntacticVerify(t *testing.T) {
 	}
 	tx.Initialize([]byte{})
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err != nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err != nil {
 		t.Fatal(err)
 	}
 }
 
+func TestBaseTxSyntacticVerifyFee(t *testing.T) {
+	c := setupCodec()
+
+	tx := &BaseTx{
+		NetID: networkID,
+		BCID:  chainID,
+		Outs: []*ava.TransferableOutput{&ava.TransferableOutput{
+			Asset: ava.Asset{ID: asset},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 12345,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+				},
+			},
+		}},
+		Ins: []*ava.TransferableInput{&ava.TransferableInput{
+			UTXOID: ava.UTXOID{
+				TxID:        ids.NewID([32]byte{0xff}),
+				OutputIndex: 0,
+			},
+			Asset: ava.Asset{ID: asset},
+			In: &secp256k1fx.TransferInput{
+				Amt: 54321,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{2},
+				},
+			},
+		}},
+	}
+	tx.Initialize([]byte{})
+
+	if err := tx.SyntacticVerify(ctx, c, asset, 54321-12345, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(ctx, c, asset, 54321-12345+1, 0); err == nil {
+		t.Fatalf("Tx should have failed verification due to an insufficient fee")
+	}
+	if err := tx.SyntacticVerify(ctx, c, ids.NewID([32]byte{1}), 1, 0); err == nil {
+		t.Fatalf("Tx should have failed verification due to the fee being paid in the wrong asset")
+	}
+}
+
 func TestBaseTxSyntacticVerifyNil(t *testing.T) {
 	c := setupCodec()
 
 	tx := (*BaseTx)(nil)
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Nil BaseTx should have errored")
 	}
 }
@@ -261,7 +304,7 @@ func TestBaseTxSyntacticVerifyWrongNetworkID(t *testing.T) {
 	}
 	tx.Initialize([]byte{})
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Wrong networkID should have errored")
 	}
 }
@@ -303,7 +346,7 @@ func TestBaseTxSyntacticVerifyWrongChainID(t *testing.T) {
 	}
 	tx.Initialize([]byte{})
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Wrong chain ID should have errored")
 	}
 }
@@ -336,7 +379,7 @@ func TestBaseTxSyntacticVerifyInvalidOutput(t *testing.T) {
 	}
 	tx.Initialize([]byte{})
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Invalid output should have errored")
 	}
 }
@@ -392,7 +435,7 @@ func TestBaseTxSyntacticVerifyUnsortedOutputs(t *testing.T) {
 	}
 	tx.Initialize([]byte{})
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Unsorted outputs should have errored")
 	}
 }
@@ -417,7 +460,7 @@ func TestBaseTxSyntacticVerifyInvalidInput(t *testing.T) {
 	}
 	tx.Initialize([]byte{})
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Invalid input should have errored")
 	}
 }
@@ -479,7 +522,7 @@ func TestBaseTxSyntacticVerifyInputOverflow(t *testing.T) {
 	}
 	tx.Initialize([]byte{})
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Input overflow should have errored")
 	}
 }
@@ -533,7 +576,7 @@ func TestBaseTxSyntacticVerifyOutputOverflow(t *testing.T) {
 	}
 	tx.Initialize([]byte{})
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Output overflow should have errored")
 	}
 }
@@ -575,7 +618,7 @@ func TestBaseTxSyntacticVerifyInsufficientFunds(t *testing.T) {
 	}
 	tx.Initialize([]byte{})
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Insufficient funds should have errored")
 	}
 }
@@ -616,7 +659,7 @@ func TestBaseTxSyntacticVerifyUninitialized(t *testing.T) {
 		}},
 	}
 
-	if err := tx.SyntacticVerify(ctx, c, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0); err == nil {
 		t.Fatalf("Uninitialized tx should have errored")
 	}
 }
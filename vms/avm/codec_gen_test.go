@@ -0,0 +1,90 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/components/codec"
+	"github.com/ava-labs/gecko/vms/components/codec/codecgen"
+	"github.com/ava-labs/gecko/vms/components/verify"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+func TestGeneratedCodec(t *testing.T) {
+	c := setupCodec()
+	for _, value := range []interface{}{
+		&Tx{},
+		&BaseTx{},
+		&CreateAssetTx{},
+		&OperationTx{},
+		&ImportTx{},
+		&ExportTx{},
+	} {
+		if err := codecgen.CheckEquivalence(c, value, 200, 0); err != nil {
+			t.Fatalf("%T: %s", value, err)
+		}
+	}
+}
+
+func benchmarkTx() *Tx {
+	return &Tx{
+		UnsignedTx: &BaseTx{
+			NetID: networkID,
+			BCID:  chainID,
+			Ins: []*ava.TransferableInput{{
+				UTXOID: ava.UTXOID{TxID: ids.Empty},
+				Asset:  ava.Asset{ID: asset},
+				In: &secp256k1fx.TransferInput{
+					Amt:   1000,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			}},
+			Outs: []*ava.TransferableOutput{{
+				Asset: ava.Asset{ID: asset},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 1000,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+					},
+				},
+			}},
+		},
+		Creds: []verify.Verifiable{&secp256k1fx.Credential{
+			Sigs: [][crypto.SECP256K1RSigLen]byte{{}},
+		}},
+	}
+}
+
+func benchmarkMarshalTx(b *testing.B, c codec.Codec) {
+	tx := benchmarkTx()
+	txBytes, err := c.Marshal(tx)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.Marshal(tx); err != nil {
+			b.Fatal(err)
+		}
+		if err := c.Unmarshal(txBytes, &Tx{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalTxGenerated(b *testing.B) { benchmarkMarshalTx(b, setupCodec()) }
+
+func BenchmarkMarshalTxReflection(b *testing.B) {
+	c, err := codec.NewReflective(setupCodec())
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkMarshalTx(b, c)
+}
@@ -87,7 +87,7 @@ func TestExportTxSerialization(t *testing.T) {
 		}},
 	}}}
 
-	c := codec.NewDefault()
+	c := codec.NewDefaultManager(CodecVersion)
 	c.RegisterType(&BaseTx{})
 	c.RegisterType(&CreateAssetTx{})
 	c.RegisterType(&OperationTx{})
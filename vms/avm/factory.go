@@ -4,6 +4,8 @@
 package avm
 
 import (
+	"time"
+
 	"github.com/ava-labs/gecko/ids"
 )
 
@@ -16,6 +18,20 @@ var (
 type Factory struct {
 	AVA      ids.ID
 	Platform ids.ID
+
+	// Fee is the amount of AVA that must be burned by every transaction
+	// verified at or after FeeActivation. Every node of the network must use
+	// the same fee and activation time.
+	Fee           uint64
+	FeeActivation time.Time
+
+	// IndexTxs enables the indexing of accepted transactions by address
+	IndexTxs bool
+
+	// StateCacheSize is the number of bytes of state that are cached.
+	// IDCacheSize and TxCacheSize are the number of state IDs and
+	// transactions that are cached. Non-positive sizes use the defaults.
+	StateCacheSize, IDCacheSize, TxCacheSize int
 }
 
 // New ...
@@ -23,5 +39,13 @@ func (f *Factory) New() (interface{}, error) {
 	return &VM{
 		ava:      f.AVA,
 		platform: f.Platform,
+		txFee:    f.Fee,
+		indexTxs: f.IndexTxs,
+
+		txFeeActivation: f.FeeActivation,
+
+		stateCacheSize: f.StateCacheSize,
+		idCacheSize:    f.IDCacheSize,
+		txCacheSize:    f.TxCacheSize,
 	}, nil
 }
@@ -0,0 +1,26 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build ignore
+// +build ignore
+
+// This program generates codec_gen.go. If a change to a type stops the
+// package from compiling, delete codec_gen.go before running it.
+package main
+
+import (
+	"github.com/ava-labs/gecko/vms/avm"
+	"github.com/ava-labs/gecko/vms/components/codec/codecgen"
+)
+
+func main() {
+	codecgen.Main(
+		"codec_gen.go",
+		&avm.Tx{},
+		&avm.BaseTx{},
+		&avm.CreateAssetTx{},
+		&avm.OperationTx{},
+		&avm.ImportTx{},
+		&avm.ExportTx{},
+	)
+}
@@ -50,7 +50,7 @@ var (
 )
 
 // SyntacticVerify that this transaction is well-formed.
-func (t *ImportTx) SyntacticVerify(ctx *snow.Context, c codec.Codec, numFxs int) error {
+func (t *ImportTx) SyntacticVerify(ctx *snow.Context, c codec.Codec, txFeeAssetID ids.ID, txFee uint64, numFxs int) error {
 	switch {
 	case t == nil:
 		return errNilTx
@@ -63,6 +63,9 @@ func (t *ImportTx) SyntacticVerify(ctx *snow.Context, c codec.Codec, numFxs int)
 	}
 
 	fc := ava.NewFlowChecker()
+	if txFee > 0 {
+		fc.Produce(txFeeAssetID, txFee)
+	}
 	for _, out := range t.Outs {
 		if err := out.Verify(); err != nil {
 			return err
@@ -93,8 +96,6 @@ func (t *ImportTx) SyntacticVerify(ctx *snow.Context, c codec.Codec, numFxs int)
 		return errInputsNotSortedUnique
 	}
 
-	// TODO: Add the Tx fee to the produced side
-
 	return fc.Verify()
 }
 
@@ -0,0 +1,387 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"container/list"
+	"errors"
+	"time"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/consensus/snowstorm"
+	"github.com/ava-labs/gecko/vms/components/ava"
+
+	safemath "github.com/ava-labs/gecko/utils/math"
+)
+
+const (
+	// maxMempoolTxs is the maximum number of transactions that can be waiting
+	// to be issued to consensus
+	maxMempoolTxs = 4096
+
+	// maxMempoolSize is the maximum number of bytes of transactions that can
+	// be waiting to be issued to consensus
+	maxMempoolSize = 64 << 20 // 64 MiB
+
+	// mempoolTxTTL is how long a transaction can wait to be issued to
+	// consensus before it is evicted
+	mempoolTxTTL = 10 * time.Minute
+
+	// mempoolIssuedTxTTL is how long a transaction can be processing in
+	// consensus before its inputs are no longer reserved. Consensus can drop
+	// issued transactions without deciding them, so this bounds how long
+	// their inputs are considered spent.
+	mempoolIssuedTxTTL = time.Hour
+)
+
+var (
+	errDuplicateTx           = errors.New("transaction is already pending")
+	errConflictingTx         = errors.New("transaction conflicts with a pending transaction that burns at least as much")
+	errConflictsWithIssued   = errors.New("transaction conflicts with a transaction that is already being processed by consensus")
+	errMempoolFull           = errors.New("mempool is full")
+	errMempoolTxTooLarge     = errors.New("transaction is larger than the mempool")
+	errPendingTxNotFound     = errors.New("transaction isn't pending")
+	errUnknownUnsignedTxType = errors.New("unknown unsigned transaction type")
+)
+
+// mempoolEntry is a transaction that has been submitted to this node and
+// hasn't been decided yet
+type mempoolEntry struct {
+	tx       *UniqueTx
+	size     int
+	addedAt  time.Time
+	issuedAt time.Time
+
+	// burned is the amount of the fee asset this transaction burns
+	burned uint64
+
+	// issued is true once the entry was handed to consensus
+	issued bool
+
+	// element is the position of this entry in the queue of transactions
+	// waiting to be issued to consensus, or in the list of issued
+	// transactions once the entry was issued
+	element *list.Element
+}
+
+// mempool tracks the transactions that were submitted to this node until they
+// are decided.
+//
+// Transactions wait in a queue until the engine asks for them, after which
+// they are considered issued until they are accepted or rejected, or until
+// they have been issued for longer than the issued TTL. Every
+// transaction is indexed by the utxos it consumes, so that conflicting
+// submissions are caught before being issued into consensus. A submission
+// that conflicts only with queued transactions replaces them if it burns
+// strictly more of the fee asset than each of them, otherwise it is rejected.
+//
+// Queued transactions that are removed without being decided, because they
+// were replaced, evicted or conflict with an accepted transaction, are passed
+// to onDrop.
+type mempool struct {
+	feeAssetID ids.ID
+
+	// Limits on the queue of transactions waiting to be issued
+	maxTxs, maxSize int
+	ttl, issuedTTL  time.Duration
+
+	onDrop func(*UniqueTx)
+
+	queue    *list.List // of *mempoolEntry, oldest first
+	issued   *list.List // of *mempoolEntry, oldest first
+	size     int        // bytes of the queued transactions
+	entries  map[[32]byte]*mempoolEntry
+	spenders map[[32]byte]ids.ID // consumed inputID -> txID
+}
+
+func newMempool(feeAssetID ids.ID, maxTxs, maxSize int, ttl, issuedTTL time.Duration, onDrop func(*UniqueTx)) *mempool {
+	return &mempool{
+		feeAssetID: feeAssetID,
+		maxTxs:     maxTxs,
+		maxSize:    maxSize,
+		ttl:        ttl,
+		issuedTTL:  issuedTTL,
+		onDrop:     onDrop,
+		queue:      list.New(),
+		issued:     list.New(),
+		entries:    make(map[[32]byte]*mempoolEntry),
+		spenders:   make(map[[32]byte]ids.ID),
+	}
+}
+
+// Add [tx] to the queue of transactions waiting to be issued. Returns the
+// queued transactions that were replaced by [tx].
+func (m *mempool) Add(tx *UniqueTx, now time.Time) ([]*UniqueTx, error) {
+	m.EvictStale(now)
+
+	txID := tx.ID()
+	if _, exists := m.entries[txID.Key()]; exists {
+		return nil, errDuplicateTx
+	}
+
+	burned, err := m.burned(tx)
+	if err != nil {
+		return nil, err
+	}
+	entry := &mempoolEntry{
+		tx:      tx,
+		size:    len(tx.Bytes()),
+		addedAt: now,
+		burned:  burned,
+	}
+	if entry.size > m.maxSize {
+		return nil, errMempoolTxTooLarge
+	}
+
+	conflicts := m.conflicts(tx)
+	for _, conflict := range conflicts {
+		switch {
+		case conflict.issued:
+			return nil, errConflictsWithIssued
+		case conflict.burned >= burned:
+			return nil, errConflictingTx
+		}
+	}
+
+	queuedTxs := m.queue.Len() - len(conflicts)
+	queuedSize := m.size + entry.size
+	for _, conflict := range conflicts {
+		queuedSize -= conflict.size
+	}
+	if queuedTxs >= m.maxTxs || queuedSize > m.maxSize {
+		return nil, errMempoolFull
+	}
+
+	replaced := make([]*UniqueTx, len(conflicts))
+	for i, conflict := range conflicts {
+		m.drop(conflict)
+		replaced[i] = conflict.tx
+	}
+
+	entry.element = m.queue.PushBack(entry)
+	m.size += entry.size
+	m.entries[txID.Key()] = entry
+	for _, in := range tx.InputUTXOs() {
+		m.spenders[in.InputID().Key()] = txID
+	}
+	return replaced, nil
+}
+
+// Issue removes every queued transaction from the queue and marks them as
+// issued at [now]. The transactions are returned in the order they were added.
+func (m *mempool) Issue(now time.Time) []snowstorm.Tx {
+	txs := make([]snowstorm.Tx, 0, m.queue.Len())
+	for e := m.queue.Front(); e != nil; e = m.queue.Front() {
+		entry := e.Value.(*mempoolEntry)
+		m.issue(entry, now)
+		txs = append(txs, entry.tx)
+	}
+	return txs
+}
+
+// SetIssued marks [tx] as issued at [now] if it's queued, as consensus is
+// already processing it
+func (m *mempool) SetIssued(tx *UniqueTx, now time.Time) {
+	if entry, exists := m.entries[tx.ID().Key()]; exists && !entry.issued {
+		m.issue(entry, now)
+	}
+}
+
+// Remove [tx] from the mempool
+func (m *mempool) Remove(tx *UniqueTx) {
+	if entry, exists := m.entries[tx.ID().Key()]; exists {
+		m.remove(entry)
+	}
+}
+
+// RemoveConflicts removes [tx], along with every transaction that conflicts
+// with it, from the mempool. Returns the removed transactions other than [tx].
+func (m *mempool) RemoveConflicts(tx *UniqueTx) []*UniqueTx {
+	m.Remove(tx)
+
+	conflicts := m.conflicts(tx)
+	removed := make([]*UniqueTx, len(conflicts))
+	for i, conflict := range conflicts {
+		m.drop(conflict)
+		removed[i] = conflict.tx
+	}
+	return removed
+}
+
+// Issued returns true if [txID] was handed to consensus and hasn't been
+// decided yet. Returns an error if [txID] isn't in the mempool.
+func (m *mempool) Issued(txID ids.ID) (bool, error) {
+	entry, exists := m.entries[txID.Key()]
+	if !exists {
+		return false, errPendingTxNotFound
+	}
+	return entry.issued, nil
+}
+
+// Spent returns true if a transaction in the mempool consumes [inputID]
+func (m *mempool) Spent(inputID ids.ID) bool {
+	_, spent := m.spenders[inputID.Key()]
+	return spent
+}
+
+// TxIDs returns the IDs of the queued transactions, in the order they were
+// added, and the IDs of the issued transactions.
+func (m *mempool) TxIDs() ([]ids.ID, []ids.ID) {
+	queued := make([]ids.ID, 0, m.queue.Len())
+	for e := m.queue.Front(); e != nil; e = e.Next() {
+		queued = append(queued, e.Value.(*mempoolEntry).tx.ID())
+	}
+	issued := make([]ids.ID, 0, m.issued.Len())
+	for e := m.issued.Front(); e != nil; e = e.Next() {
+		issued = append(issued, e.Value.(*mempoolEntry).tx.ID())
+	}
+	return queued, issued
+}
+
+// Queued returns the transactions waiting to be issued, in the order they were
+// added
+func (m *mempool) Queued() []*UniqueTx {
+	txs := make([]*UniqueTx, 0, m.queue.Len())
+	for e := m.queue.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*mempoolEntry).tx)
+	}
+	return txs
+}
+
+// Len returns the number of transactions waiting to be issued
+func (m *mempool) Len() int { return m.queue.Len() }
+
+// Size returns the number of bytes of the transactions waiting to be issued
+func (m *mempool) Size() int { return m.size }
+
+// EvictStale removes the queued transactions that were added more than the
+// TTL before [now], and the issued transactions that were issued more than
+// the issued TTL before [now].
+//
+// Issued transactions that are evicted may still be decided by consensus, so
+// they aren't dropped. Their inputs can be spent by new submissions, which
+// consensus will treat as conflicts.
+func (m *mempool) EvictStale(now time.Time) {
+	for e := m.queue.Front(); e != nil; e = m.queue.Front() {
+		entry := e.Value.(*mempoolEntry)
+		if now.Sub(entry.addedAt) <= m.ttl {
+			break
+		}
+		m.drop(entry)
+	}
+	for e := m.issued.Front(); e != nil; e = m.issued.Front() {
+		entry := e.Value.(*mempoolEntry)
+		if now.Sub(entry.issuedAt) <= m.issuedTTL {
+			break
+		}
+		m.remove(entry)
+	}
+}
+
+// conflicts returns the entries that consume any of the utxos [tx] consumes,
+// other than [tx] itself
+func (m *mempool) conflicts(tx *UniqueTx) []*mempoolEntry {
+	txID := tx.ID()
+	conflictIDs := ids.Set{}
+	conflicts := []*mempoolEntry(nil)
+	for _, in := range tx.InputUTXOs() {
+		spenderID, spent := m.spenders[in.InputID().Key()]
+		if !spent || spenderID.Equals(txID) || conflictIDs.Contains(spenderID) {
+			continue
+		}
+		conflictIDs.Add(spenderID)
+		conflicts = append(conflicts, m.entries[spenderID.Key()])
+	}
+	return conflicts
+}
+
+// drop removes [entry] and, if it was never issued, reports it to onDrop as
+// it will never be decided
+func (m *mempool) drop(entry *mempoolEntry) {
+	issued := entry.issued
+	m.remove(entry)
+	if !issued && m.onDrop != nil {
+		m.onDrop(entry.tx)
+	}
+}
+
+// issue moves [entry] from the queue to the issued transactions
+func (m *mempool) issue(entry *mempoolEntry, now time.Time) {
+	m.queue.Remove(entry.element)
+	m.size -= entry.size
+	entry.issued = true
+	entry.issuedAt = now
+	entry.element = m.issued.PushBack(entry)
+}
+
+func (m *mempool) remove(entry *mempoolEntry) {
+	if entry.issued {
+		m.issued.Remove(entry.element)
+	} else {
+		m.queue.Remove(entry.element)
+		m.size -= entry.size
+	}
+	entry.element = nil
+
+	txID := entry.tx.ID()
+	delete(m.entries, txID.Key())
+	for _, in := range entry.tx.InputUTXOs() {
+		inputKey := in.InputID().Key()
+		if spenderID, ok := m.spenders[inputKey]; ok && spenderID.Equals(txID) {
+			delete(m.spenders, inputKey)
+		}
+	}
+}
+
+// burned returns the amount of the fee asset that [tx] burns
+func (m *mempool) burned(tx *UniqueTx) (uint64, error) {
+	if m.feeAssetID.IsZero() {
+		return 0, nil
+	}
+
+	ins, outs, err := flows(tx.Tx)
+	if err != nil {
+		return 0, err
+	}
+
+	consumed := uint64(0)
+	for _, in := range ins {
+		if in.AssetID().Equals(m.feeAssetID) {
+			consumed, err = safemath.Add64(consumed, in.Input().Amount())
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	produced := uint64(0)
+	for _, out := range outs {
+		if out.AssetID().Equals(m.feeAssetID) {
+			produced, err = safemath.Add64(produced, out.Output().Amount())
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	return safemath.Sub64(consumed, produced)
+}
+
+// flows returns the transferable inputs and outputs of [tx]
+func flows(tx *Tx) ([]*ava.TransferableInput, []*ava.TransferableOutput, error) {
+	switch utx := tx.UnsignedTx.(type) {
+	case *BaseTx:
+		return utx.Ins, utx.Outs, nil
+	case *CreateAssetTx:
+		return utx.Ins, utx.Outs, nil
+	case *OperationTx:
+		return utx.Ins, utx.Outs, nil
+	case *ImportTx:
+		ins := append([]*ava.TransferableInput(nil), utx.BaseTx.Ins...)
+		return append(ins, utx.Ins...), utx.Outs, nil
+	case *ExportTx:
+		outs := append([]*ava.TransferableOutput(nil), utx.BaseTx.Outs...)
+		return utx.Ins, append(outs, utx.Outs...), nil
+	default:
+		return nil, nil, errUnknownUnsignedTxType
+	}
+}
@@ -0,0 +1,232 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+// newMempoolTestTx returns a tx with [size] bytes that consumes one utxo of
+// [asset] per entry in [inputs], burning [burned] of the asset
+func newMempoolTestTx(id byte, size int, burned uint64, inputs ...byte) *UniqueTx {
+	tx := &BaseTx{}
+	for _, input := range inputs {
+		tx.Ins = append(tx.Ins, &ava.TransferableInput{
+			UTXOID: ava.UTXOID{TxID: ids.NewID([32]byte{input})},
+			Asset:  ava.Asset{ID: asset},
+			In:     &secp256k1fx.TransferInput{Amt: burned + 1},
+		})
+	}
+	if len(inputs) > 0 {
+		tx.Outs = append(tx.Outs, &ava.TransferableOutput{
+			Asset: ava.Asset{ID: asset},
+			Out:   &secp256k1fx.TransferOutput{Amt: uint64(len(inputs))*(burned+1) - burned},
+		})
+	}
+	tx.Initialize(make([]byte, size))
+
+	return &UniqueTx{
+		TxState: &TxState{
+			Tx:     &Tx{UnsignedTx: tx},
+			unique: true,
+		},
+		txID: ids.NewID([32]byte{id}),
+	}
+}
+
+func TestMempoolAddAndIssue(t *testing.T) {
+	m := newMempool(asset, 10, 1000, time.Minute, time.Hour, nil)
+	now := time.Unix(0, 0)
+
+	tx0 := newMempoolTestTx(0, 10, 1, 0)
+	tx1 := newMempoolTestTx(1, 10, 1, 1)
+	if _, err := m.Add(tx0, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Add(tx1, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Add(tx0, now); err != errDuplicateTx {
+		t.Fatalf("Should have errored with %s, got %v", errDuplicateTx, err)
+	}
+	if m.Len() != 2 || m.Size() != 20 {
+		t.Fatalf("Wrong mempool size: %d txs, %d bytes", m.Len(), m.Size())
+	}
+
+	txs := m.Issue(now)
+	if len(txs) != 2 || !txs[0].ID().Equals(tx0.ID()) || !txs[1].ID().Equals(tx1.ID()) {
+		t.Fatalf("Txs should have been issued in the order they were added")
+	}
+	if m.Len() != 0 || m.Size() != 0 {
+		t.Fatalf("Queue should be empty after issuing")
+	}
+	if issued, err := m.Issued(tx0.ID()); err != nil || !issued {
+		t.Fatalf("Tx should be marked as issued")
+	}
+
+	// A tx that conflicts with an issued tx can't be replaced
+	if _, err := m.Add(newMempoolTestTx(2, 10, 5, 0), now); err != errConflictsWithIssued {
+		t.Fatalf("Should have errored with %s, got %v", errConflictsWithIssued, err)
+	}
+
+	m.Remove(tx0)
+	if _, err := m.Issued(tx0.ID()); err != errPendingTxNotFound {
+		t.Fatalf("Removed tx should no longer be pending")
+	}
+	if m.Spent(tx0.InputUTXOs()[0].InputID()) {
+		t.Fatalf("Removed tx's inputs should no longer be marked as spent")
+	}
+}
+
+func TestMempoolConflicts(t *testing.T) {
+	m := newMempool(asset, 10, 1000, time.Minute, time.Hour, nil)
+	now := time.Unix(0, 0)
+
+	tx0 := newMempoolTestTx(0, 10, 2, 0, 1)
+	if _, err := m.Add(tx0, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// Doesn't burn more than the tx it conflicts with
+	if _, err := m.Add(newMempoolTestTx(1, 10, 2, 1), now); err != errConflictingTx {
+		t.Fatalf("Should have errored with %s, got %v", errConflictingTx, err)
+	}
+
+	tx2 := newMempoolTestTx(2, 10, 3, 1)
+	replaced, err := m.Add(tx2, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replaced) != 1 || !replaced[0].ID().Equals(tx0.ID()) {
+		t.Fatalf("Tx should have replaced the conflicting tx")
+	}
+	if m.Len() != 1 || m.Spent(tx0.InputUTXOs()[0].InputID()) {
+		t.Fatalf("Replaced tx should have been removed")
+	}
+
+	// Accepting a tx drops the pending txs that conflict with it
+	removed := m.RemoveConflicts(newMempoolTestTx(3, 10, 0, 1))
+	if len(removed) != 1 || !removed[0].ID().Equals(tx2.ID()) || m.Len() != 0 {
+		t.Fatalf("Conflicting pending tx should have been removed")
+	}
+}
+
+func TestMempoolLimits(t *testing.T) {
+	m := newMempool(asset, 2, 25, time.Minute, time.Hour, nil)
+	now := time.Unix(0, 0)
+
+	if _, err := m.Add(newMempoolTestTx(0, 30, 1, 0), now); err != errMempoolTxTooLarge {
+		t.Fatalf("Should have errored with %s, got %v", errMempoolTxTooLarge, err)
+	}
+	if _, err := m.Add(newMempoolTestTx(1, 10, 1, 1), now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Add(newMempoolTestTx(2, 20, 1, 2), now); err != errMempoolFull {
+		t.Fatalf("Should have errored with %s due to size, got %v", errMempoolFull, err)
+	}
+	if _, err := m.Add(newMempoolTestTx(3, 10, 1, 3), now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Add(newMempoolTestTx(4, 1, 1, 4), now); err != errMempoolFull {
+		t.Fatalf("Should have errored with %s due to count, got %v", errMempoolFull, err)
+	}
+
+	// Stale txs are evicted to make room
+	later := now.Add(2 * time.Minute)
+	if _, err := m.Add(newMempoolTestTx(5, 10, 1, 5), later); err != nil {
+		t.Fatal(err)
+	}
+	if queued, _ := m.TxIDs(); len(queued) != 1 {
+		t.Fatalf("Stale txs should have been evicted, %d txs are queued", len(queued))
+	}
+}
+
+func TestMempoolDroppedTxs(t *testing.T) {
+	dropped := []ids.ID(nil)
+	m := newMempool(asset, 10, 1000, time.Minute, time.Hour, func(tx *UniqueTx) {
+		dropped = append(dropped, tx.ID())
+	})
+	now := time.Unix(0, 0)
+
+	tx0 := newMempoolTestTx(0, 10, 1, 0)
+	if _, err := m.Add(tx0, now); err != nil {
+		t.Fatal(err)
+	}
+	m.Issue(now)
+
+	// Replaced txs are dropped
+	tx1 := newMempoolTestTx(1, 10, 1, 1)
+	if _, err := m.Add(tx1, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Add(newMempoolTestTx(2, 10, 2, 1), now); err != nil {
+		t.Fatal(err)
+	}
+	if len(dropped) != 1 || !dropped[0].Equals(tx1.ID()) {
+		t.Fatalf("Replaced tx should have been dropped")
+	}
+
+	// Consensus never decided tx0, so its inputs are released once it has
+	// been issued for longer than the issued TTL
+	later := now.Add(time.Hour + time.Second)
+	tx3 := newMempoolTestTx(3, 10, 1, 0)
+	if _, err := m.Add(tx3, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Issued(tx0.ID()); err != errPendingTxNotFound {
+		t.Fatalf("Expired issued tx should no longer be pending")
+	}
+	if spenderID := m.spenders[tx0.InputUTXOs()[0].InputID().Key()]; !spenderID.Equals(tx3.ID()) {
+		t.Fatalf("Expired issued tx's inputs should be spendable by new txs")
+	}
+
+	// The stale queued tx is dropped, but the expired issued tx may still be
+	// decided by consensus, so it isn't
+	if len(dropped) != 2 || !dropped[1].Equals(ids.NewID([32]byte{2})) {
+		t.Fatalf("Only the stale queued tx should have been dropped, got %v", dropped)
+	}
+	if queued, issued := m.TxIDs(); len(queued) != 1 || len(issued) != 0 {
+		t.Fatalf("Wrong pending txs: %d queued, %d issued", len(queued), len(issued))
+	}
+}
+
+func TestMempoolSetIssued(t *testing.T) {
+	dropped := []ids.ID(nil)
+	m := newMempool(asset, 10, 1000, time.Minute, time.Hour, func(tx *UniqueTx) {
+		dropped = append(dropped, tx.ID())
+	})
+	now := time.Unix(0, 0)
+
+	tx0 := newMempoolTestTx(0, 10, 1, 0)
+	tx1 := newMempoolTestTx(1, 10, 1, 1)
+	if _, err := m.Add(tx0, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Add(tx1, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// Consensus learned about tx0 from another node
+	m.SetIssued(tx0, now)
+	if issued, err := m.Issued(tx0.ID()); err != nil || !issued {
+		t.Fatalf("Tx should have been issued, returned %v and %v", issued, err)
+	}
+	if m.Len() != 1 || m.Size() != 10 {
+		t.Fatalf("Wrong mempool size: %d txs, %d bytes", m.Len(), m.Size())
+	}
+	if txs := m.Issue(now); len(txs) != 1 || !txs[0].ID().Equals(tx1.ID()) {
+		t.Fatalf("Only the queued tx should have been issued")
+	}
+
+	// Issued txs aren't dropped once stale, as consensus may still decide them
+	m.EvictStale(now.Add(2 * time.Hour))
+	if len(dropped) != 0 {
+		t.Fatalf("Issued txs shouldn't have been dropped, dropped %v", dropped)
+	}
+}
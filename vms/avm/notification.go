@@ -0,0 +1,74 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/vms/components/ava"
+)
+
+// TxNotification is published over the "accepted" channel of the
+// notifications endpoint when a transaction is accepted. Subscriptions can
+// filter notifications by the addresses and assets involved. The pubsub
+// endpoint keeps publishing only the IDs of transactions.
+type TxNotification struct {
+	TxID ids.ID `json:"txID"`
+
+	// Addresses that own the UTXOs the transaction consumes or produces
+	Addresses []string `json:"addresses"`
+
+	// AssetIDs of the UTXOs the transaction consumes or produces
+	AssetIDs []ids.ID `json:"assetIDs"`
+
+	addrs [][]byte
+}
+
+// FilterAddresses implements the json.Filterable interface
+func (n *TxNotification) FilterAddresses() [][]byte { return n.addrs }
+
+// FilterAssetIDs implements the json.Filterable interface
+func (n *TxNotification) FilterAssetIDs() []ids.ID { return n.AssetIDs }
+
+// notification returns the notification published for this transaction.
+//
+// UTXOs that are consumed but aren't in the VM's state, such as imported
+// UTXOs, don't contribute to the notification. It reads the consumed UTXOs
+// from the VM's state, so it must be called while accepting the transaction,
+// before the transaction's side effects are executed.
+func (tx *UniqueTx) notification() *TxNotification {
+	n := &TxNotification{TxID: tx.ID()}
+
+	utxos := tx.UTXOs()
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		if utxo, err := tx.vm.state.UTXO(utxoID.InputID()); err == nil {
+			utxos = append(utxos, utxo)
+		}
+	}
+
+	addrs := map[string]struct{}{}
+	assetIDs := ids.Set{}
+	for _, utxo := range utxos {
+		if assetID := utxo.AssetID(); !assetIDs.Contains(assetID) {
+			assetIDs.Add(assetID)
+			n.AssetIDs = append(n.AssetIDs, assetID)
+		}
+
+		addressable, ok := utxo.Out.(ava.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addr := range addressable.Addresses() {
+			if _, exists := addrs[string(addr)]; exists {
+				continue
+			}
+			addrs[string(addr)] = struct{}{}
+			n.addrs = append(n.addrs, addr)
+			n.Addresses = append(n.Addresses, tx.vm.Format(addr))
+		}
+	}
+	return n
+}
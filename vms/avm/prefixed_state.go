@@ -4,32 +4,51 @@
 package avm
 
 import (
+	"bytes"
+	"math"
+
 	"github.com/ava-labs/gecko/cache"
+	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/choices"
 	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/wrappers"
 	"github.com/ava-labs/gecko/vms/components/ava"
+
+	safemath "github.com/ava-labs/gecko/utils/math"
 )
 
 const (
 	txID uint64 = iota
 	utxoID
 	txStatusID
-	fundsID
+	legacyFundsID // Previously used to store an address's utxoIDs as a single list
 	dbInitializedID
+	utxoIndexInitializedID
+	pendingTxsID
 )
 
 var (
-	dbInitialized = ids.Empty.Prefix(dbInitializedID)
+	dbInitialized        = ids.Empty.Prefix(dbInitializedID)
+	utxoIndexInitialized = ids.Empty.Prefix(utxoIndexInitializedID)
+	pendingTxs           = ids.Empty.Prefix(pendingTxsID)
 )
 
 // prefixedState wraps a state object. By prefixing the state, there will be no
 // collisions between different types of objects that have the same hash.
+//
+// The utxo and balance indices are stored outside of the prefixed state so
+// that their keys can be iterated over in order:
+//   utxoIndex:    address | utxoID          -> nil
+//   assetIndex:   address | assetID | utxoID -> nil
+//   balanceIndex: address | assetID          -> balance
 type prefixedState struct {
 	state *state
 
-	tx, utxo, txStatus, funds cache.Cacher
-	uniqueTx                  cache.Deduplicator
+	tx, utxo, txStatus cache.Cacher
+	uniqueTx           cache.Deduplicator
+
+	utxoIndex, assetIndex, balanceIndex database.Database
 }
 
 // UniqueTx de-duplicates the transaction.
@@ -74,15 +93,149 @@ func (s *prefixedState) SetDBInitialized(status choices.Status) error {
 	return s.state.SetStatus(dbInitialized, status)
 }
 
-// Funds returns the mapping from the 32 byte representation of an address to a
-// list of utxo IDs that reference the address.
-func (s *prefixedState) Funds(id ids.ID) ([]ids.ID, error) {
-	return s.state.IDs(uniqueID(id, fundsID, s.funds))
+// UTXOIndexInitialized returns the status of the utxo and balance indices. If
+// the indices haven't been built, the status will be unknown.
+func (s *prefixedState) UTXOIndexInitialized() (choices.Status, error) {
+	return s.state.Status(utxoIndexInitialized)
+}
+
+// SetUTXOIndexInitialized saves the provided status of the utxo and balance
+// indices.
+func (s *prefixedState) SetUTXOIndexInitialized(status choices.Status) error {
+	return s.state.SetStatus(utxoIndexInitialized, status)
+}
+
+// PendingTxs returns the IDs of the txs that were waiting to be issued when the
+// VM last shut down
+func (s *prefixedState) PendingTxs() ([]ids.ID, error) {
+	txIDs, err := s.state.IDs(pendingTxs)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	return txIDs, err
+}
+
+// SetPendingTxs saves the IDs of the txs waiting to be issued
+func (s *prefixedState) SetPendingTxs(txIDs []ids.ID) error {
+	return s.state.SetIDs(pendingTxs, txIDs)
+}
+
+// BuildUTXOIndex adds every utxo in [db], which is the database of the state,
+// to the utxo and balance indices, and removes the lists of utxoIDs that
+// databases stored per address before the indices existed.
+//
+// Utxos are stored under a hash of their ID, so they're found by decoding
+// every value and keeping the ones whose key matches the decoded utxo.
+func (s *prefixedState) BuildUTXOIndex(db database.Iteratee) error {
+	utxos := []*ava.UTXO(nil)
+	iter := db.NewIterator()
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) != hashing.HashLen {
+			continue
+		}
+		utxo := &ava.UTXO{}
+		if err := s.state.Codec.Unmarshal(iter.Value(), utxo); err != nil {
+			continue
+		}
+		if utxoKey := utxo.InputID().Prefix(utxoID); !bytes.Equal(key, utxoKey.Bytes()) {
+			continue
+		}
+		utxos = append(utxos, utxo)
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	for _, utxo := range utxos {
+		addressable, ok := utxo.Out.(ava.Addressable)
+		if !ok {
+			continue
+		}
+		addrs := addressable.Addresses()
+		if err := s.addUTXO(addrs, utxo); err != nil {
+			return err
+		}
+		for _, addr := range addrs {
+			addrID := ids.NewID(hashing.ComputeHash256Array(addr))
+			if err := s.state.DB.Delete(addrID.Prefix(legacyFundsID).Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return s.SetUTXOIndexInitialized(choices.Accepted)
+}
+
+// UTXOIDs returns at most [limit] IDs of utxos that reference the 32 byte
+// representation of an address, in ascending order. Only IDs strictly after
+// [start] are returned. If [start] is empty, iteration begins with the first
+// utxo. If [assetID] isn't empty, only utxos of that asset are returned. If
+// [limit] is not positive, every matching utxoID is returned.
+func (s *prefixedState) UTXOIDs(addr, assetID, start ids.ID, limit int) ([]ids.ID, error) {
+	db := s.utxoIndex
+	prefix := addr.Bytes()
+	if !assetID.IsZero() {
+		db = s.assetIndex
+		prefix = indexKey(addr.Bytes(), assetID)
+	}
+
+	startKey := prefix
+	if !start.IsZero() {
+		startKey = indexKey(prefix, start)
+	}
+
+	iter := db.NewIteratorWithStartAndPrefix(startKey, prefix)
+	defer iter.Release()
+
+	utxoIDs := []ids.ID(nil)
+	for (limit <= 0 || len(utxoIDs) < limit) && iter.Next() {
+		utxoID, err := ids.ToID(iter.Key()[len(prefix):])
+		if err != nil {
+			return nil, err
+		}
+		if utxoID.Equals(start) {
+			continue
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	return utxoIDs, iter.Error()
+}
+
+// Balance returns the amount of [assetID] that the 32 byte representation of
+// an address at least partially owns.
+func (s *prefixedState) Balance(addr, assetID ids.ID) (uint64, error) {
+	bytes, err := s.balanceIndex.Get(indexKey(addr.Bytes(), assetID))
+	if err == database.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return unpackBalance(bytes)
 }
 
-// SetFunds saves the mapping from address to utxo IDs to storage.
-func (s *prefixedState) SetFunds(id ids.ID, idSlice []ids.ID) error {
-	return s.state.SetIDs(uniqueID(id, fundsID, s.funds), idSlice)
+// Balances returns the IDs of the assets that the 32 byte representation of an
+// address at least partially owns, along with the amount of each asset.
+func (s *prefixedState) Balances(addr ids.ID) ([]ids.ID, []uint64, error) {
+	prefix := addr.Bytes()
+	iter := s.balanceIndex.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+
+	assetIDs := []ids.ID(nil)
+	balances := []uint64(nil)
+	for iter.Next() {
+		assetID, err := ids.ToID(iter.Key()[len(prefix):])
+		if err != nil {
+			return nil, nil, err
+		}
+		balance, err := unpackBalance(iter.Value())
+		if err != nil {
+			return nil, nil, err
+		}
+		assetIDs = append(assetIDs, assetID)
+		balances = append(balances, balance)
+	}
+	return assetIDs, balances, iter.Error()
 }
 
 // SpendUTXO consumes the provided utxo.
@@ -100,19 +253,25 @@ func (s *prefixedState) SpendUTXO(utxoID ids.ID) error {
 		return nil
 	}
 
-	return s.removeUTXO(addressable.Addresses(), utxoID)
+	return s.removeUTXO(addressable.Addresses(), utxo)
 }
 
-func (s *prefixedState) removeUTXO(addrs [][]byte, utxoID ids.ID) error {
+func (s *prefixedState) removeUTXO(addrs [][]byte, utxo *ava.UTXO) error {
+	utxoID := utxo.InputID()
+	assetID := utxo.AssetID()
 	for _, addr := range addrs {
 		addrID := ids.NewID(hashing.ComputeHash256Array(addr))
-		utxos := ids.Set{}
-		funds, _ := s.Funds(addrID)
-		utxos.Add(funds...)
-		utxos.Remove(utxoID)
-		if err := s.SetFunds(addrID, utxos.List()); err != nil {
+		if err := s.utxoIndex.Delete(indexKey(addrID.Bytes(), utxoID)); err != nil {
+			return err
+		}
+		if err := s.assetIndex.Delete(indexKey(indexKey(addrID.Bytes(), assetID), utxoID)); err != nil {
 			return err
 		}
+		if transferable, ok := utxo.Out.(ava.Transferable); ok {
+			if err := s.removeBalance(addrID, assetID, transferable.Amount()); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -129,19 +288,78 @@ func (s *prefixedState) FundUTXO(utxo *ava.UTXO) error {
 		return nil
 	}
 
-	return s.addUTXO(addressable.Addresses(), utxoID)
+	return s.addUTXO(addressable.Addresses(), utxo)
 }
 
-func (s *prefixedState) addUTXO(addrs [][]byte, utxoID ids.ID) error {
+func (s *prefixedState) addUTXO(addrs [][]byte, utxo *ava.UTXO) error {
+	utxoID := utxo.InputID()
+	assetID := utxo.AssetID()
 	for _, addr := range addrs {
 		addrID := ids.NewID(hashing.ComputeHash256Array(addr))
-		utxos := ids.Set{}
-		funds, _ := s.Funds(addrID)
-		utxos.Add(funds...)
-		utxos.Add(utxoID)
-		if err := s.SetFunds(addrID, utxos.List()); err != nil {
+		if err := s.utxoIndex.Put(indexKey(addrID.Bytes(), utxoID), nil); err != nil {
 			return err
 		}
+		if err := s.assetIndex.Put(indexKey(indexKey(addrID.Bytes(), assetID), utxoID), nil); err != nil {
+			return err
+		}
+		if transferable, ok := utxo.Out.(ava.Transferable); ok {
+			if err := s.addBalance(addrID, assetID, transferable.Amount()); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
+
+// addBalance increases the balance of an address. Balances that would overflow
+// are capped at MaxUint64.
+func (s *prefixedState) addBalance(addr, assetID ids.ID, amount uint64) error {
+	balance, err := s.Balance(addr, assetID)
+	if err != nil {
+		return err
+	}
+	newBalance, err := safemath.Add64(balance, amount)
+	if err != nil {
+		newBalance = math.MaxUint64
+	}
+	return s.setBalance(addr, assetID, newBalance)
+}
+
+// removeBalance decreases the balance of an address. Balances that would
+// underflow, which is only possible after a capped overflow, are set to 0.
+func (s *prefixedState) removeBalance(addr, assetID ids.ID, amount uint64) error {
+	balance, err := s.Balance(addr, assetID)
+	if err != nil {
+		return err
+	}
+	newBalance, err := safemath.Sub64(balance, amount)
+	if err != nil {
+		newBalance = 0
+	}
+	return s.setBalance(addr, assetID, newBalance)
+}
+
+func (s *prefixedState) setBalance(addr, assetID ids.ID, balance uint64) error {
+	key := indexKey(addr.Bytes(), assetID)
+	if balance == 0 {
+		return s.balanceIndex.Delete(key)
+	}
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.LongLen)}
+	p.PackLong(balance)
+	return s.balanceIndex.Put(key, p.Bytes)
+}
+
+func unpackBalance(bytes []byte) (uint64, error) {
+	p := wrappers.Packer{Bytes: bytes}
+	balance := p.UnpackLong()
+	return balance, p.Err
+}
+
+// indexKey returns [prefix] followed by the bytes of [id]
+func indexKey(prefix []byte, id ids.ID) []byte {
+	idBytes := id.Bytes()
+	key := make([]byte, len(prefix)+len(idBytes))
+	copy(key, prefix)
+	copy(key[len(prefix):], idBytes)
+	return key
+}
@@ -169,7 +169,8 @@ func TestPrefixedFundingAddresses(t *testing.T) {
 	if err := state.FundUTXO(utxo); err != nil {
 		t.Fatal(err)
 	}
-	funds, err := state.Funds(ids.NewID(hashing.ComputeHash256Array([]byte{0})))
+	addr := ids.NewID(hashing.ComputeHash256Array([]byte{0}))
+	funds, err := state.UTXOIDs(addr, ids.ID{}, ids.ID{}, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -182,8 +183,110 @@ func TestPrefixedFundingAddresses(t *testing.T) {
 	if err := state.SpendUTXO(utxo.InputID()); err != nil {
 		t.Fatal(err)
 	}
-	_, err = state.Funds(ids.NewID(hashing.ComputeHash256Array([]byte{0})))
-	if err == nil {
+	funds, err = state.UTXOIDs(addr, ids.ID{}, ids.ID{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(funds) != 0 {
 		t.Fatalf("Should have returned no utxoIDs")
 	}
 }
+
+func TestPrefixedUTXOIndex(t *testing.T) {
+	_, _, vm := GenesisVM(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	state := vm.state
+
+	shortAddr := ids.NewShortID([20]byte{7})
+	addr := ids.NewID(hashing.ComputeHash256Array(shortAddr.Bytes()))
+	assetA := ids.NewID([32]byte{1})
+	assetB := ids.NewID([32]byte{2})
+
+	utxoIDs := []ids.ID{}
+	for i := 0; i < 5; i++ {
+		assetID := assetA
+		if i%2 == 1 {
+			assetID = assetB
+		}
+		utxo := &ava.UTXO{
+			UTXOID: ava.UTXOID{
+				TxID:        ids.NewID([32]byte{42}),
+				OutputIndex: uint32(i),
+			},
+			Asset: ava.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 10,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{shortAddr},
+				},
+			},
+		}
+		if err := state.FundUTXO(utxo); err != nil {
+			t.Fatal(err)
+		}
+		utxoIDs = append(utxoIDs, utxo.InputID())
+	}
+	ids.SortIDs(utxoIDs)
+
+	// Page through the utxos two at a time
+	fetched := []ids.ID{}
+	start := ids.ID{}
+	for {
+		page, err := state.UTXOIDs(addr, ids.ID{}, start, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fetched = append(fetched, page...)
+		if len(page) < 2 {
+			break
+		}
+		start = page[len(page)-1]
+	}
+	if len(fetched) != len(utxoIDs) {
+		t.Fatalf("Should have returned %d utxoIDs, returned %d", len(utxoIDs), len(fetched))
+	}
+	for i, utxoID := range utxoIDs {
+		if !utxoID.Equals(fetched[i]) {
+			t.Fatalf("Returned utxoIDs out of order")
+		}
+	}
+
+	assetBUTXOIDs, err := state.UTXOIDs(addr, assetB, ids.ID{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assetBUTXOIDs) != 2 {
+		t.Fatalf("Should have returned 2 utxoIDs, returned %d", len(assetBUTXOIDs))
+	}
+
+	if balance, err := state.Balance(addr, assetA); err != nil {
+		t.Fatal(err)
+	} else if balance != 30 {
+		t.Fatalf("Balance should have been 30, was %d", balance)
+	}
+
+	if err := state.SpendUTXO(assetBUTXOIDs[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	assetIDs, balances, err := state.Balances(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assetIDs) != 2 || len(balances) != 2 {
+		t.Fatalf("Should have returned 2 balances")
+	}
+	for i, assetID := range assetIDs {
+		switch {
+		case assetID.Equals(assetA) && balances[i] != 30:
+			t.Fatalf("Balance of asset A should have been 30, was %d", balances[i])
+		case assetID.Equals(assetB) && balances[i] != 10:
+			t.Fatalf("Balance of asset B should have been 10, was %d", balances[i])
+		}
+	}
+}
@@ -7,9 +7,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"math"
 	"net/http"
 
+	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/choices"
 	"github.com/ava-labs/gecko/utils/crypto"
@@ -37,6 +37,7 @@ var (
 	errUnknownOutputType         = errors.New("unknown output type")
 	errUnneededAddress           = errors.New("address not required to sign")
 	errUnknownCredentialType     = errors.New("unknown credential type")
+	errNoAddresses               = errors.New("no addresses provided")
 )
 
 // Service defines the base service for the asset vm
@@ -99,11 +100,17 @@ func (service *Service) GetTxStatus(r *http.Request, args *GetTxStatusArgs, repl
 // GetTxArgs are arguments for passing into GetTx requests
 type GetTxArgs struct {
 	TxID ids.ID `json:"txID"`
+
+	// Encoding is either "cb58", the default, or "json"
+	Encoding string `json:"encoding"`
 }
 
 // GetTxReply defines the GetTxStatus replies returned from the API
 type GetTxReply struct {
 	Tx formatting.CB58 `json:"tx"`
+
+	// DecodedTx is only populated when the json encoding is requested
+	DecodedTx interface{} `json:"decodedTx,omitempty"`
 }
 
 // GetTx returns the specified transaction
@@ -123,33 +130,108 @@ func (service *Service) GetTx(r *http.Request, args *GetTxArgs, reply *GetTxRepl
 	}
 
 	reply.Tx.Bytes = tx.Bytes()
+
+	switch args.Encoding {
+	case "", CB58Encoding:
+	case JSONEncoding:
+		formatter := txFormatter{formatAddress: func(addr ids.ShortID) string {
+			return service.vm.Format(addr.Bytes())
+		}}
+		reply.DecodedTx = formatter.Format(tx.Tx)
+	default:
+		return fmt.Errorf("%w: %s", errUnknownEncoding, args.Encoding)
+	}
 	return nil
 }
 
+// Index is an address and an ID of a utxo referencing that address. Together
+// they mark a position while paging through the utxos of a set of addresses.
+type Index struct {
+	Address string `json:"address"`
+	UTXO    string `json:"utxo"`
+}
+
 // GetUTXOsArgs are arguments for passing into GetUTXOs requests
 type GetUTXOsArgs struct {
 	Addresses []string `json:"addresses"`
+
+	// If provided, only utxos of this asset are returned
+	AssetID string `json:"assetID"`
+
+	// If provided, only utxos after this index are returned
+	StartIndex Index `json:"startIndex"`
+
+	// The maximum number of utxos to return. If 0, or larger than the
+	// maximum, the maximum is used
+	Limit json.Uint32 `json:"limit"`
 }
 
 // GetUTXOsReply defines the GetUTXOs replies returned from the API
 type GetUTXOsReply struct {
+	// Number of utxos returned
+	NumFetched json.Uint64 `json:"numFetched"`
+
 	UTXOs []formatting.CB58 `json:"utxos"`
+
+	// The index of the last utxo returned, to be used as the StartIndex of the
+	// next call
+	EndIndex Index `json:"endIndex"`
 }
 
-// GetUTXOs creates an empty account with the name passed in
+// GetUTXOs returns a page of the utxos that reference at least one of the
+// provided addresses
 func (service *Service) GetUTXOs(r *http.Request, args *GetUTXOsArgs, reply *GetUTXOsReply) error {
 	service.vm.ctx.Log.Verbo("GetUTXOs called with %s", args.Addresses)
 
 	addrSet := ids.Set{}
+	addrStrs := make(map[[32]byte]string, len(args.Addresses))
 	for _, addr := range args.Addresses {
 		addrBytes, err := service.vm.Parse(addr)
 		if err != nil {
 			return err
 		}
-		addrSet.Add(ids.NewID(hashing.ComputeHash256Array(addrBytes)))
+		addrID := ids.NewID(hashing.ComputeHash256Array(addrBytes))
+		addrSet.Add(addrID)
+		addrStrs[addrID.Key()] = addr
+	}
+
+	assetID := ids.ID{}
+	if args.AssetID != "" {
+		id, err := service.vm.Lookup(args.AssetID)
+		if err != nil {
+			id, err = ids.FromString(args.AssetID)
+			if err != nil {
+				return fmt.Errorf("asset '%s' not found", args.AssetID)
+			}
+		}
+		assetID = id
+	}
+
+	startAddr := ids.ID{}
+	startUTXOID := ids.ID{}
+	if args.StartIndex.Address != "" {
+		addrBytes, err := service.vm.Parse(args.StartIndex.Address)
+		if err != nil {
+			return fmt.Errorf("problem parsing start index address: %w", err)
+		}
+		startAddr = ids.NewID(hashing.ComputeHash256Array(addrBytes))
+		addrStrs[startAddr.Key()] = args.StartIndex.Address
+
+		if args.StartIndex.UTXO != "" {
+			startUTXOID, err = ids.FromString(args.StartIndex.UTXO)
+			if err != nil {
+				return fmt.Errorf("problem parsing start index utxo: %w", err)
+			}
+		}
 	}
 
-	utxos, err := service.vm.GetUTXOs(addrSet)
+	utxos, endAddr, endUTXOID, err := service.vm.GetPaginatedUTXOs(
+		addrSet,
+		assetID,
+		startAddr,
+		startUTXOID,
+		int(args.Limit),
+	)
 	if err != nil {
 		return err
 	}
@@ -162,6 +244,109 @@ func (service *Service) GetUTXOs(r *http.Request, args *GetUTXOsArgs, reply *Get
 		}
 		reply.UTXOs = append(reply.UTXOs, formatting.CB58{Bytes: b})
 	}
+	reply.NumFetched = json.Uint64(len(utxos))
+	if !endAddr.IsZero() {
+		reply.EndIndex.Address = addrStrs[endAddr.Key()]
+	}
+	if !endUTXOID.IsZero() {
+		reply.EndIndex.UTXO = endUTXOID.String()
+	}
+	return nil
+}
+
+// GetAddressTxsArgs are arguments for passing into GetAddressTxs requests
+type GetAddressTxsArgs struct {
+	Address string `json:"address"`
+
+	// If provided, only transactions that moved this asset are returned
+	AssetID string `json:"assetID"`
+
+	// Position in the address's history to start returning entries from
+	Cursor json.Uint64 `json:"cursor"`
+
+	// The maximum number of entries to return. If 0, or larger than the
+	// maximum, the maximum is used
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// AddressTxEntry describes how an asset moved relative to an address in an
+// accepted transaction
+type AddressTxEntry struct {
+	TxID      ids.ID      `json:"txID"`
+	AssetID   string      `json:"assetID"`
+	Direction string      `json:"direction"`
+	Sent      json.Uint64 `json:"sent"`
+	Received  json.Uint64 `json:"received"`
+	Timestamp json.Uint64 `json:"timestamp"`
+}
+
+// GetAddressTxsReply defines the GetAddressTxs replies returned from the API
+type GetAddressTxsReply struct {
+	Txs []AddressTxEntry `json:"txs"`
+
+	// The cursor to pass in to fetch the next page of entries
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns a page of the transactions that touched an address, in
+// the order they were accepted. Only available if the node indexes
+// transactions.
+func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	service.vm.ctx.Log.Verbo("GetAddressTxs called with address: %s assetID: %s", args.Address, args.AssetID)
+
+	if service.vm.txIndex == nil {
+		return errTxIndexDisabled
+	}
+	if broken, err := service.vm.txIndex.Broken(); err != nil {
+		return fmt.Errorf("problem reading the transaction index status: %w", err)
+	} else if broken {
+		return errTxIndexBroken
+	}
+
+	address, err := service.vm.Parse(args.Address)
+	if err != nil {
+		return fmt.Errorf("problem parsing address: %w", err)
+	}
+	addrID := ids.NewID(hashing.ComputeHash256Array(address))
+
+	assetID := ids.ID{}
+	if args.AssetID != "" {
+		id, err := service.vm.Lookup(args.AssetID)
+		if err != nil {
+			id, err = ids.FromString(args.AssetID)
+			if err != nil {
+				return fmt.Errorf("asset '%s' not found", args.AssetID)
+			}
+		}
+		assetID = id
+	}
+
+	pageSize := int(args.PageSize)
+	if pageSize <= 0 || pageSize > maxAddressTxsToFetch {
+		pageSize = maxAddressTxsToFetch
+	}
+
+	entries, err := service.vm.txIndex.History(addrID, assetID, uint64(args.Cursor), pageSize)
+	if err != nil {
+		return fmt.Errorf("problem retrieving address history: %w", err)
+	}
+
+	reply.Txs = make([]AddressTxEntry, len(entries))
+	for i, entry := range entries {
+		assetStr := entry.AssetID.String()
+		if alias, err := service.vm.PrimaryAlias(entry.AssetID); err == nil {
+			assetStr = alias
+		}
+		reply.Txs[i] = AddressTxEntry{
+			TxID:      entry.TxID,
+			AssetID:   assetStr,
+			Direction: entry.Direction().String(),
+			Sent:      json.Uint64(entry.Sent),
+			Received:  json.Uint64(entry.Received),
+			Timestamp: json.Uint64(entry.Timestamp),
+		}
+	}
+	reply.Cursor = args.Cursor + json.Uint64(len(entries))
 	return nil
 }
 
@@ -218,8 +403,8 @@ type GetBalanceArgs struct {
 
 // GetBalanceReply defines the GetBalance replies returned from the API
 type GetBalanceReply struct {
-	Balance json.Uint64  `json:"balance"`
-	UTXOIDs []ava.UTXOID `json:"utxoIDs"`
+	Balance json.Uint64 `json:"balance"`
+	UTXOIDs []ids.ID    `json:"utxoIDs"`
 }
 
 // GetBalance returns the amount of an asset that an address at least partially owns
@@ -239,31 +424,25 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 		}
 	}
 
-	addrSet := ids.Set{}
-	addrSet.Add(ids.NewID(hashing.ComputeHash256Array(address)))
-
-	utxos, err := service.vm.GetUTXOs(addrSet)
+	// GetBalance is served without the context lock, so it reads from a
+	// snapshot of the state
+	state, release, err := service.vm.readOnlyState()
 	if err != nil {
 		return err
 	}
+	defer release()
 
-	for _, utxo := range utxos {
-		if !utxo.AssetID().Equals(assetID) {
-			continue
-		}
-		transferable, ok := utxo.Out.(ava.Transferable)
-		if !ok {
-			continue
-		}
-		amt, err := safemath.Add64(transferable.Amount(), uint64(reply.Balance))
-		if err != nil {
-			return err
-		}
-		reply.Balance = json.Uint64(amt)
-		reply.UTXOIDs = append(reply.UTXOIDs, utxo.UTXOID)
+	addrID := ids.NewID(hashing.ComputeHash256Array(address))
+	balance, err := state.Balance(addrID, assetID)
+	if err != nil {
+		return err
 	}
+	reply.Balance = json.Uint64(balance)
 
-	return nil
+	// The IDs are read straight from the index, so the utxos don't need to be
+	// loaded
+	reply.UTXOIDs, err = state.UTXOIDs(addrID, assetID, ids.ID{}, 0)
+	return err
 }
 
 // Balance ...
@@ -294,43 +473,32 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 	if err != nil {
 		return fmt.Errorf("couldn't parse given address: %s", err)
 	}
-	addrAsSet := ids.Set{}
-	addrAsSet.Add(ids.NewID(hashing.ComputeHash256Array(address)))
+	addrID := ids.NewID(hashing.ComputeHash256Array(address))
 
-	utxos, err := service.vm.GetUTXOs(addrAsSet)
+	// GetAllBalances is served without the context lock, so it reads from a
+	// snapshot of the state
+	state, release, err := service.vm.readOnlyState()
 	if err != nil {
-		return fmt.Errorf("couldn't get address's UTXOs: %s", err)
+		return err
 	}
+	defer release()
 
-	assetIDs := ids.Set{}                    // IDs of assets the address has a non-zero balance of
-	balances := make(map[[32]byte]uint64, 0) // key: ID (as bytes). value: balance of that asset
-	for _, utxo := range utxos {
-		transferable, ok := utxo.Out.(ava.Transferable)
-		if !ok {
-			continue
-		}
-		assetID := utxo.AssetID()
-		assetIDs.Add(assetID)
-		balance := balances[assetID.Key()] // 0 if key doesn't exist
-		balance, err := safemath.Add64(transferable.Amount(), balance)
-		if err != nil {
-			balances[assetID.Key()] = math.MaxUint64
-		} else {
-			balances[assetID.Key()] = balance
-		}
+	assetIDs, balances, err := state.Balances(addrID)
+	if err != nil {
+		return fmt.Errorf("couldn't get address's balances: %s", err)
 	}
 
-	reply.Balances = make([]Balance, assetIDs.Len())
-	for i, assetID := range assetIDs.List() {
+	reply.Balances = make([]Balance, len(assetIDs))
+	for i, assetID := range assetIDs {
 		if alias, err := service.vm.PrimaryAlias(assetID); err == nil {
 			reply.Balances[i] = Balance{
 				AssetID: alias,
-				Balance: json.Uint64(balances[assetID.Key()]),
+				Balance: json.Uint64(balances[i]),
 			}
 		} else {
 			reply.Balances[i] = Balance{
 				AssetID: assetID.String(),
-				Balance: json.Uint64(balances[assetID.Key()]),
+				Balance: json.Uint64(balances[i]),
 			}
 		}
 	}
@@ -371,6 +539,11 @@ func (service *Service) CreateFixedCapAsset(r *http.Request, args *CreateFixedCa
 		return errNoHolders
 	}
 
+	ins, keys, outs, err := service.payFee(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
 	initialState := &InitialState{
 		FxID: 0, // TODO: Should lookup secp256k1fx FxID
 		Outs: []verify.Verifiable{},
@@ -380,6 +553,8 @@ func (service *Service) CreateFixedCapAsset(r *http.Request, args *CreateFixedCa
 		BaseTx: BaseTx{
 			NetID: service.vm.ctx.NetworkID,
 			BCID:  service.vm.ctx.ChainID,
+			Outs:  outs,
+			Ins:   ins,
 		},
 		Name:         args.Name,
 		Symbol:       args.Symbol,
@@ -408,6 +583,10 @@ func (service *Service) CreateFixedCapAsset(r *http.Request, args *CreateFixedCa
 	}
 	initialState.Sort(service.vm.codec)
 
+	if err := service.signTx(tx, keys); err != nil {
+		return err
+	}
+
 	b, err := service.vm.codec.Marshal(tx)
 	if err != nil {
 		return fmt.Errorf("problem creating transaction: %w", err)
@@ -456,6 +635,11 @@ func (service *Service) CreateVariableCapAsset(r *http.Request, args *CreateVari
 		return errNoMinters
 	}
 
+	ins, keys, outs, err := service.payFee(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
 	initialState := &InitialState{
 		FxID: 0, // TODO: Should lookup secp256k1fx FxID
 		Outs: []verify.Verifiable{},
@@ -465,6 +649,8 @@ func (service *Service) CreateVariableCapAsset(r *http.Request, args *CreateVari
 		BaseTx: BaseTx{
 			NetID: service.vm.ctx.NetworkID,
 			BCID:  service.vm.ctx.ChainID,
+			Outs:  outs,
+			Ins:   ins,
 		},
 		Name:         args.Name,
 		Symbol:       args.Symbol,
@@ -496,6 +682,10 @@ func (service *Service) CreateVariableCapAsset(r *http.Request, args *CreateVari
 	}
 	initialState.Sort(service.vm.codec)
 
+	if err := service.signTx(tx, keys); err != nil {
+		return err
+	}
+
 	b, err := service.vm.codec.Marshal(tx)
 	if err != nil {
 		return fmt.Errorf("problem creating transaction: %w", err)
@@ -533,12 +723,11 @@ func (service *Service) CreateAddress(r *http.Request, args *CreateAddressArgs,
 
 	user := userState{vm: service.vm}
 
-	factory := crypto.FactorySECP256K1R{}
-	skIntf, err := factory.NewPrivateKey()
+	// If the user holds a mnemonic, the key is the next key derived from it
+	sk, err := service.vm.ctx.Keystore.NewKey(args.Username, args.Password)
 	if err != nil {
 		return fmt.Errorf("problem generating private key: %w", err)
 	}
-	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
 
 	if err := user.SetKey(db, sk); err != nil {
 		return fmt.Errorf("problem saving private key: %w", err)
@@ -683,69 +872,22 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *SendReply)
 		return fmt.Errorf("problem retrieving user: %w", err)
 	}
 
-	user := userState{vm: service.vm}
-
-	addresses, _ := user.Addresses(db)
-
-	addrs := ids.Set{}
-	addrs.Add(addresses...)
-	utxos, err := service.vm.GetUTXOs(addrs)
+	addrs, kc, err := service.keychain(db)
 	if err != nil {
-		return fmt.Errorf("problem retrieving user's UTXOs: %w", err)
-	}
-
-	kc := secp256k1fx.NewKeychain()
-	for _, addr := range addresses {
-		sk, err := user.Key(db, addr)
-		if err != nil {
-			return fmt.Errorf("problem retrieving private key: %w", err)
-		}
-		kc.Add(sk)
+		return err
 	}
 
-	amountSpent := uint64(0)
-	time := service.vm.clock.Unix()
-
-	ins := []*ava.TransferableInput{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-	for _, utxo := range utxos {
-		if !utxo.AssetID().Equals(assetID) {
-			continue
-		}
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
-		if err != nil {
-			continue
-		}
-		input, ok := inputIntf.(ava.Transferable)
-		if !ok {
-			continue
-		}
-		spent, err := safemath.Add64(amountSpent, input.Amount())
-		if err != nil {
-			return errSpendOverflow
-		}
-		amountSpent = spent
-
-		in := &ava.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  ava.Asset{ID: assetID},
-			In:     input,
-		}
-
-		ins = append(ins, in)
-		keys = append(keys, signers)
-
-		if amountSpent >= uint64(args.Amount) {
-			break
-		}
+	amounts, err := service.withFee(map[[32]byte]uint64{
+		assetID.Key(): uint64(args.Amount),
+	})
+	if err != nil {
+		return err
 	}
-
-	if amountSpent < uint64(args.Amount) {
-		return errInsufficientFunds
+	amountsSpent, ins, keys, err := service.spend(addrs, kc, amounts)
+	if err != nil {
+		return err
 	}
 
-	ava.SortTransferableInputsWithSigners(ins, keys)
-
 	outs := []*ava.TransferableOutput{&ava.TransferableOutput{
 		Asset: ava.Asset{ID: assetID},
 		Out: &secp256k1fx.TransferOutput{
@@ -757,22 +899,7 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *SendReply)
 			},
 		},
 	}}
-
-	if amountSpent > uint64(args.Amount) {
-		changeAddr := kc.Keys[0].PublicKey().Address()
-		outs = append(outs, &ava.TransferableOutput{
-			Asset: ava.Asset{ID: assetID},
-			Out: &secp256k1fx.TransferOutput{
-				Amt:      amountSpent - uint64(args.Amount),
-				Locktime: 0,
-				OutputOwners: secp256k1fx.OutputOwners{
-					Threshold: 1,
-					Addrs:     []ids.ShortID{changeAddr},
-				},
-			},
-		})
-	}
-
+	outs = append(outs, changeOutputs(amounts, amountsSpent, kc.Keys[0].PublicKey().Address())...)
 	ava.SortTransferableOutputs(outs, service.vm.codec)
 
 	tx := Tx{
@@ -783,26 +910,8 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *SendReply)
 			Ins:   ins,
 		},
 	}
-
-	unsignedBytes, err := service.vm.codec.Marshal(&tx.UnsignedTx)
-	if err != nil {
-		return fmt.Errorf("problem creating transaction: %w", err)
-	}
-	hash := hashing.ComputeHash256(unsignedBytes)
-
-	for _, credKeys := range keys {
-		cred := &secp256k1fx.Credential{}
-		for _, key := range credKeys {
-			sig, err := key.SignHash(hash)
-			if err != nil {
-				return fmt.Errorf("problem creating transaction: %w", err)
-			}
-			fixedSig := [crypto.SECP256K1RSigLen]byte{}
-			copy(fixedSig[:], sig)
-
-			cred.Sigs = append(cred.Sigs, fixedSig)
-		}
-		tx.Creds = append(tx.Creds, cred)
+	if err := service.signTx(&tx, keys); err != nil {
+		return err
 	}
 
 	b, err := service.vm.codec.Marshal(tx)
@@ -819,25 +928,36 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *SendReply)
 	return nil
 }
 
-// CreateMintTxArgs are arguments for passing into CreateMintTx requests
-type CreateMintTxArgs struct {
-	Amount  json.Uint64 `json:"amount"`
-	AssetID string      `json:"assetID"`
-	To      string      `json:"to"`
-	Minters []string    `json:"minters"`
-}
+// BuildSendArgs are arguments for passing into BuildSend requests
+type BuildSendArgs struct {
+	// Addresses whose funds are sent
+	From []string `json:"from"`
 
-// CreateMintTxReply defines the CreateMintTx replies returned from the API
-type CreateMintTxReply struct {
-	Tx formatting.CB58 `json:"tx"`
+	// The amount of funds to send
+	Amount json.Uint64 `json:"amount"`
+
+	// The ID of the asset being sent
+	AssetID string `json:"assetID"`
+
+	// The address of the recipient
+	To string `json:"to"`
+
+	// Address that receives the change. Defaults to the first address in
+	// [From].
+	ChangeAddr string `json:"changeAddr"`
 }
 
-// CreateMintTx returns the newly created unsigned transaction
-func (service *Service) CreateMintTx(r *http.Request, args *CreateMintTxArgs, reply *CreateMintTxReply) error {
-	service.vm.ctx.Log.Verbo("CreateMintTx called")
+// BuildSend returns the envelope of an unsigned transaction sending funds
+// from addresses whose keys aren't in the keystore. The transaction is signed
+// offline and issued with IssueSignedTx.
+func (service *Service) BuildSend(r *http.Request, args *BuildSendArgs, reply *UnsignedTxEnvelope) error {
+	service.vm.ctx.Log.Verbo("BuildSend called with from: %s", args.From)
 
 	if args.Amount == 0 {
-		return errInvalidMintAmount
+		return errInvalidAmount
+	}
+	if len(args.From) == 0 {
+		return errNoAddresses
 	}
 
 	assetID, err := service.vm.Lookup(args.AssetID)
@@ -848,47 +968,200 @@ func (service *Service) CreateMintTx(r *http.Request, args *CreateMintTxArgs, re
 		}
 	}
 
-	toBytes, err := service.vm.Parse(args.To)
-	if err != nil {
-		return fmt.Errorf("problem parsing to address '%s': %w", args.To, err)
-	}
-	to, err := ids.ToShortID(toBytes)
+	to, err := service.parseShortAddress(args.To)
 	if err != nil {
-		return fmt.Errorf("problem parsing to address '%s': %w", args.To, err)
+		return fmt.Errorf("problem parsing to address: %w", err)
 	}
 
 	addrs := ids.Set{}
-	minters := ids.ShortSet{}
-	for _, minter := range args.Minters {
-		addrBytes, err := service.vm.Parse(minter)
-		if err != nil {
-			return fmt.Errorf("problem parsing minter address '%s': %w", minter, err)
-		}
-		addr, err := ids.ToShortID(addrBytes)
+	kc := &watchOnlySpender{}
+	for _, addrStr := range args.From {
+		addr, err := service.parseShortAddress(addrStr)
 		if err != nil {
-			return fmt.Errorf("problem parsing minter address '%s': %w", minter, err)
+			return fmt.Errorf("problem parsing from address '%s': %w", addrStr, err)
 		}
-		addrs.Add(ids.NewID(hashing.ComputeHash256Array(addrBytes)))
-		minters.Add(addr)
+		addrs.Add(ids.NewID(hashing.ComputeHash256Array(addr.Bytes())))
+		kc.addrs.Add(addr)
 	}
 
-	utxos, err := service.vm.GetUTXOs(addrs)
+	changeAddrStr := args.ChangeAddr
+	if changeAddrStr == "" {
+		changeAddrStr = args.From[0]
+	}
+	changeAddr, err := service.parseShortAddress(changeAddrStr)
 	if err != nil {
-		return fmt.Errorf("problem getting user's UTXOs: %w", err)
+		return fmt.Errorf("problem parsing change address: %w", err)
 	}
 
-	for _, utxo := range utxos {
-		switch out := utxo.Out.(type) {
-		case *secp256k1fx.MintOutput:
-			if !utxo.AssetID().Equals(assetID) {
-				continue
-			}
-			sigs := []uint32{}
-			for i := uint32(0); i < uint32(len(out.Addrs)) && uint32(len(sigs)) < out.Threshold; i++ {
-				if minters.Contains(out.Addrs[i]) {
-					sigs = append(sigs, i)
-				}
-			}
+	amounts, err := service.withFee(map[[32]byte]uint64{
+		assetID.Key(): uint64(args.Amount),
+	})
+	if err != nil {
+		return err
+	}
+	amountsSpent, ins, _, err := service.spend(addrs, kc, amounts)
+	if err != nil {
+		return err
+	}
+
+	outs := []*ava.TransferableOutput{&ava.TransferableOutput{
+		Asset: ava.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:      uint64(args.Amount),
+			Locktime: 0,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		},
+	}}
+	outs = append(outs, changeOutputs(amounts, amountsSpent, changeAddr)...)
+	ava.SortTransferableOutputs(outs, service.vm.codec)
+
+	envelope, err := service.vm.newUnsignedTxEnvelope(&BaseTx{
+		NetID: service.vm.ctx.NetworkID,
+		BCID:  service.vm.ctx.ChainID,
+		Outs:  outs,
+		Ins:   ins,
+	})
+	if err != nil {
+		return err
+	}
+	*reply = *envelope
+	return nil
+}
+
+// APICredential is a secp256k1fx.Credential produced by an offline signer
+type APICredential struct {
+	Signatures []formatting.CB58 `json:"signatures"`
+}
+
+// IssueSignedTxArgs are arguments for passing into IssueSignedTx requests
+type IssueSignedTxArgs struct {
+	// The unsigned transaction of an UnsignedTxEnvelope
+	UnsignedTx formatting.CB58 `json:"unsignedTx"`
+
+	// The credential of each input of the transaction, in order
+	Credentials []APICredential `json:"credentials"`
+}
+
+// IssueSignedTx attaches credentials produced by an offline signer to an
+// unsigned transaction and issues the signed transaction
+func (service *Service) IssueSignedTx(r *http.Request, args *IssueSignedTxArgs, reply *IssueTxReply) error {
+	service.vm.ctx.Log.Verbo("IssueSignedTx called with %s", args.UnsignedTx)
+
+	var utx UnsignedTx
+	if err := service.vm.codec.Unmarshal(args.UnsignedTx.Bytes, &utx); err != nil {
+		return fmt.Errorf("problem parsing unsigned transaction: %w", err)
+	}
+
+	tx := Tx{UnsignedTx: utx}
+	for _, apiCred := range args.Credentials {
+		cred := &secp256k1fx.Credential{}
+		for _, sig := range apiCred.Signatures {
+			if len(sig.Bytes) != crypto.SECP256K1RSigLen {
+				return errInvalidSignatureLen
+			}
+			fixedSig := [crypto.SECP256K1RSigLen]byte{}
+			copy(fixedSig[:], sig.Bytes)
+			cred.Sigs = append(cred.Sigs, fixedSig)
+		}
+		tx.Creds = append(tx.Creds, cred)
+	}
+
+	b, err := service.vm.codec.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	txID, err := service.vm.IssueTx(b, nil)
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	return nil
+}
+
+// CreateMintTxArgs are arguments for passing into CreateMintTx requests
+type CreateMintTxArgs struct {
+	Amount  json.Uint64 `json:"amount"`
+	AssetID string      `json:"assetID"`
+	To      string      `json:"to"`
+	Minters []string    `json:"minters"`
+
+	// User that pays the transaction fee. Only required if the fee is non-zero
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// CreateMintTxReply defines the CreateMintTx replies returned from the API
+type CreateMintTxReply struct {
+	Tx formatting.CB58 `json:"tx"`
+}
+
+// CreateMintTx returns the newly created unsigned transaction
+func (service *Service) CreateMintTx(r *http.Request, args *CreateMintTxArgs, reply *CreateMintTxReply) error {
+	service.vm.ctx.Log.Verbo("CreateMintTx called")
+
+	if args.Amount == 0 {
+		return errInvalidMintAmount
+	}
+
+	assetID, err := service.vm.Lookup(args.AssetID)
+	if err != nil {
+		assetID, err = ids.FromString(args.AssetID)
+		if err != nil {
+			return fmt.Errorf("asset '%s' not found", args.AssetID)
+		}
+	}
+
+	toBytes, err := service.vm.Parse(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address '%s': %w", args.To, err)
+	}
+	to, err := ids.ToShortID(toBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address '%s': %w", args.To, err)
+	}
+
+	addrs := ids.Set{}
+	minters := ids.ShortSet{}
+	for _, minter := range args.Minters {
+		addrBytes, err := service.vm.Parse(minter)
+		if err != nil {
+			return fmt.Errorf("problem parsing minter address '%s': %w", minter, err)
+		}
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return fmt.Errorf("problem parsing minter address '%s': %w", minter, err)
+		}
+		addrs.Add(ids.NewID(hashing.ComputeHash256Array(addrBytes)))
+		minters.Add(addr)
+	}
+
+	utxos, err := service.vm.GetAssetUTXOs(addrs, assetID)
+	if err != nil {
+		return fmt.Errorf("problem getting user's UTXOs: %w", err)
+	}
+
+	ins, keys, outs, err := service.payFee(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
+	for _, utxo := range utxos {
+		switch out := utxo.Out.(type) {
+		case *secp256k1fx.MintOutput:
+			if !utxo.AssetID().Equals(assetID) {
+				continue
+			}
+			sigs := []uint32{}
+			for i := uint32(0); i < uint32(len(out.Addrs)) && uint32(len(sigs)) < out.Threshold; i++ {
+				if minters.Contains(out.Addrs[i]) {
+					sigs = append(sigs, i)
+				}
+			}
 
 			if uint32(len(sigs)) != out.Threshold {
 				continue
@@ -898,6 +1171,8 @@ func (service *Service) CreateMintTx(r *http.Request, args *CreateMintTxArgs, re
 				BaseTx: BaseTx{
 					NetID: service.vm.ctx.NetworkID,
 					BCID:  service.vm.ctx.ChainID,
+					Outs:  outs,
+					Ins:   ins,
 				},
 				Ops: []*Operation{
 					&Operation{
@@ -924,6 +1199,11 @@ func (service *Service) CreateMintTx(r *http.Request, args *CreateMintTxArgs, re
 				},
 			}}
 
+			// The minters' credential is added by SignMintTx
+			if err := service.signTx(&tx, keys); err != nil {
+				return err
+			}
+
 			txBytes, err := service.vm.codec.Marshal(&tx)
 			if err != nil {
 				return fmt.Errorf("problem creating transaction: %w", err)
@@ -980,9 +1260,6 @@ func (service *Service) SignMintTx(r *http.Request, args *SignMintTxArgs, reply
 	if !ok {
 		return errors.New("transaction must be a mint transaction")
 	}
-	if len(opTx.Ins) != 0 {
-		return errCanOnlySignSingleInputTxs
-	}
 	if len(opTx.Ops) != 1 {
 		return errCanOnlySignSingleInputTxs
 	}
@@ -1023,11 +1300,17 @@ func (service *Service) SignMintTx(r *http.Request, args *SignMintTxArgs, reply
 		return errUnneededAddress
 	}
 
-	if len(tx.Creds) == 0 {
+	// The credentials of the inputs paying the fee precede the credential of
+	// the mint operation
+	credIndex := len(opTx.Ins)
+	if len(tx.Creds) < credIndex {
+		return errWrongNumberOfCredentials
+	}
+	if len(tx.Creds) == credIndex {
 		tx.Creds = append(tx.Creds, &secp256k1fx.Credential{})
 	}
 
-	cred, ok := tx.Creds[0].(*secp256k1fx.Credential)
+	cred, ok := tx.Creds[credIndex].(*secp256k1fx.Credential)
 	if !ok {
 		return errUnknownCredentialType
 	}
@@ -1090,33 +1373,25 @@ func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, reply *I
 		return fmt.Errorf("problem retrieving user: %w", err)
 	}
 
-	user := userState{vm: service.vm}
-
-	addresses, _ := user.Addresses(db)
+	addrs, kc, err := service.keychain(db)
+	if err != nil {
+		return err
+	}
 
-	addrs := ids.Set{}
-	addrs.Add(addresses...)
 	utxos, err := service.vm.GetAtomicUTXOs(addrs)
 	if err != nil {
 		return fmt.Errorf("problem retrieving user's atomic UTXOs: %w", err)
 	}
 
-	kc := secp256k1fx.NewKeychain()
-	for _, addr := range addresses {
-		sk, err := user.Key(db, addr)
-		if err != nil {
-			return fmt.Errorf("problem retrieving private key: %w", err)
-		}
-		kc.Add(sk)
-	}
-
 	amount := uint64(0)
 	time := service.vm.clock.Unix()
 
+	service.vm.mempool.EvictStale(service.vm.clock.Time())
+
 	ins := []*ava.TransferableInput{}
 	keys := [][]*crypto.PrivateKeySECP256K1R{}
 	for _, utxo := range utxos {
-		if !utxo.AssetID().Equals(service.vm.ava) {
+		if !utxo.AssetID().Equals(service.vm.ava) || service.vm.mempool.Spent(utxo.InputID()) {
 			continue
 		}
 		inputIntf, signers, err := kc.Spend(utxo.Out, time)
@@ -1143,6 +1418,13 @@ func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, reply *I
 		keys = append(keys, signers)
 	}
 
+	// The fee is paid out of the imported AVA
+	fee := service.vm.fee()
+	if amount <= fee {
+		return errInsufficientFunds
+	}
+	amount -= fee
+
 	ava.SortTransferableInputsWithSigners(ins, keys)
 
 	outs := []*ava.TransferableOutput{&ava.TransferableOutput{
@@ -1165,26 +1447,8 @@ func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, reply *I
 		},
 		Ins: ins,
 	}}
-
-	unsignedBytes, err := service.vm.codec.Marshal(&tx.UnsignedTx)
-	if err != nil {
-		return fmt.Errorf("problem creating transaction: %w", err)
-	}
-	hash := hashing.ComputeHash256(unsignedBytes)
-
-	for _, credKeys := range keys {
-		cred := &secp256k1fx.Credential{}
-		for _, key := range credKeys {
-			sig, err := key.SignHash(hash)
-			if err != nil {
-				return fmt.Errorf("problem creating transaction: %w", err)
-			}
-			fixedSig := [crypto.SECP256K1RSigLen]byte{}
-			copy(fixedSig[:], sig)
-
-			cred.Sigs = append(cred.Sigs, fixedSig)
-		}
-		tx.Creds = append(tx.Creds, cred)
+	if err := service.signTx(&tx, keys); err != nil {
+		return err
 	}
 
 	b, err := service.vm.codec.Marshal(tx)
@@ -1210,8 +1474,9 @@ type ExportAVAArgs struct {
 	// Amount of nAVA to send
 	Amount json.Uint64 `json:"amount"`
 
-	// ID of P-Chain account that will receive the AVA
-	To ids.ShortID `json:"to"`
+	// P-Chain account that will receive the AVA. Either a Bech32 address or a
+	// legacy CB58 account ID.
+	To string `json:"to"`
 }
 
 // ExportAVAReply defines the Send replies returned from the API
@@ -1229,114 +1494,321 @@ func (service *Service) ExportAVA(_ *http.Request, args *ExportAVAArgs, reply *E
 		return errInvalidAmount
 	}
 
+	to, err := ava.ParseShortAddress(service.vm.ctx, service.vm.platform, args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address '%s': %w", args.To, err)
+	}
+
 	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
 	if err != nil {
 		return fmt.Errorf("problem retrieving user: %w", err)
 	}
 
+	addrs, kc, err := service.keychain(db)
+	if err != nil {
+		return err
+	}
+
+	amounts, err := service.withFee(map[[32]byte]uint64{
+		service.vm.ava.Key(): uint64(args.Amount),
+	})
+	if err != nil {
+		return err
+	}
+	amountsSpent, ins, keys, err := service.spend(addrs, kc, amounts)
+	if err != nil {
+		return err
+	}
+
+	exportOuts := []*ava.TransferableOutput{&ava.TransferableOutput{
+		Asset: ava.Asset{ID: service.vm.ava},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:      uint64(args.Amount),
+			Locktime: 0,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		},
+	}}
+
+	outs := changeOutputs(amounts, amountsSpent, kc.Keys[0].PublicKey().Address())
+	ava.SortTransferableOutputs(outs, service.vm.codec)
+
+	tx := Tx{UnsignedTx: &ExportTx{
+		BaseTx: BaseTx{
+			NetID: service.vm.ctx.NetworkID,
+			BCID:  service.vm.ctx.ChainID,
+			Outs:  outs,
+			Ins:   ins,
+		},
+		Outs: exportOuts,
+	}}
+	if err := service.signTx(&tx, keys); err != nil {
+		return err
+	}
+
+	b, err := service.vm.codec.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	txID, err := service.vm.IssueTx(b, nil)
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	return nil
+}
+
+// GetPendingTxsReply defines the GetPendingTxs replies returned from the API
+type GetPendingTxsReply struct {
+	// Txs waiting to be issued to consensus, in the order they were submitted
+	Queued []ids.ID `json:"queued"`
+
+	// Txs that are being processed by consensus
+	Issued []ids.ID `json:"issued"`
+
+	// Number of bytes of the queued txs
+	QueuedSize json.Uint64 `json:"queuedSize"`
+}
+
+// GetPendingTxs returns the txs submitted to this node that haven't been
+// decided yet
+func (service *Service) GetPendingTxs(_ *http.Request, _ *struct{}, reply *GetPendingTxsReply) error {
+	service.vm.ctx.Log.Verbo("GetPendingTxs called")
+
+	service.vm.mempool.EvictStale(service.vm.clock.Time())
+
+	reply.Queued, reply.Issued = service.vm.mempool.TxIDs()
+	reply.QueuedSize = json.Uint64(service.vm.mempool.Size())
+	return nil
+}
+
+// GetPendingTxArgs are arguments for passing into GetPendingTx requests
+type GetPendingTxArgs struct {
+	TxID ids.ID `json:"txID"`
+}
+
+// GetPendingTxReply defines the GetPendingTx replies returned from the API
+type GetPendingTxReply struct {
+	Tx     formatting.CB58 `json:"tx"`
+	Issued bool            `json:"issued"`
+}
+
+// GetPendingTx returns a tx submitted to this node that hasn't been decided
+// yet, and whether it was issued to consensus
+func (service *Service) GetPendingTx(_ *http.Request, args *GetPendingTxArgs, reply *GetPendingTxReply) error {
+	service.vm.ctx.Log.Verbo("GetPendingTx called with %s", args.TxID)
+
+	if args.TxID.IsZero() {
+		return errNilTxID
+	}
+
+	issued, err := service.vm.mempool.Issued(args.TxID)
+	if err != nil {
+		return err
+	}
+
+	tx := UniqueTx{
+		vm:   service.vm,
+		txID: args.TxID,
+	}
+	reply.Tx.Bytes = tx.Bytes()
+	reply.Issued = issued
+	return nil
+}
+
+// GetTxFeeReply defines the GetTxFee replies returned from the API
+type GetTxFeeReply struct {
+	// AssetID is the asset that fees are paid in
+	AssetID ids.ID `json:"assetID"`
+
+	// TxFee is the amount of the asset burned by every transaction verified
+	// at or after ActivationTime
+	TxFee json.Uint64 `json:"txFee"`
+
+	// ActivationTime is the Unix time the fee activates at
+	ActivationTime json.Uint64 `json:"activationTime"`
+}
+
+// GetTxFee returns the fee that every transaction issued to this chain must
+// burn once it has activated
+func (service *Service) GetTxFee(_ *http.Request, _ *struct{}, reply *GetTxFeeReply) error {
+	service.vm.ctx.Log.Verbo("GetTxFee called")
+
+	reply.AssetID = service.vm.ava
+	reply.TxFee = json.Uint64(service.vm.txFee)
+	reply.ActivationTime = json.Uint64(service.vm.txFeeActivation.Unix())
+	return nil
+}
+
+// keychain returns the hashes of the addresses the user controls, and a
+// keychain containing the user's keys
+func (service *Service) keychain(db database.Database) (ids.Set, *secp256k1fx.Keychain, error) {
 	user := userState{vm: service.vm}
 
 	addresses, _ := user.Addresses(db)
 
 	addrs := ids.Set{}
 	addrs.Add(addresses...)
-	utxos, err := service.vm.GetUTXOs(addrs)
-	if err != nil {
-		return fmt.Errorf("problem retrieving user's UTXOs: %w", err)
-	}
 
 	kc := secp256k1fx.NewKeychain()
 	for _, addr := range addresses {
 		sk, err := user.Key(db, addr)
 		if err != nil {
-			return fmt.Errorf("problem retrieving private key: %w", err)
+			return nil, nil, fmt.Errorf("problem retrieving private key: %w", err)
 		}
 		kc.Add(sk)
 	}
+	return addrs, kc, nil
+}
+
+// withFee returns [amounts] with the transaction fee added to the amount of
+// AVA
+func (service *Service) withFee(amounts map[[32]byte]uint64) (map[[32]byte]uint64, error) {
+	fee := service.vm.fee()
+	if fee == 0 {
+		return amounts, nil
+	}
+	avaKey := service.vm.ava.Key()
+	amount, err := safemath.Add64(amounts[avaKey], fee)
+	if err != nil {
+		return nil, errSpendOverflow
+	}
+	amounts[avaKey] = amount
+	return amounts, nil
+}
+
+// parseShortAddress parses [addrStr] as the address of a key on this chain
+func (service *Service) parseShortAddress(addrStr string) (ids.ShortID, error) {
+	addrBytes, err := service.vm.Parse(addrStr)
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	return ids.ToShortID(addrBytes)
+}
+
+// spender creates the input spending an output at [time], and returns the
+// keys that sign the input
+type spender interface {
+	Spend(out verify.Verifiable, time uint64) (verify.Verifiable, []*crypto.PrivateKeySECP256K1R, error)
+}
 
-	amountSpent := uint64(0)
+// spend consumes utxos that reference [addrs] and can be spent by [kc] until
+// at least [amounts] of each asset has been consumed. Returns the amount of
+// each asset that was consumed, along with the sorted inputs and the keys
+// needed to sign each input.
+func (service *Service) spend(addrs ids.Set, kc spender, amounts map[[32]byte]uint64) (
+	map[[32]byte]uint64,
+	[]*ava.TransferableInput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	amountsSpent := make(map[[32]byte]uint64, len(amounts))
 	time := service.vm.clock.Unix()
 
+	// Txs that have been issued for too long no longer reserve their utxos
+	service.vm.mempool.EvictStale(service.vm.clock.Time())
+
 	ins := []*ava.TransferableInput{}
 	keys := [][]*crypto.PrivateKeySECP256K1R{}
-	for _, utxo := range utxos {
-		if !utxo.AssetID().Equals(service.vm.ava) {
-			continue
-		}
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
+	for assetKey, amount := range amounts {
+		assetID := ids.NewID(assetKey)
+		utxoIDs, err := getAssetUTXOIDs(service.vm.state, addrs, assetID)
 		if err != nil {
-			continue
+			return nil, nil, nil, fmt.Errorf("problem retrieving user's UTXOs: %w", err)
 		}
-		input, ok := inputIntf.(ava.Transferable)
-		if !ok {
-			continue
-		}
-		spent, err := safemath.Add64(amountSpent, input.Amount())
-		if err != nil {
-			return errSpendOverflow
-		}
-		amountSpent = spent
 
-		in := &ava.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  ava.Asset{ID: service.vm.ava},
-			In:     input,
+		// Utxos are only loaded until enough of the asset has been found
+		amountSpent := uint64(0)
+		for _, utxoID := range utxoIDs.List() {
+			if amountSpent >= amount {
+				break
+			}
+			// Utxos consumed by pending txs would cause a conflict
+			if service.vm.mempool.Spent(utxoID) {
+				continue
+			}
+			utxo, err := service.vm.state.UTXO(utxoID)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("problem retrieving user's UTXOs: %w", err)
+			}
+			if !utxo.AssetID().Equals(assetID) {
+				continue
+			}
+			inputIntf, signers, err := kc.Spend(utxo.Out, time)
+			if err != nil {
+				continue
+			}
+			input, ok := inputIntf.(ava.Transferable)
+			if !ok {
+				continue
+			}
+			spent, err := safemath.Add64(amountSpent, input.Amount())
+			if err != nil {
+				return nil, nil, nil, errSpendOverflow
+			}
+			amountSpent = spent
+
+			ins = append(ins, &ava.TransferableInput{
+				UTXOID: utxo.UTXOID,
+				Asset:  ava.Asset{ID: assetID},
+				In:     input,
+			})
+			keys = append(keys, signers)
 		}
 
-		ins = append(ins, in)
-		keys = append(keys, signers)
-
-		if amountSpent >= uint64(args.Amount) {
-			break
+		if amountSpent < amount {
+			return nil, nil, nil, errInsufficientFunds
 		}
+		amountsSpent[assetKey] = amountSpent
 	}
 
-	if amountSpent < uint64(args.Amount) {
-		return errInsufficientFunds
+	ava.SortTransferableInputsWithSigners(ins, keys)
+	return amountsSpent, ins, keys, nil
+}
+
+// payFee returns the inputs that pay the transaction fee out of the user's
+// funds, the keys needed to sign each input, and the outputs returning the
+// change to the user. If there is no transaction fee, the user isn't loaded.
+func (service *Service) payFee(username, password string) (
+	[]*ava.TransferableInput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	[]*ava.TransferableOutput,
+	error,
+) {
+	if service.vm.fee() == 0 {
+		return nil, nil, nil, nil
 	}
 
-	ava.SortTransferableInputsWithSigners(ins, keys)
+	db, err := service.vm.ctx.Keystore.GetDatabase(username, password)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("problem retrieving user: %w", err)
+	}
 
-	exportOuts := []*ava.TransferableOutput{&ava.TransferableOutput{
-		Asset: ava.Asset{ID: service.vm.ava},
-		Out: &secp256k1fx.TransferOutput{
-			Amt:      uint64(args.Amount),
-			Locktime: 0,
-			OutputOwners: secp256k1fx.OutputOwners{
-				Threshold: 1,
-				Addrs:     []ids.ShortID{args.To},
-			},
-		},
-	}}
+	addrs, kc, err := service.keychain(db)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	outs := []*ava.TransferableOutput{}
-	if amountSpent > uint64(args.Amount) {
-		changeAddr := kc.Keys[0].PublicKey().Address()
-		outs = append(outs, &ava.TransferableOutput{
-			Asset: ava.Asset{ID: service.vm.ava},
-			Out: &secp256k1fx.TransferOutput{
-				Amt:      amountSpent - uint64(args.Amount),
-				Locktime: 0,
-				OutputOwners: secp256k1fx.OutputOwners{
-					Threshold: 1,
-					Addrs:     []ids.ShortID{changeAddr},
-				},
-			},
-		})
+	amounts, err := service.withFee(map[[32]byte]uint64{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	amountsSpent, ins, keys, err := service.spend(addrs, kc, amounts)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
+	outs := changeOutputs(amounts, amountsSpent, kc.Keys[0].PublicKey().Address())
 	ava.SortTransferableOutputs(outs, service.vm.codec)
+	return ins, keys, outs, nil
+}
 
-	tx := Tx{UnsignedTx: &ExportTx{
-		BaseTx: BaseTx{
-			NetID: service.vm.ctx.NetworkID,
-			BCID:  service.vm.ctx.ChainID,
-			Outs:  outs,
-			Ins:   ins,
-		},
-		Outs: exportOuts,
-	}}
-
+// signTx adds a credential to [tx] for each set of [keys]
+func (service *Service) signTx(tx *Tx, keys [][]*crypto.PrivateKeySECP256K1R) error {
 	unsignedBytes, err := service.vm.codec.Marshal(&tx.UnsignedTx)
 	if err != nil {
 		return fmt.Errorf("problem creating transaction: %w", err)
@@ -1357,17 +1829,29 @@ func (service *Service) ExportAVA(_ *http.Request, args *ExportAVAArgs, reply *E
 		}
 		tx.Creds = append(tx.Creds, cred)
 	}
+	return nil
+}
 
-	b, err := service.vm.codec.Marshal(tx)
-	if err != nil {
-		return fmt.Errorf("problem creating transaction: %w", err)
-	}
-
-	txID, err := service.vm.IssueTx(b, nil)
-	if err != nil {
-		return fmt.Errorf("problem issuing transaction: %w", err)
+// changeOutputs returns outputs sending the amount of each asset that was
+// spent in excess of [amounts] to [changeAddr]
+func changeOutputs(amounts, amountsSpent map[[32]byte]uint64, changeAddr ids.ShortID) []*ava.TransferableOutput {
+	outs := []*ava.TransferableOutput{}
+	for assetKey, amountSpent := range amountsSpent {
+		amount := amounts[assetKey]
+		if amountSpent <= amount {
+			continue
+		}
+		outs = append(outs, &ava.TransferableOutput{
+			Asset: ava.Asset{ID: ids.NewID(assetKey)},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:      amountSpent - amount,
+				Locktime: 0,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{changeAddr},
+				},
+			},
+		})
 	}
-
-	reply.TxID = txID
-	return nil
+	return outs
 }
@@ -5,13 +5,21 @@ package avm
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/gecko/api/keystore"
+	"github.com/ava-labs/gecko/database/memdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/choices"
+	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
 )
 
 func setup(t *testing.T) ([]byte, *VM, *Service) {
@@ -109,6 +117,94 @@ func TestServiceGetBalance(t *testing.T) {
 	assert.Len(t, balanceReply.UTXOIDs, 4, "should have only returned four utxoIDs")
 }
 
+func TestServiceGetBalanceBech32(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer ctx.Lock.Unlock()
+	defer vm.Shutdown()
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	assetID := genesisTx.ID()
+	addr := keys[0].PublicKey().Address()
+
+	bech32, err := ava.FormatAddress(vm.ctx, vm.ctx.ChainID, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	balanceArgs := &GetBalanceArgs{
+		Address: bech32,
+		AssetID: assetID.String(),
+	}
+	balanceReply := &GetBalanceReply{}
+	err = s.GetBalance(nil, balanceArgs, balanceReply)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(300000), uint64(balanceReply.Balance))
+
+	// Addresses of other chains are rejected
+	otherChain, err := ava.FormatAddress(vm.ctx, ids.NewID([32]byte{7}), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	balanceArgs.Address = otherChain
+	err = s.GetBalance(nil, balanceArgs, &GetBalanceReply{})
+	assert.Error(t, err)
+}
+
+func TestServiceGetBalanceSnapshot(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer ctx.Lock.Unlock()
+	defer vm.Shutdown()
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	assetID := genesisTx.ID()
+	addr := keys[0].PublicKey().Address()
+
+	utxo := &ava.UTXO{
+		UTXOID: ava.UTXOID{
+			TxID:        ids.NewID([32]byte{42}),
+			OutputIndex: 0,
+		},
+		Asset: ava.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 10,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+	if err := vm.state.FundUTXO(utxo); err != nil {
+		t.Fatal(err)
+	}
+
+	balanceArgs := &GetBalanceArgs{
+		Address: fmt.Sprintf("%s-%s", vm.ctx.ChainID, addr),
+		AssetID: assetID.String(),
+	}
+
+	// The snapshot only contains committed state
+	balanceReply := &GetBalanceReply{}
+	err := s.GetBalance(nil, balanceArgs, balanceReply)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(300000), uint64(balanceReply.Balance))
+	assert.Len(t, balanceReply.UTXOIDs, 4)
+
+	if err := vm.db.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	balanceReply = &GetBalanceReply{}
+	err = s.GetBalance(nil, balanceArgs, balanceReply)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(300010), uint64(balanceReply.Balance))
+	assert.Len(t, balanceReply.UTXOIDs, 5)
+
+	found := false
+	for _, utxoID := range balanceReply.UTXOIDs {
+		found = found || utxoID.Equals(utxo.InputID())
+	}
+	assert.True(t, found, "should have returned the ID of the new utxo")
+}
+
 func TestServiceGetTx(t *testing.T) {
 	genesisBytes, vm, s := setup(t)
 	defer func() {
@@ -128,6 +224,59 @@ func TestServiceGetTx(t *testing.T) {
 	assert.Equal(t, genesisTxBytes, reply.Tx.Bytes, "Wrong tx returned from service.GetTx")
 }
 
+func TestServiceGetTxJSON(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+
+	reply := GetTxReply{}
+	err := s.GetTx(nil, &GetTxArgs{
+		TxID:     genesisTx.ID(),
+		Encoding: JSONEncoding,
+	}, &reply)
+	assert.NoError(t, err)
+	assert.Equal(t, genesisTx.Bytes(), reply.Tx.Bytes)
+
+	decoded, ok := reply.DecodedTx.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Wrong decoded tx type %T", reply.DecodedTx)
+	}
+	unsignedTx := decoded["unsignedTx"].(map[string]interface{})
+	assert.Equal(t, "avm.CreateAssetTx", unsignedTx["type"])
+	assert.Equal(t, "myFixedCapAsset", unsignedTx["name"])
+	assert.Equal(t, ids.Empty.String(), unsignedTx["blockchainID"])
+
+	states := unsignedTx["initialStates"].([]interface{})
+	outs := states[0].(map[string]interface{})["outputs"].([]interface{})
+	out := outs[0].(map[string]interface{})
+	assert.Equal(t, "secp256k1fx.TransferOutput", out["type"])
+	addrs := out["addresses"].([]interface{})
+	if addr := addrs[0].(string); !strings.HasPrefix(addr, ctx.ChainID.String()+addressSep) {
+		t.Fatalf("Address %s isn't in the chain's address format", addr)
+	}
+}
+
+func TestServiceGetTxUnknownEncoding(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+
+	reply := GetTxReply{}
+	err := s.GetTx(nil, &GetTxArgs{
+		TxID:     genesisTx.ID(),
+		Encoding: "hex",
+	}, &reply)
+	assert.Error(t, err, "Unknown encoding should have returned an error")
+}
+
 func TestServiceGetNilTx(t *testing.T) {
 	_, vm, s := setup(t)
 	defer func() {
@@ -164,13 +313,13 @@ func TestServiceGetUTXOsInvalidAddress(t *testing.T) {
 		label string
 		args  *GetUTXOsArgs
 	}{
-		{"[", &GetUTXOsArgs{[]string{""}}},
-		{"[-]", &GetUTXOsArgs{[]string{"-"}}},
-		{"[foo]", &GetUTXOsArgs{[]string{"foo"}}},
-		{"[foo-bar]", &GetUTXOsArgs{[]string{"foo-bar"}}},
-		{"[<ChainID>]", &GetUTXOsArgs{[]string{ctx.ChainID.String()}}},
-		{"[<ChainID>-]", &GetUTXOsArgs{[]string{fmt.Sprintf("%s-", ctx.ChainID.String())}}},
-		{"[<Unknown ID>-<addr0>]", &GetUTXOsArgs{[]string{fmt.Sprintf("%s-%s", ids.NewID([32]byte{42}).String(), addr0.String())}}},
+		{"[", &GetUTXOsArgs{Addresses: []string{""}}},
+		{"[-]", &GetUTXOsArgs{Addresses: []string{"-"}}},
+		{"[foo]", &GetUTXOsArgs{Addresses: []string{"foo"}}},
+		{"[foo-bar]", &GetUTXOsArgs{Addresses: []string{"foo-bar"}}},
+		{"[<ChainID>]", &GetUTXOsArgs{Addresses: []string{ctx.ChainID.String()}}},
+		{"[<ChainID>-]", &GetUTXOsArgs{Addresses: []string{fmt.Sprintf("%s-", ctx.ChainID.String())}}},
+		{"[<Unknown ID>-<addr0>]", &GetUTXOsArgs{Addresses: []string{fmt.Sprintf("%s-%s", ids.NewID([32]byte{42}).String(), addr0.String())}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.label, func(t *testing.T) {
@@ -201,7 +350,7 @@ func TestServiceGetUTXOs(t *testing.T) {
 			0,
 		}, {
 			"[<ChainID>-<unrelated address>]",
-			&GetUTXOsArgs{[]string{
+			&GetUTXOsArgs{Addresses: []string{
 				// TODO: Should GetUTXOs() raise an error for this? The address portion is
 				//		 longer than addr0.String()
 				fmt.Sprintf("%s-%s", ctx.ChainID.String(), ids.NewID([32]byte{42}).String()),
@@ -209,13 +358,13 @@ func TestServiceGetUTXOs(t *testing.T) {
 			0,
 		}, {
 			"[<ChainID>-<addr0>]",
-			&GetUTXOsArgs{[]string{
+			&GetUTXOsArgs{Addresses: []string{
 				fmt.Sprintf("%s-%s", ctx.ChainID.String(), addr0.String()),
 			}},
 			7,
 		}, {
 			"[<ChainID>-<addr0>,<ChainID>-<addr0>]",
-			&GetUTXOsArgs{[]string{
+			&GetUTXOsArgs{Addresses: []string{
 				fmt.Sprintf("%s-%s", ctx.ChainID.String(), addr0.String()),
 				fmt.Sprintf("%s-%s", ctx.ChainID.String(), addr0.String()),
 			}},
@@ -234,6 +383,67 @@ func TestServiceGetUTXOs(t *testing.T) {
 	}
 }
 
+func TestServiceGetUTXOsPaginated(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	addr0 := fmt.Sprintf("%s-%s", ctx.ChainID.String(), keys[0].PublicKey().Address())
+	addr1 := fmt.Sprintf("%s-%s", ctx.ChainID.String(), keys[1].PublicKey().Address())
+
+	allReply := &GetUTXOsReply{}
+	if err := s.GetUTXOs(nil, &GetUTXOsArgs{Addresses: []string{addr0, addr1}}, allReply); err != nil {
+		t.Fatal(err)
+	}
+
+	fetched := map[string]bool{}
+	args := &GetUTXOsArgs{
+		Addresses: []string{addr0, addr1},
+		Limit:     2,
+	}
+	for {
+		reply := &GetUTXOsReply{}
+		if err := s.GetUTXOs(nil, args, reply); err != nil {
+			t.Fatal(err)
+		}
+		if int(reply.NumFetched) != len(reply.UTXOs) {
+			t.Fatalf("NumFetched is %d but %d utxos were returned", reply.NumFetched, len(reply.UTXOs))
+		}
+		if len(reply.UTXOs) > 2 {
+			t.Fatalf("Limit of 2 utxos was exceeded with %d utxos", len(reply.UTXOs))
+		}
+		if len(reply.UTXOs) == 0 {
+			break
+		}
+		for _, utxo := range reply.UTXOs {
+			fetched[utxo.String()] = true
+		}
+		args.StartIndex = reply.EndIndex
+	}
+	if len(fetched) != len(allReply.UTXOs) {
+		t.Fatalf("Paging returned %d utxos, expected %d", len(fetched), len(allReply.UTXOs))
+	}
+	for _, utxo := range allReply.UTXOs {
+		if !fetched[utxo.String()] {
+			t.Fatalf("Paging didn't return utxo %s", utxo)
+		}
+	}
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	assetReply := &GetUTXOsReply{}
+	if err := s.GetUTXOs(nil, &GetUTXOsArgs{
+		Addresses: []string{addr0},
+		AssetID:   genesisTx.ID().String(),
+	}, assetReply); err != nil {
+		t.Fatal(err)
+	}
+	if len(assetReply.UTXOs) != 4 {
+		t.Fatalf("Expected 4 utxos of the asset, got %d", len(assetReply.UTXOs))
+	}
+}
+
 func TestGetAssetDescription(t *testing.T) {
 	genesisBytes, vm, s := setup(t)
 	defer func() {
@@ -340,3 +550,162 @@ func TestCreateVariableCapAsset(t *testing.T) {
 		t.Fatalf("Wrong assetID returned from CreateFixedCapAsset %s", reply.AssetID)
 	}
 }
+
+func TestServiceSendWithFee(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Keystore = nil
+		ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	vm.ava = genesisTx.ID()
+	vm.txFee = 10
+
+	username := "bobby"
+	password := "StrnasfqewiurPasswdn56d"
+	ks := keystore.Keystore{}
+	ks.Initialize(logging.NoLog{}, memdb.New())
+	if err := ks.CreateUser(nil, &keystore.CreateUserArgs{
+		Username: username,
+		Password: password,
+	}, &keystore.CreateUserReply{}); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Keystore = ks.NewBlockchainKeyStore(chainID, keystore.VMAccount([]string{"avm"}))
+
+	if err := s.ImportKey(nil, &ImportKeyArgs{
+		Username:   username,
+		Password:   password,
+		PrivateKey: formatting.CB58{Bytes: keys[0].Bytes()},
+	}, &ImportKeyReply{}); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := SendReply{}
+	if err := s.Send(nil, &SendArgs{
+		Username: username,
+		Password: password,
+		Amount:   100,
+		AssetID:  genesisTx.ID().String(),
+		To:       vm.Format(keys[1].PublicKey().Address().Bytes()),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	txIntf, err := vm.GetTx(reply.TxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := txIntf.(*UniqueTx).Tx.UnsignedTx.(*BaseTx)
+
+	consumed := uint64(0)
+	for _, in := range tx.Ins {
+		consumed += in.Input().Amount()
+	}
+	produced := uint64(0)
+	for _, out := range tx.Outs {
+		produced += out.Output().Amount()
+	}
+	if burned := consumed - produced; burned != vm.txFee {
+		t.Fatalf("Send burned %d, expected the fee of %d", burned, vm.txFee)
+	}
+
+	feeReply := GetTxFeeReply{}
+	if err := s.GetTxFee(nil, nil, &feeReply); err != nil {
+		t.Fatal(err)
+	}
+	if uint64(feeReply.TxFee) != vm.txFee || !feeReply.AssetID.Equals(vm.ava) {
+		t.Fatalf("Wrong fee returned: %d of %s", feeReply.TxFee, feeReply.AssetID)
+	}
+}
+
+func TestServiceGetPendingTxs(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	// Prevent the tx from being flushed to the engine
+	vm.batchTimeout = time.Hour
+
+	tx := NewTx(t, genesisBytes, vm)
+	txID, err := vm.IssueTx(tx.Bytes(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.IssueTx(tx.Bytes(), nil); err != errDuplicateTx {
+		t.Fatalf("Reissuing a pending tx should have errored with %s, got %v", errDuplicateTx, err)
+	}
+
+	reply := GetPendingTxsReply{}
+	if err := s.GetPendingTxs(nil, nil, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Queued) != 1 || !reply.Queued[0].Equals(txID) || len(reply.Issued) != 0 {
+		t.Fatalf("Wrong pending txs returned: %v", reply)
+	}
+	if int(reply.QueuedSize) != len(tx.Bytes()) {
+		t.Fatalf("Wrong queued size %d", reply.QueuedSize)
+	}
+
+	vm.PendingTxs()
+
+	txReply := GetPendingTxReply{}
+	if err := s.GetPendingTx(nil, &GetPendingTxArgs{TxID: txID}, &txReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, tx.Bytes(), txReply.Tx.Bytes)
+	assert.True(t, txReply.Issued)
+}
+
+func TestServiceCreateAddressHD(t *testing.T) {
+	_, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Keystore = nil
+		ctx.Lock.Unlock()
+	}()
+
+	username := "bobby"
+	password := "StrnasfqewiurPasswdn56d"
+	mnemonic := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	ks := keystore.Keystore{}
+	ks.Initialize(logging.NoLog{}, memdb.New())
+	if err := ks.ImportMnemonic(nil, &keystore.ImportMnemonicArgs{
+		Username: username,
+		Password: password,
+		Mnemonic: mnemonic,
+	}, &keystore.ImportMnemonicReply{}); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Keystore = ks.NewBlockchainKeyStore(chainID, keystore.VMAccount([]string{"avm"}))
+
+	seed, err := crypto.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	master, err := crypto.NewMasterKeySECP256K1R(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uint32(0); i < 2; i++ {
+		reply := CreateAddressReply{}
+		if err := s.CreateAddress(nil, &CreateAddressArgs{
+			Username: username,
+			Password: password,
+		}, &reply); err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := master.Derive(append(keystore.DerivationPath(keystore.VMAccount([]string{"avm"})), i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := vm.Format(key.PrivateKey().PublicKey().Address().Bytes()); reply.Address != expected {
+			t.Fatalf("Created address %s ; Expected: %s", reply.Address, expected)
+		}
+	}
+}
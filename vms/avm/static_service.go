@@ -6,12 +6,17 @@ package avm
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/utils/timer"
 	"github.com/ava-labs/gecko/utils/wrappers"
 	"github.com/ava-labs/gecko/vms/components/codec"
+	"github.com/ava-labs/gecko/vms/nftfx"
+	"github.com/ava-labs/gecko/vms/propertyfx"
 	"github.com/ava-labs/gecko/vms/secp256k1fx"
 
 	cjson "github.com/ava-labs/gecko/utils/json"
@@ -47,7 +52,7 @@ type BuildGenesisReply struct {
 func (*StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, reply *BuildGenesisReply) error {
 	errs := wrappers.Errs{}
 
-	c := codec.NewDefault()
+	c := codec.NewDefaultManager(CodecVersion)
 	errs.Add(
 		c.RegisterType(&BaseTx{}),
 		c.RegisterType(&CreateAssetTx{}),
@@ -160,3 +165,79 @@ func (*StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, repl
 	reply.Bytes.Bytes = b
 	return nil
 }
+
+// DecodeTxArgs are arguments for DecodeTx
+type DecodeTxArgs struct {
+	Tx formatting.CB58 `json:"tx"`
+
+	// ChainAlias is used as the prefix of the addresses in the decoded
+	// transaction. If empty, the ID of the chain the transaction was issued to
+	// is used.
+	ChainAlias string `json:"chainAlias"`
+}
+
+// DecodeTxReply is the reply from DecodeTx
+type DecodeTxReply struct {
+	TxID      ids.ID      `json:"txID"`
+	DecodedTx interface{} `json:"decodedTx"`
+}
+
+// DecodeTx returns the JSON representation of a transaction issued to an AVM
+// chain that was created with the secp256k1fx, nftfx and propertyfx, in that
+// order.
+func (*StaticService) DecodeTx(_ *http.Request, args *DecodeTxArgs, reply *DecodeTxReply) error {
+	c, err := staticCodec()
+	if err != nil {
+		return err
+	}
+
+	tx := &Tx{}
+	if err := c.Unmarshal(args.Tx.Bytes, tx); err != nil {
+		return err
+	}
+	if tx.UnsignedTx == nil {
+		return errNilTx
+	}
+	tx.Initialize(args.Tx.Bytes)
+
+	chainAlias := args.ChainAlias
+	if chainAlias == "" {
+		chainAlias = txChainID(tx).String()
+	}
+	formatter := txFormatter{formatAddress: func(addr ids.ShortID) string {
+		return fmt.Sprintf("%s%s%s", chainAlias, addressSep, formatting.CB58{Bytes: addr.Bytes()})
+	}}
+
+	reply.TxID = tx.ID()
+	reply.DecodedTx = formatter.Format(tx)
+	return nil
+}
+
+// staticFxVM is the minimal VM needed to register the types of an fx
+type staticFxVM struct {
+	codec codec.Codec
+	clock timer.Clock
+}
+
+func (vm *staticFxVM) Codec() codec.Codec     { return vm.codec }
+func (vm *staticFxVM) Clock() *timer.Clock    { return &vm.clock }
+func (vm *staticFxVM) Logger() logging.Logger { return logging.NoLog{} }
+
+// staticCodec returns a codec that has the types of the default AVM chain
+// registered in the same order as the VM registers them
+func staticCodec() (codec.Codec, error) {
+	c := codec.NewDefaultManager(CodecVersion)
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&BaseTx{}),
+		c.RegisterType(&CreateAssetTx{}),
+		c.RegisterType(&OperationTx{}),
+		c.RegisterType(&ImportTx{}),
+		c.RegisterType(&ExportTx{}),
+	)
+	vm := &staticFxVM{codec: c}
+	for _, fx := range []Fx{&secp256k1fx.Fx{}, &nftfx.Fx{}, &propertyfx.Fx{}} {
+		errs.Add(fx.Initialize(vm))
+	}
+	return c, errs.Err
+}
@@ -5,6 +5,8 @@ package avm
 
 import (
 	"testing"
+
+	"github.com/ava-labs/gecko/utils/formatting"
 )
 
 func TestBuildGenesis(t *testing.T) {
@@ -78,3 +80,52 @@ func TestBuildGenesis(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDecodeTx(t *testing.T) {
+	genesisBytes, _, vm := GenesisVM(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	tx := NewTx(t, genesisBytes, vm)
+
+	ss := StaticService{}
+	reply := DecodeTxReply{}
+	if err := ss.DecodeTx(nil, &DecodeTxArgs{Tx: formatting.CB58{Bytes: tx.Bytes()}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.TxID.Equals(tx.ID()) {
+		t.Fatalf("Wrong txID returned. Expected %s, got %s", tx.ID(), reply.TxID)
+	}
+
+	decoded := reply.DecodedTx.(map[string]interface{})
+	unsignedTx := decoded["unsignedTx"].(map[string]interface{})
+	if txType := unsignedTx["type"]; txType != "avm.BaseTx" {
+		t.Fatalf("Wrong tx type %s", txType)
+	}
+	ins := unsignedTx["inputs"].([]interface{})
+	if len(ins) != 1 {
+		t.Fatalf("Expected 1 input, got %d", len(ins))
+	}
+	in := ins[0].(map[string]interface{})
+	if assetID := in["assetID"]; assetID != tx.UnsignedTx.(*BaseTx).Ins[0].AssetID().String() {
+		t.Fatalf("Wrong assetID %s", assetID)
+	}
+	if inType := in["input"].(map[string]interface{})["type"]; inType != "secp256k1fx.TransferInput" {
+		t.Fatalf("Wrong input type %s", inType)
+	}
+	creds := decoded["credentials"].([]interface{})
+	sigs := creds[0].(map[string]interface{})["signatures"].([]interface{})
+	if len(sigs) != 1 {
+		t.Fatalf("Expected 1 signature, got %d", len(sigs))
+	}
+}
+
+func TestDecodeTxInvalid(t *testing.T) {
+	ss := StaticService{}
+	reply := DecodeTxReply{}
+	if err := ss.DecodeTx(nil, &DecodeTxArgs{Tx: formatting.CB58{Bytes: []byte{1, 2, 3}}}, &reply); err == nil {
+		t.Fatal("Should have errored due to invalid tx bytes")
+	}
+}
@@ -0,0 +1,283 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/wrappers"
+	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/components/codec"
+
+	safemath "github.com/ava-labs/gecko/utils/math"
+)
+
+const (
+	// maxAddressTxsToFetch is the maximum number of history entries that can
+	// be returned in a single paginated request
+	maxAddressTxsToFetch = 1024
+)
+
+var (
+	errTxIndexDisabled = errors.New("transaction indexing is disabled on this node")
+	errTxIndexBroken   = errors.New("transaction index is incomplete since a transaction failed to be indexed")
+
+	brokenKey = []byte("broken")
+)
+
+// Direction describes how an address was involved in a transaction
+type Direction uint8
+
+// Directions an asset can move relative to an address
+const (
+	Received Direction = iota + 1
+	Sent
+	SentAndReceived
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Received:
+		return "received"
+	case Sent:
+		return "sent"
+	case SentAndReceived:
+		return "sentAndReceived"
+	default:
+		return "unknown"
+	}
+}
+
+// AddressTx is an entry in the transaction history of an address. Each entry
+// records how one asset moved relative to the address in one accepted
+// transaction.
+type AddressTx struct {
+	TxID      ids.ID `serialize:"true"`
+	AssetID   ids.ID `serialize:"true"`
+	Sent      uint64 `serialize:"true"`
+	Received  uint64 `serialize:"true"`
+	Timestamp uint64 `serialize:"true"`
+}
+
+// Direction returns whether the address sent, received, or both sent and
+// received the asset in this transaction
+func (t *AddressTx) Direction() Direction {
+	switch {
+	case t.Sent > 0 && t.Received > 0:
+		return SentAndReceived
+	case t.Sent > 0:
+		return Sent
+	default:
+		return Received
+	}
+}
+
+// txIndex records, for every address, the history of accepted transactions
+// that consumed or produced utxos referencing the address.
+//
+// The history is stored as:
+//   counts:       address [| assetID]         -> number of entries
+//   history:      address | index             -> entry
+//   assetHistory: address | assetID | index   -> entry
+// where index is the big endian position of the entry in the history.
+//
+// If a transaction fails to be indexed, the index is marked as broken and is no
+// longer updated or served, as the history of some addresses is incomplete.
+type txIndex struct {
+	codec codec.Codec
+
+	counts, history, assetHistory, status database.Database
+
+	// true if the index is known to be broken
+	broken bool
+}
+
+func newTxIndex(db database.Database, c codec.Codec) *txIndex {
+	return &txIndex{
+		codec:        c,
+		counts:       prefixdb.New([]byte("txIndexCounts"), db),
+		history:      prefixdb.New([]byte("txIndexHistory"), db),
+		assetHistory: prefixdb.New([]byte("txIndexAssetHistory"), db),
+		status:       prefixdb.New([]byte("txIndexStatus"), db),
+	}
+}
+
+// MarkBroken records that a transaction failed to be indexed
+func (i *txIndex) MarkBroken() error {
+	i.broken = true
+	return i.status.Put(brokenKey, nil)
+}
+
+// Broken returns true if a transaction failed to be indexed
+func (i *txIndex) Broken() (bool, error) {
+	if i.broken {
+		return true, nil
+	}
+	broken, err := i.status.Has(brokenKey)
+	i.broken = broken
+	return broken, err
+}
+
+// Index records [tx] in the history of every address that the [spent] utxos
+// or the [produced] utxos reference. [timestamp] is the time that the
+// transaction was accepted.
+func (i *txIndex) Index(txID ids.ID, spent, produced []*ava.UTXO, timestamp uint64) error {
+	// Entries are kept in the order they were created in, so that the history
+	// is written deterministically
+	entries := []*AddressTx{}
+	addrs := []ids.ID{}
+	lookup := make(map[[64]byte]*AddressTx)
+
+	record := func(utxos []*ava.UTXO, sent bool) error {
+		for _, utxo := range utxos {
+			addressable, ok := utxo.Out.(ava.Addressable)
+			if !ok {
+				continue
+			}
+			amount := uint64(0)
+			if transferable, ok := utxo.Out.(ava.Transferable); ok {
+				amount = transferable.Amount()
+			}
+			assetID := utxo.AssetID()
+			for _, addr := range addressable.Addresses() {
+				addrID := ids.NewID(hashing.ComputeHash256Array(addr))
+
+				key := [64]byte{}
+				copy(key[:], addrID.Bytes())
+				copy(key[32:], assetID.Bytes())
+
+				entry, exists := lookup[key]
+				if !exists {
+					entry = &AddressTx{
+						TxID:      txID,
+						AssetID:   assetID,
+						Timestamp: timestamp,
+					}
+					lookup[key] = entry
+					entries = append(entries, entry)
+					addrs = append(addrs, addrID)
+				}
+
+				var err error
+				if sent {
+					entry.Sent, err = safemath.Add64(entry.Sent, amount)
+				} else {
+					entry.Received, err = safemath.Add64(entry.Received, amount)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := record(spent, true); err != nil {
+		return err
+	}
+	if err := record(produced, false); err != nil {
+		return err
+	}
+
+	for j, entry := range entries {
+		if err := i.add(addrs[j], entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *txIndex) add(addr ids.ID, entry *AddressTx) error {
+	entryBytes, err := i.codec.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	addrPrefix := addr.Bytes()
+	assetPrefix := indexKey(addrPrefix, entry.AssetID)
+
+	index, err := i.count(addrPrefix)
+	if err != nil {
+		return err
+	}
+	assetIndex, err := i.count(assetPrefix)
+	if err != nil {
+		return err
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		i.history.Put(historyKey(addrPrefix, index), entryBytes),
+		i.assetHistory.Put(historyKey(assetPrefix, assetIndex), entryBytes),
+		i.setCount(addrPrefix, index+1),
+		i.setCount(assetPrefix, assetIndex+1),
+	)
+	return errs.Err
+}
+
+// Count returns the number of entries in the history of the 32 byte
+// representation of an address. If [assetID] isn't empty, only entries of that
+// asset are counted.
+func (i *txIndex) Count(addr, assetID ids.ID) (uint64, error) {
+	if assetID.IsZero() {
+		return i.count(addr.Bytes())
+	}
+	return i.count(indexKey(addr.Bytes(), assetID))
+}
+
+// History returns at most [limit] entries of the history of the 32 byte
+// representation of an address, starting with the entry at position [start].
+// Entries are returned in the order they were accepted. If [assetID] isn't
+// empty, only entries of that asset are returned.
+func (i *txIndex) History(addr, assetID ids.ID, start uint64, limit int) ([]*AddressTx, error) {
+	db := i.history
+	prefix := addr.Bytes()
+	if !assetID.IsZero() {
+		db = i.assetHistory
+		prefix = indexKey(prefix, assetID)
+	}
+
+	iter := db.NewIteratorWithStartAndPrefix(historyKey(prefix, start), prefix)
+	defer iter.Release()
+
+	entries := []*AddressTx(nil)
+	for len(entries) < limit && iter.Next() {
+		entry := &AddressTx{}
+		if err := i.codec.Unmarshal(iter.Value(), entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, iter.Error()
+}
+
+func (i *txIndex) count(prefix []byte) (uint64, error) {
+	bytes, err := i.counts.Get(prefix)
+	if err == database.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	p := wrappers.Packer{Bytes: bytes}
+	count := p.UnpackLong()
+	return count, p.Err
+}
+
+func (i *txIndex) setCount(prefix []byte, count uint64) error {
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.LongLen)}
+	p.PackLong(count)
+	return i.counts.Put(prefix, p.Bytes)
+}
+
+// historyKey returns [prefix] followed by the big endian representation of
+// [index], so that entries are iterated over in the order they were added.
+func historyKey(prefix []byte, index uint64) []byte {
+	p := wrappers.Packer{Bytes: make([]byte, len(prefix)+wrappers.LongLen)}
+	p.PackFixedBytes(prefix)
+	p.PackLong(index)
+	return p.Bytes
+}
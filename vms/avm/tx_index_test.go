@@ -0,0 +1,169 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/components/codec"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+func testIndexUTXO(txID, assetID ids.ID, index uint32, amount uint64, addr ids.ShortID) *ava.UTXO {
+	return &ava.UTXO{
+		UTXOID: ava.UTXOID{
+			TxID:        txID,
+			OutputIndex: index,
+		},
+		Asset: ava.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+}
+
+func TestTxIndexHistory(t *testing.T) {
+	index := newTxIndex(memdb.New(), codec.NewDefault())
+
+	alice := ids.NewShortID([20]byte{1})
+	bob := ids.NewShortID([20]byte{2})
+	aliceID := ids.NewID(hashing.ComputeHash256Array(alice.Bytes()))
+	bobID := ids.NewID(hashing.ComputeHash256Array(bob.Bytes()))
+	assetA := ids.NewID([32]byte{'a'})
+	assetB := ids.NewID([32]byte{'b'})
+
+	tx0 := ids.NewID([32]byte{0})
+	tx1 := ids.NewID([32]byte{1})
+
+	// tx0 funds alice with both assets
+	if err := index.Index(tx0, nil, []*ava.UTXO{
+		testIndexUTXO(tx0, assetA, 0, 10, alice),
+		testIndexUTXO(tx0, assetB, 1, 20, alice),
+	}, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	// tx1 has alice send 7 of asset A to bob, with 3 returned as change
+	if err := index.Index(tx1, []*ava.UTXO{
+		testIndexUTXO(tx0, assetA, 0, 10, alice),
+	}, []*ava.UTXO{
+		testIndexUTXO(tx1, assetA, 0, 7, bob),
+		testIndexUTXO(tx1, assetA, 1, 3, alice),
+	}, 6); err != nil {
+		t.Fatal(err)
+	}
+
+	if count, err := index.Count(aliceID, ids.ID{}); err != nil {
+		t.Fatal(err)
+	} else if count != 3 {
+		t.Fatalf("Expected 3 entries for alice, got %d", count)
+	}
+	if count, err := index.Count(aliceID, assetB); err != nil {
+		t.Fatal(err)
+	} else if count != 1 {
+		t.Fatalf("Expected 1 asset B entry for alice, got %d", count)
+	}
+
+	history, err := index.History(aliceID, assetA, 0, maxAddressTxsToFetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 asset A entries for alice, got %d", len(history))
+	}
+	if first := history[0]; !first.TxID.Equals(tx0) || first.Direction() != Received || first.Received != 10 || first.Timestamp != 5 {
+		t.Fatalf("Wrong first entry: %+v", first)
+	}
+	if second := history[1]; !second.TxID.Equals(tx1) || second.Direction() != SentAndReceived || second.Sent != 10 || second.Received != 3 {
+		t.Fatalf("Wrong second entry: %+v", second)
+	}
+
+	// Page through alice's history one entry at a time
+	for i := uint64(0); i < 3; i++ {
+		page, err := index.History(aliceID, ids.ID{}, i, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) != 1 {
+			t.Fatalf("Expected 1 entry at position %d, got %d", i, len(page))
+		}
+	}
+	if page, err := index.History(aliceID, ids.ID{}, 3, 1); err != nil {
+		t.Fatal(err)
+	} else if len(page) != 0 {
+		t.Fatalf("Expected no entries past the end of the history, got %d", len(page))
+	}
+
+	bobHistory, err := index.History(bobID, ids.ID{}, 0, maxAddressTxsToFetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bobHistory) != 1 || !bobHistory[0].TxID.Equals(tx1) || bobHistory[0].Received != 7 {
+		t.Fatalf("Wrong history for bob: %+v", bobHistory)
+	}
+}
+
+func TestServiceGetAddressTxsDisabled(t *testing.T) {
+	_, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	addr := fmt.Sprintf("%s-%s", ctx.ChainID.String(), keys[0].PublicKey().Address())
+	reply := &GetAddressTxsReply{}
+	if err := s.GetAddressTxs(nil, &GetAddressTxsArgs{Address: addr}, reply); err != errTxIndexDisabled {
+		t.Fatalf("Expected %s, got %v", errTxIndexDisabled, err)
+	}
+}
+
+func TestTxIndexBroken(t *testing.T) {
+	db := memdb.New()
+	index := newTxIndex(db, codec.NewDefault())
+
+	if broken, err := index.Broken(); err != nil {
+		t.Fatal(err)
+	} else if broken {
+		t.Fatalf("A new index shouldn't be broken")
+	}
+	if err := index.MarkBroken(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The index should still be broken after being reopened
+	index = newTxIndex(db, codec.NewDefault())
+	if broken, err := index.Broken(); err != nil {
+		t.Fatal(err)
+	} else if !broken {
+		t.Fatalf("The index should have been marked as broken")
+	}
+}
+
+func TestServiceGetAddressTxsBroken(t *testing.T) {
+	_, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	vm.txIndex = newTxIndex(memdb.New(), vm.codec)
+	if err := vm.txIndex.MarkBroken(); err != nil {
+		t.Fatal(err)
+	}
+
+	addr := fmt.Sprintf("%s-%s", ctx.ChainID.String(), keys[0].PublicKey().Address())
+	reply := &GetAddressTxsReply{}
+	if err := s.GetAddressTxs(nil, &GetAddressTxsArgs{Address: addr}, reply); err != errTxIndexBroken {
+		t.Fatalf("Expected %s, got %v", errTxIndexBroken, err)
+	}
+}
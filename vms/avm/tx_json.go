@@ -0,0 +1,152 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/formatting"
+
+	cjson "github.com/ava-labs/gecko/utils/json"
+)
+
+// Encodings that a transaction can be returned in from the API
+const (
+	CB58Encoding = "cb58"
+	JSONEncoding = "json"
+)
+
+var (
+	errUnknownEncoding = errors.New("unknown encoding")
+
+	idType      = reflect.TypeOf(ids.ID{})
+	shortIDType = reflect.TypeOf(ids.ShortID{})
+)
+
+// txFormatter renders transactions into values that can be marshalled into
+// human readable JSON.
+//
+// Only the serialized fields of a transaction are rendered. Fields are named
+// by their json tags, embedded structs without a json tag are flattened into
+// their parent, and values stored in interfaces are annotated with their type
+// so that the fx that defined them can be identified. IDs and byte strings are
+// rendered in CB58, numbers are rendered as strings, and addresses are
+// rendered with [formatAddress].
+type txFormatter struct {
+	formatAddress func(addr ids.ShortID) string
+}
+
+// Format returns the JSON view of [tx]
+func (f *txFormatter) Format(tx *Tx) interface{} { return f.format(reflect.ValueOf(tx)) }
+
+func (f *txFormatter) format(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		formatted := f.format(value.Elem())
+		if fields, ok := formatted.(map[string]interface{}); ok {
+			fields["type"] = typeName(value.Elem().Type())
+		}
+		return formatted
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return f.format(value.Elem())
+	}
+
+	switch value.Type() {
+	case idType:
+		return value.Interface().(ids.ID).String()
+	case shortIDType:
+		return f.formatAddress(value.Interface().(ids.ShortID))
+	}
+
+	switch value.Kind() {
+	case reflect.Bool, reflect.String:
+		return value.Interface()
+	case reflect.Uint8:
+		return cjson.Uint8(value.Uint())
+	case reflect.Uint16:
+		return cjson.Uint16(value.Uint())
+	case reflect.Uint32:
+		return cjson.Uint32(value.Uint())
+	case reflect.Uint64:
+		return cjson.Uint64(value.Uint())
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(bytes), value)
+			return formatting.CB58{Bytes: bytes}.String()
+		}
+		elems := make([]interface{}, value.Len())
+		for i := range elems {
+			elems[i] = f.format(value.Index(i))
+		}
+		return elems
+	case reflect.Struct:
+		fields := make(map[string]interface{})
+		f.formatFields(value, fields)
+		return fields
+	default:
+		return value.Interface()
+	}
+}
+
+// formatFields adds the serialized fields of the struct [value] to [fields]
+func (f *txFormatter) formatFields(value reflect.Value, fields map[string]interface{}) {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("serialize") != "true" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if name == "" && field.Anonymous && fieldValue.Kind() == reflect.Struct {
+			f.formatFields(fieldValue, fields)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = f.format(fieldValue)
+	}
+}
+
+// typeName returns the package qualified name of [t], such as
+// secp256k1fx.TransferOutput
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.String()
+}
+
+// txChainID returns the ID of the chain that [tx] was issued to
+func txChainID(tx *Tx) ids.ID {
+	switch utx := tx.UnsignedTx.(type) {
+	case *BaseTx:
+		return utx.BCID
+	case *CreateAssetTx:
+		return utx.BCID
+	case *OperationTx:
+		return utx.BCID
+	case *ImportTx:
+		return utx.BCID
+	case *ExportTx:
+		return utx.BCID
+	default:
+		return ids.Empty
+	}
+}
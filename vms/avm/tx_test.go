@@ -17,7 +17,7 @@ import (
 func TestTxNil(t *testing.T) {
 	c := codec.NewDefault()
 	tx := (*Tx)(nil)
-	if err := tx.SyntacticVerify(ctx, c, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 1); err == nil {
 		t.Fatalf("Should have errored due to nil tx")
 	}
 	if err := tx.SemanticVerify(nil, nil); err == nil {
@@ -25,8 +25,8 @@ func TestTxNil(t *testing.T) {
 	}
 }
 
-func setupCodec() codec.Codec {
-	c := codec.NewDefault()
+func setupCodec() codec.Manager {
+	c := codec.NewDefaultManager(CodecVersion)
 	c.RegisterType(&BaseTx{})
 	c.RegisterType(&CreateAssetTx{})
 	c.RegisterType(&OperationTx{})
@@ -43,7 +43,7 @@ func setupCodec() codec.Codec {
 func TestTxEmpty(t *testing.T) {
 	c := setupCodec()
 	tx := &Tx{}
-	if err := tx.SyntacticVerify(ctx, c, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 1); err == nil {
 		t.Fatalf("Should have errored due to nil tx")
 	}
 }
@@ -81,7 +81,7 @@ func TestTxInvalidCredential(t *testing.T) {
 	}
 	tx.Initialize(b)
 
-	if err := tx.SyntacticVerify(ctx, c, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 1); err == nil {
 		t.Fatalf("Tx should have failed due to an invalid credential")
 	}
 }
@@ -139,7 +139,7 @@ func TestTxInvalidUnsignedTx(t *testing.T) {
 	}
 	tx.Initialize(b)
 
-	if err := tx.SyntacticVerify(ctx, c, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 1); err == nil {
 		t.Fatalf("Tx should have failed due to an invalid unsigned tx")
 	}
 }
@@ -188,7 +188,7 @@ func TestTxInvalidNumberOfCredentials(t *testing.T) {
 	}
 	tx.Initialize(b)
 
-	if err := tx.SyntacticVerify(ctx, c, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 1); err == nil {
 		t.Fatalf("Tx should have failed due to an invalid unsigned tx")
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/choices"
 	"github.com/ava-labs/gecko/snow/consensus/snowstorm"
+	"github.com/ava-labs/gecko/utils/logging"
 	"github.com/ava-labs/gecko/vms/components/ava"
 )
 
@@ -107,6 +108,24 @@ func (tx *UniqueTx) Accept() {
 		return
 	}
 
+	// Pending txs that conflict with this tx can no longer be accepted
+	for _, conflict := range tx.vm.mempool.RemoveConflicts(tx) {
+		tx.vm.ctx.Log.LogKV(logging.Debug, "Dropping pending tx that conflicts with an accepted tx", "txID", conflict.ID(), "acceptedTxID", tx.txID)
+	}
+
+	// The index must be updated before the spent utxos are removed. The index
+	// is optional, so failing to update it doesn't stop the tx from being
+	// accepted.
+	if err := tx.index(); err != nil {
+		tx.vm.ctx.Log.Error("Failed to index tx %s due to %s. Address histories will no longer be served", tx.txID, err)
+		if err := tx.vm.txIndex.MarkBroken(); err != nil {
+			tx.vm.ctx.Log.Error("Failed to mark the tx index as broken due to %s", err)
+		}
+	}
+
+	// The notification must also be built before the spent utxos are removed
+	notification := tx.notification()
+
 	// Remove spent utxos
 	for _, utxo := range tx.InputUTXOs() {
 		if utxo.Symbolic() {
@@ -143,6 +162,7 @@ func (tx *UniqueTx) Accept() {
 	tx.vm.ctx.Log.Verbo("Accepted Tx: %s", txID)
 
 	tx.vm.pubsub.Publish("accepted", txID)
+	tx.vm.notifications.Publish("accepted", notification)
 
 	tx.deps = nil // Needed to prevent a memory leak
 
@@ -151,6 +171,30 @@ func (tx *UniqueTx) Accept() {
 	}
 }
 
+// index records this transaction in the history of the addresses it touches,
+// if the VM is indexing transactions and the index isn't broken
+func (tx *UniqueTx) index() error {
+	if tx.vm.txIndex == nil {
+		return nil
+	}
+	if broken, err := tx.vm.txIndex.Broken(); err != nil || broken {
+		return err
+	}
+
+	spent := []*ava.UTXO{}
+	for _, utxo := range tx.InputUTXOs() {
+		if utxo.Symbolic() {
+			continue
+		}
+		spentUTXO, err := tx.vm.state.UTXO(utxo.InputID())
+		if err != nil {
+			return err
+		}
+		spent = append(spent, spentUTXO)
+	}
+	return tx.vm.txIndex.Index(tx.ID(), spent, tx.UTXOs(), tx.vm.clock.Unix())
+}
+
 // Reject is called when the transaction was finalized as rejected by consensus
 func (tx *UniqueTx) Reject() {
 	defer tx.vm.db.Abort()
@@ -160,6 +204,8 @@ func (tx *UniqueTx) Reject() {
 		return
 	}
 
+	tx.vm.mempool.Remove(tx)
+
 	txID := tx.ID()
 	tx.vm.ctx.Log.Debug("Rejecting Tx: %s", txID)
 
@@ -281,7 +327,7 @@ func (tx *UniqueTx) SyntacticVerify() error {
 	}
 
 	tx.verifiedTx = true
-	tx.validity = tx.Tx.SyntacticVerify(tx.vm.ctx, tx.vm.codec, len(tx.vm.fxs))
+	tx.validity = tx.Tx.SyntacticVerify(tx.vm.ctx, tx.vm.codec, tx.vm.ava, 0, len(tx.vm.fxs))
 	return tx.validity
 }
 
@@ -293,6 +339,15 @@ func (tx *UniqueTx) SemanticVerify() error {
 		return tx.validity
 	}
 
+	// The fee is only checked once the tx is verified for consensus, so that
+	// txs accepted before the fee activated can still be parsed while
+	// bootstrapping
+	if fee := tx.vm.fee(); fee > 0 {
+		if err := tx.Tx.SyntacticVerify(tx.vm.ctx, tx.vm.codec, tx.vm.ava, fee, len(tx.vm.fxs)); err != nil {
+			return err
+		}
+	}
+
 	if err := tx.Tx.SemanticVerify(tx.vm, tx); err != nil {
 		return err
 	}
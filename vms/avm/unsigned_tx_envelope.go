@@ -0,0 +1,206 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/json"
+	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/components/codec"
+	"github.com/ava-labs/gecko/vms/components/verify"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+var (
+	errCantSpend             = errors.New("output can't be spent by the provided addresses")
+	errUnsupportedInput      = errors.New("only secp256k1fx transfer inputs of base txs can be signed offline")
+	errEnvelopeInputMismatch = errors.New("envelope inputs don't match the transaction's inputs")
+	errInvalidSigIndices     = errors.New("invalid signature indices")
+	errMissingSigningKey     = errors.New("missing key of a required signer")
+	errInvalidSignatureLen   = fmt.Errorf("signatures must be %d bytes", crypto.SECP256K1RSigLen)
+)
+
+// UnsignedTxEnvelope is an unsigned transaction along with what an offline
+// signer, such as a hardware wallet, needs to verify and sign it without
+// access to a node.
+//
+// [UnsignedTx] is the serialized UnsignedTx that is signed. [Inputs] describes
+// the utxo consumed by each input of the transaction, in order.
+//
+// To sign the transaction, the signer hashes [UnsignedTx] with SHA256. For
+// input i, it then signs the hash with the key of Inputs[i].Owners[j] for each
+// j in Inputs[i].SigIndices, in order. The signatures of input i make up the
+// i-th secp256k1fx.Credential of the signed transaction, which is issued with
+// IssueSignedTx.
+type UnsignedTxEnvelope struct {
+	UnsignedTx formatting.CB58       `json:"unsignedTx"`
+	Inputs     []UnsignedTxInputUTXO `json:"inputs"`
+}
+
+// UnsignedTxInputUTXO describes the utxo consumed by an input of an unsigned
+// transaction, and which of its owners sign the input
+type UnsignedTxInputUTXO struct {
+	UTXOID     ava.UTXOID    `json:"utxoID"`
+	AssetID    ids.ID        `json:"assetID"`
+	Amount     json.Uint64   `json:"amount"`
+	Locktime   json.Uint64   `json:"locktime"`
+	Threshold  json.Uint32   `json:"threshold"`
+	Owners     []ids.ShortID `json:"owners"`
+	SigIndices []json.Uint32 `json:"sigIndices"`
+}
+
+// Sign verifies that the envelope describes the inputs of its transaction,
+// which is parsed with [c], and returns the credentials signing each input
+// with the keys in [kc]. It doesn't require access to a node.
+func (e *UnsignedTxEnvelope) Sign(c codec.Codec, kc *secp256k1fx.Keychain) ([]*secp256k1fx.Credential, error) {
+	var utx UnsignedTx
+	if err := c.Unmarshal(e.UnsignedTx.Bytes, &utx); err != nil {
+		return nil, fmt.Errorf("couldn't parse unsigned transaction: %w", err)
+	}
+
+	// Only the inputs of base txs can be checked against the envelope
+	baseTx, ok := utx.(*BaseTx)
+	if !ok {
+		return nil, errUnsupportedInput
+	}
+	if len(baseTx.Ins) != len(e.Inputs) || baseTx.NumCredentials() != len(e.Inputs) {
+		return nil, errEnvelopeInputMismatch
+	}
+	for i, input := range e.Inputs {
+		in := baseTx.Ins[i]
+		transferInput, ok := in.In.(*secp256k1fx.TransferInput)
+		switch {
+		case !ok:
+			return nil, errUnsupportedInput
+		case !in.InputID().Equals(input.UTXOID.InputID()),
+			!in.AssetID().Equals(input.AssetID),
+			transferInput.Amt != uint64(input.Amount),
+			len(transferInput.SigIndices) != len(input.SigIndices):
+			return nil, errEnvelopeInputMismatch
+		}
+		for j, sigIndex := range transferInput.SigIndices {
+			if sigIndex != uint32(input.SigIndices[j]) {
+				return nil, errEnvelopeInputMismatch
+			}
+		}
+	}
+
+	hash := hashing.ComputeHash256(e.UnsignedTx.Bytes)
+	creds := make([]*secp256k1fx.Credential, len(e.Inputs))
+	for i, input := range e.Inputs {
+		if err := input.verifySigIndices(); err != nil {
+			return nil, err
+		}
+		cred := &secp256k1fx.Credential{}
+		for _, sigIndex := range input.SigIndices {
+			key, ok := kc.Get(input.Owners[sigIndex])
+			if !ok {
+				return nil, errMissingSigningKey
+			}
+			sig, err := key.SignHash(hash)
+			if err != nil {
+				return nil, fmt.Errorf("problem signing transaction: %w", err)
+			}
+			fixedSig := [crypto.SECP256K1RSigLen]byte{}
+			copy(fixedSig[:], sig)
+			cred.Sigs = append(cred.Sigs, fixedSig)
+		}
+		creds[i] = cred
+	}
+	return creds, nil
+}
+
+// verifySigIndices returns an error if the signature indices aren't a sorted
+// set of [Threshold] owners
+func (in *UnsignedTxInputUTXO) verifySigIndices() error {
+	if uint32(len(in.SigIndices)) != uint32(in.Threshold) {
+		return errInvalidSigIndices
+	}
+	for i, sigIndex := range in.SigIndices {
+		if int(sigIndex) >= len(in.Owners) || (i > 0 && sigIndex <= in.SigIndices[i-1]) {
+			return errInvalidSigIndices
+		}
+	}
+	return nil
+}
+
+// newUnsignedTxEnvelope returns the envelope of [tx], whose inputs must spend
+// secp256k1fx transfer outputs
+func (vm *VM) newUnsignedTxEnvelope(tx *BaseTx) (*UnsignedTxEnvelope, error) {
+	utx := UnsignedTx(tx)
+	unsignedBytes, err := vm.codec.Marshal(&utx)
+	if err != nil {
+		return nil, fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	envelope := &UnsignedTxEnvelope{
+		UnsignedTx: formatting.CB58{Bytes: unsignedBytes},
+		Inputs:     make([]UnsignedTxInputUTXO, len(tx.Ins)),
+	}
+	for i, in := range tx.Ins {
+		transferInput, ok := in.In.(*secp256k1fx.TransferInput)
+		if !ok {
+			return nil, errUnsupportedInput
+		}
+		utxo, err := vm.getUTXO(&in.UTXOID)
+		if err != nil {
+			return nil, err
+		}
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return nil, errUnsupportedInput
+		}
+
+		sigIndices := make([]json.Uint32, len(transferInput.SigIndices))
+		for j, sigIndex := range transferInput.SigIndices {
+			sigIndices[j] = json.Uint32(sigIndex)
+		}
+		envelope.Inputs[i] = UnsignedTxInputUTXO{
+			UTXOID:     in.UTXOID,
+			AssetID:    in.AssetID(),
+			Amount:     json.Uint64(out.Amt),
+			Locktime:   json.Uint64(out.Locktime),
+			Threshold:  json.Uint32(out.Threshold),
+			Owners:     out.Addrs,
+			SigIndices: sigIndices,
+		}
+	}
+	return envelope, nil
+}
+
+// watchOnlySpender spends outputs owned by addresses whose keys aren't known.
+// The inputs it creates have the signature indices of the addresses, but no
+// keys are returned to sign them.
+type watchOnlySpender struct{ addrs ids.ShortSet }
+
+func (s *watchOnlySpender) Spend(out verify.Verifiable, time uint64) (verify.Verifiable, []*crypto.PrivateKeySECP256K1R, error) {
+	transferOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok || time < transferOut.Locktime {
+		return nil, nil, errCantSpend
+	}
+	sigIndices := []uint32{}
+	for i, addr := range transferOut.Addrs {
+		if uint32(len(sigIndices)) == transferOut.Threshold {
+			break
+		}
+		if s.addrs.Contains(addr) {
+			sigIndices = append(sigIndices, uint32(i))
+		}
+	}
+	if uint32(len(sigIndices)) != transferOut.Threshold {
+		return nil, nil, errCantSpend
+	}
+	return &secp256k1fx.TransferInput{
+		Amt: transferOut.Amt,
+		Input: secp256k1fx.Input{
+			SigIndices: sigIndices,
+		},
+	}, nil, nil
+}
@@ -0,0 +1,137 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/gecko/snow/choices"
+	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+func TestServiceBuildSendOfflineSigning(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer ctx.Lock.Unlock()
+	defer vm.Shutdown()
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	from := keys[0].PublicKey().Address()
+	to := keys[1].PublicKey().Address()
+
+	buildSend := func() UnsignedTxEnvelope {
+		envelope := UnsignedTxEnvelope{}
+		if err := s.BuildSend(nil, &BuildSendArgs{
+			From:    []string{fmt.Sprintf("%s-%s", vm.ctx.ChainID, from)},
+			Amount:  1000,
+			AssetID: genesisTx.ID().String(),
+			To:      fmt.Sprintf("%s-%s", vm.ctx.ChainID, to),
+		}, &envelope); err != nil {
+			t.Fatal(err)
+		}
+		return envelope
+	}
+	issueArgs := func(envelope UnsignedTxEnvelope, creds []*secp256k1fx.Credential) *IssueSignedTxArgs {
+		args := &IssueSignedTxArgs{UnsignedTx: envelope.UnsignedTx}
+		for _, cred := range creds {
+			apiCred := APICredential{}
+			for _, sig := range cred.Sigs {
+				apiCred.Signatures = append(apiCred.Signatures, formatting.CB58{Bytes: sig[:]})
+			}
+			args.Credentials = append(args.Credentials, apiCred)
+		}
+		return args
+	}
+
+	envelope := buildSend()
+	if len(envelope.Inputs) == 0 {
+		t.Fatalf("Envelope should describe the spent utxos")
+	}
+	for _, input := range envelope.Inputs {
+		if len(input.Owners) != 1 || !input.Owners[0].Equals(from) {
+			t.Fatalf("Envelope input should be owned by %s, but is owned by %s", from, input.Owners)
+		}
+	}
+
+	// The keys of the recipient can't sign the transaction
+	wrongKC := secp256k1fx.NewKeychain()
+	wrongKC.Add(keys[1])
+	if _, err := envelope.Sign(vm.codec, wrongKC); err != errMissingSigningKey {
+		t.Fatalf("Expected %s, got %v", errMissingSigningKey, err)
+	}
+
+	// An envelope that misrepresents the spent amounts isn't signed
+	tampered := envelope
+	tampered.Inputs = append([]UnsignedTxInputUTXO(nil), envelope.Inputs...)
+	tampered.Inputs[0].Amount++
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(keys[0])
+	if _, err := tampered.Sign(vm.codec, kc); err != errEnvelopeInputMismatch {
+		t.Fatalf("Expected %s, got %v", errEnvelopeInputMismatch, err)
+	}
+
+	creds, err := envelope.Sign(vm.codec, kc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := IssueTxReply{}
+	if err := s.IssueSignedTx(nil, issueArgs(envelope, creds), &reply); err != nil {
+		t.Fatal(err)
+	}
+	statusReply := GetTxStatusReply{}
+	if err := s.GetTxStatus(nil, &GetTxStatusArgs{TxID: reply.TxID}, &statusReply); err != nil {
+		t.Fatal(err)
+	}
+	if statusReply.Status != choices.Processing {
+		t.Fatalf("Issued transaction should be processing, but is %s", statusReply.Status)
+	}
+
+	// Credentials with invalid signatures are rejected. The transaction spends
+	// other utxos than the one already issued, so it doesn't conflict with it.
+	fresh := buildSend()
+	spent := map[[32]byte]bool{}
+	for _, input := range envelope.Inputs {
+		spent[input.UTXOID.InputID().Key()] = true
+	}
+	for _, input := range fresh.Inputs {
+		if spent[input.UTXOID.InputID().Key()] {
+			t.Fatalf("Envelope spends utxo %s, which was already spent", input.UTXOID.InputID())
+		}
+	}
+	freshCreds, err := fresh.Sign(vm.codec, kc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := issueArgs(fresh, freshCreds)
+	validSig := args.Credentials[0].Signatures[0]
+	args.Credentials[0].Signatures[0] = formatting.CB58{Bytes: make([]byte, 65)}
+	if err := s.IssueSignedTx(nil, args, &IssueTxReply{}); err == nil {
+		t.Fatalf("Issuing a transaction with an invalid signature should have errored")
+	}
+	args.Credentials[0].Signatures[0] = validSig
+	if err := s.IssueSignedTx(nil, args, &IssueTxReply{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnsignedTxEnvelopeSignOnlyBaseTxs(t *testing.T) {
+	genesisBytes, vm, _ := setup(t)
+	defer ctx.Lock.Unlock()
+	defer vm.Shutdown()
+
+	// The inputs of txs other than base txs can't be checked against the
+	// envelope, so they aren't signed
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	unsignedBytes, err := vm.codec.Marshal(&genesisTx.UnsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope := UnsignedTxEnvelope{UnsignedTx: formatting.CB58{Bytes: unsignedBytes}}
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(keys[0])
+	if _, err := envelope.Sign(vm.codec, kc); err != errUnsupportedInput {
+		t.Fatalf("Expected %s, got %v", errUnsupportedInput, err)
+	}
+}
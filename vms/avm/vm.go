@@ -4,6 +4,7 @@
 package avm
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/ava-labs/gecko/cache"
 	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/database/snapshotdb"
 	"github.com/ava-labs/gecko/database/versiondb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
@@ -30,13 +33,31 @@ import (
 	cjson "github.com/ava-labs/gecko/utils/json"
 )
 
+//go:generate go run gen_codec.go
+
 const (
-	batchTimeout   = time.Second
-	batchSize      = 30
-	stateCacheSize = 10000
-	idCacheSize    = 10000
-	txCacheSize    = 10000
-	addressSep     = "-"
+	batchTimeout = time.Second
+	batchSize    = 30
+	addressSep   = "-"
+
+	// Default cache budgets. The state cache is bounded by the bytes its
+	// entries take up, and the others by their number of entries.
+	defaultStateCacheSize = 32 << 20
+	defaultIDCacheSize    = 10000
+	defaultTxCacheSize    = 10000
+
+	// snapshotCacheSize is the number of entries the caches of a read-only
+	// view of the state hold. The view only lives for a single API call.
+	snapshotCacheSize = 256
+
+	// maxUTXOsToFetch is the maximum number of UTXOs that can be returned in a
+	// single paginated request
+	maxUTXOsToFetch = 1024
+
+	// CodecVersion is the version of the codec that transactions and state
+	// are marshalled with. State stored with an earlier version must be
+	// migrated when it's increased.
+	CodecVersion = 0
 )
 
 var (
@@ -54,6 +75,11 @@ type VM struct {
 	ava      ids.ID
 	platform ids.ID
 
+	// Amount of AVA that must be burned by every transaction verified at or
+	// after txFeeActivation
+	txFee           uint64
+	txFeeActivation time.Time
+
 	// Contains information of where this VM is executing
 	ctx *snow.Context
 
@@ -64,13 +90,24 @@ type VM struct {
 
 	pubsub *cjson.PubSubServer
 
+	// notifications publishes the addresses and assets involved in accepted
+	// txs, so subscriptions can be filtered by them
+	notifications *cjson.PubSubServer
+
 	// State management
 	state *prefixedState
 
+	// Cache budgets. Non-positive budgets are replaced by the defaults.
+	stateCacheSize, idCacheSize, txCacheSize int
+
+	// Address transaction history, nil if transactions aren't being indexed
+	indexTxs bool
+	txIndex  *txIndex
+
 	// Transaction issuing
 	timer        *timer.Timer
 	batchTimeout time.Duration
-	txs          []snowstorm.Tx
+	mempool      *mempool
 	toEngine     chan<- common.Message
 
 	baseDB database.Database
@@ -117,14 +154,16 @@ func (vm *VM) Initialize(
 	vm.typeToFxIndex = map[reflect.Type]int{}
 	vm.Aliaser.Initialize()
 
-	vm.pubsub = cjson.NewPubSubServer(ctx)
-	c := codec.NewDefault()
+	vm.pubsub = cjson.NewPubSubServer(ctx, vm)
+	vm.notifications = cjson.NewPubSubServer(ctx, vm)
+	c := codec.NewDefaultManager(CodecVersion)
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		vm.pubsub.Register("accepted"),
 		vm.pubsub.Register("rejected"),
 		vm.pubsub.Register("verified"),
+		vm.notifications.Register("accepted"),
 
 		c.RegisterType(&BaseTx{}),
 		c.RegisterType(&CreateAssetTx{}),
@@ -161,19 +200,35 @@ func (vm *VM) Initialize(
 
 	vm.codec = c
 
+	if vm.stateCacheSize <= 0 {
+		vm.stateCacheSize = defaultStateCacheSize
+	}
+	if vm.idCacheSize <= 0 {
+		vm.idCacheSize = defaultIDCacheSize
+	}
+	if vm.txCacheSize <= 0 {
+		vm.txCacheSize = defaultTxCacheSize
+	}
 	vm.state = &prefixedState{
 		state: &state{State: ava.State{
-			Cache: &cache.LRU{Size: stateCacheSize},
+			Cache: &cache.SizedLRU{MaxSize: vm.stateCacheSize, Metrics: vm.cacheMetrics("state")},
 			DB:    vm.db,
 			Codec: vm.codec,
 		}},
 
-		tx:       &cache.LRU{Size: idCacheSize},
-		utxo:     &cache.LRU{Size: idCacheSize},
-		txStatus: &cache.LRU{Size: idCacheSize},
-		funds:    &cache.LRU{Size: idCacheSize},
+		tx:       &cache.LRU{Size: vm.idCacheSize, Metrics: vm.cacheMetrics("tx_id")},
+		utxo:     &cache.LRU{Size: vm.idCacheSize, Metrics: vm.cacheMetrics("utxo_id")},
+		txStatus: &cache.LRU{Size: vm.idCacheSize, Metrics: vm.cacheMetrics("status_id")},
 
-		uniqueTx: &cache.EvictableLRU{Size: txCacheSize},
+		uniqueTx: &cache.EvictableLRU{Size: vm.txCacheSize, Metrics: vm.cacheMetrics("unique_tx")},
+
+		utxoIndex:    prefixdb.New([]byte("utxoIndex"), vm.db),
+		assetIndex:   prefixdb.New([]byte("assetIndex"), vm.db),
+		balanceIndex: prefixdb.New([]byte("balanceIndex"), vm.db),
+	}
+
+	if vm.indexTxs {
+		vm.txIndex = newTxIndex(vm.db, vm.codec)
 	}
 
 	if err := vm.initAliases(genesisBytes); err != nil {
@@ -186,6 +241,21 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	// State stored with another codec version can't be read until it has been
+	// migrated
+	if err := codec.VerifyDatabaseVersion(vm.db, c); err != nil {
+		return err
+	}
+
+	// Databases created before the utxo and balance indices existed have them
+	// built in place
+	if indexStatus, err := vm.state.UTXOIndexInitialized(); err != nil || indexStatus == choices.Unknown {
+		vm.ctx.Log.Info("Building the utxo and balance indices")
+		if err := vm.state.BuildUTXOIndex(vm.db); err != nil {
+			return fmt.Errorf("couldn't build the utxo indices: %w", err)
+		}
+	}
+
 	vm.timer = timer.NewTimer(func() {
 		ctx.Lock.Lock()
 		defer ctx.Lock.Unlock()
@@ -194,6 +264,11 @@ func (vm *VM) Initialize(
 	})
 	go ctx.Log.RecoverAndPanic(vm.timer.Dispatch)
 	vm.batchTimeout = batchTimeout
+	vm.mempool = newMempool(vm.ava, maxMempoolTxs, maxMempoolSize, mempoolTxTTL, mempoolIssuedTxTTL, vm.dropTx)
+
+	if err := vm.restorePendingTxs(); err != nil {
+		return fmt.Errorf("couldn't restore the pending txs: %w", err)
+	}
 
 	return vm.db.Commit()
 }
@@ -210,6 +285,20 @@ func (vm *VM) Shutdown() {
 	vm.timer.Stop()
 	vm.ctx.Lock.Lock()
 
+	// Changes that weren't committed are discarded before the underlying
+	// database is closed
+	vm.db.Abort()
+
+	// Pending txs were never issued into consensus, so they're persisted to be
+	// issued once the VM restarts
+	if err := vm.persistPendingTxs(); err != nil {
+		vm.ctx.Log.Error("Persisting the pending txs failed with %s", err)
+		vm.db.Abort()
+	}
+
+	if err := vm.db.Close(); err != nil {
+		vm.ctx.Log.Error("Closing the versioned database failed with %s", err)
+	}
 	if err := vm.baseDB.Close(); err != nil {
 		vm.ctx.Log.Error("Closing the database failed with %s", err)
 	}
@@ -224,8 +313,9 @@ func (vm *VM) CreateHandlers() map[string]*common.HTTPHandler {
 	rpcServer.RegisterService(&Service{vm: vm}, "avm") // name this service "avm"
 
 	return map[string]*common.HTTPHandler{
-		"":        &common.HTTPHandler{Handler: rpcServer},
-		"/pubsub": &common.HTTPHandler{LockOptions: common.NoLock, Handler: vm.pubsub},
+		"":               common.NewMethodLockHandler(&vm.ctx.Lock, rpcServer, "avm.getBalance", "avm.getAllBalances"),
+		"/pubsub":        &common.HTTPHandler{LockOptions: common.NoLock, Handler: vm.pubsub},
+		"/notifications": &common.HTTPHandler{LockOptions: common.NoLock, Handler: vm.notifications},
 	}
 }
 
@@ -245,13 +335,21 @@ func (vm *VM) CreateStaticHandlers() map[string]*common.HTTPHandler {
 func (vm *VM) PendingTxs() []snowstorm.Tx {
 	vm.timer.Cancel()
 
-	txs := vm.txs
-	vm.txs = nil
-	return txs
+	return vm.mempool.Issue(vm.clock.Time())
 }
 
 // ParseTx implements the avalanche.DAGVM interface
-func (vm *VM) ParseTx(b []byte) (snowstorm.Tx, error) { return vm.parseTx(b) }
+func (vm *VM) ParseTx(b []byte) (snowstorm.Tx, error) {
+	tx, err := vm.parseTx(b)
+	if err != nil {
+		return nil, err
+	}
+
+	// Consensus may have learned about a pending tx from another node, in
+	// which case it will be decided without this node issuing it
+	vm.mempool.SetIssued(tx, vm.clock.Time())
+	return tx, vm.persistTx(tx)
+}
 
 // GetTx implements the avalanche.DAGVM interface
 func (vm *VM) GetTx(txID ids.ID) (snowstorm.Tx, error) {
@@ -279,10 +377,24 @@ func (vm *VM) IssueTx(b []byte, onDecide func(choices.Status)) (ids.ID, error) {
 	if err != nil {
 		return ids.ID{}, err
 	}
-	if err := tx.Verify(); err != nil {
+
+	// A tx this node doesn't know about yet is only persisted once it's in
+	// the mempool, so that a refused tx isn't left processing
+	if tx.Status() == choices.Unknown {
+		err = tx.SemanticVerify()
+	} else {
+		err = tx.Verify()
+	}
+	if err != nil {
+		return ids.ID{}, err
+	}
+	if err := vm.issueTx(tx); err != nil {
+		return ids.ID{}, err
+	}
+	if err := vm.persistTx(tx); err != nil {
+		vm.mempool.Remove(tx)
 		return ids.ID{}, err
 	}
-	vm.issueTx(tx)
 	tx.onDecide = onDecide
 	return tx.ID(), nil
 }
@@ -315,15 +427,27 @@ func (vm *VM) GetAtomicUTXOs(addrs ids.Set) ([]*ava.UTXO, error) {
 // GetUTXOs returns the utxos that at least one of the provided addresses is
 // referenced in.
 func (vm *VM) GetUTXOs(addrs ids.Set) ([]*ava.UTXO, error) {
-	utxoIDs := ids.Set{}
-	for _, addr := range addrs.List() {
-		utxos, _ := vm.state.Funds(addr)
-		utxoIDs.Add(utxos...)
+	return vm.GetAssetUTXOs(addrs, ids.ID{})
+}
+
+// GetAssetUTXOs returns the utxos of [assetID] that at least one of the
+// provided addresses is referenced in. If [assetID] is empty, utxos of every
+// asset are returned.
+func (vm *VM) GetAssetUTXOs(addrs ids.Set, assetID ids.ID) ([]*ava.UTXO, error) {
+	return getAssetUTXOs(vm.state, addrs, assetID)
+}
+
+// getAssetUTXOs returns the utxos of [assetID] in [state] that at least one of
+// the provided addresses is referenced in
+func getAssetUTXOs(state *prefixedState, addrs ids.Set, assetID ids.ID) ([]*ava.UTXO, error) {
+	utxoIDs, err := getAssetUTXOIDs(state, addrs, assetID)
+	if err != nil {
+		return nil, err
 	}
 
 	utxos := []*ava.UTXO{}
 	for _, utxoID := range utxoIDs.List() {
-		utxo, err := vm.state.UTXO(utxoID)
+		utxo, err := state.UTXO(utxoID)
 		if err != nil {
 			return nil, err
 		}
@@ -332,12 +456,141 @@ func (vm *VM) GetUTXOs(addrs ids.Set) ([]*ava.UTXO, error) {
 	return utxos, nil
 }
 
+// getAssetUTXOIDs returns the IDs of the utxos of [assetID] in [state] that at
+// least one of the provided addresses is referenced in. The utxos themselves
+// aren't loaded.
+func getAssetUTXOIDs(state *prefixedState, addrs ids.Set, assetID ids.ID) (ids.Set, error) {
+	utxoIDs := ids.Set{}
+	for _, addr := range addrs.List() {
+		addrUTXOIDs, err := state.UTXOIDs(addr, assetID, ids.ID{}, 0)
+		if err != nil {
+			return nil, err
+		}
+		utxoIDs.Add(addrUTXOIDs...)
+	}
+	return utxoIDs, nil
+}
+
+// readOnlyState returns a view of the last committed state that doesn't
+// change and can be read without holding the context lock. The returned
+// function must be called once the view is no longer needed.
+func (vm *VM) readOnlyState() (*prefixedState, func(), error) {
+	snapshot, err := vm.db.GetDatabase().Snapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	db := snapshotdb.New(snapshot)
+	readOnly := &prefixedState{
+		state: &state{State: ava.State{
+			Cache: &cache.LRU{Size: snapshotCacheSize},
+			DB:    db,
+			Codec: vm.codec,
+		}},
+
+		tx:       &cache.LRU{Size: snapshotCacheSize},
+		utxo:     &cache.LRU{Size: snapshotCacheSize},
+		txStatus: &cache.LRU{Size: snapshotCacheSize},
+
+		uniqueTx: &cache.EvictableLRU{Size: snapshotCacheSize},
+
+		utxoIndex:    prefixdb.New([]byte("utxoIndex"), db),
+		assetIndex:   prefixdb.New([]byte("assetIndex"), db),
+		balanceIndex: prefixdb.New([]byte("balanceIndex"), db),
+	}
+	return readOnly, func() { db.Close() }, nil
+}
+
+// GetPaginatedUTXOs returns at most [limit] utxos that at least one of the
+// provided addresses is referenced in. Addresses are visited in ascending
+// order, and the utxos of each address are visited in ascending order of their
+// IDs. Iteration resumes strictly after the utxo [startUTXOID] of the address
+// [startAddr]; if [startAddr] is empty, iteration begins with the first
+// address. If [assetID] isn't empty, only utxos of that asset are returned.
+//
+// Returns the utxos, along with the address and utxoID of the last utxo
+// visited, which should be passed back in to fetch the next page. A utxo that
+// references multiple of the provided addresses is returned once per page, but
+// may be returned again in a later page.
+func (vm *VM) GetPaginatedUTXOs(
+	addrs ids.Set,
+	assetID,
+	startAddr,
+	startUTXOID ids.ID,
+	limit int,
+) ([]*ava.UTXO, ids.ID, ids.ID, error) {
+	if limit <= 0 || limit > maxUTXOsToFetch {
+		limit = maxUTXOsToFetch
+	}
+
+	addrList := addrs.List()
+	ids.SortIDs(addrList)
+
+	lastAddr := startAddr
+	lastUTXOID := startUTXOID
+	utxoIDs := ids.Set{}
+	utxos := []*ava.UTXO{}
+	for _, addr := range addrList {
+		start := ids.ID{}
+		if !startAddr.IsZero() {
+			switch bytes.Compare(addr.Bytes(), startAddr.Bytes()) {
+			case -1:
+				continue
+			case 0:
+				start = startUTXOID
+			}
+		}
+
+		// Keep reading from this address until either the page is full or the
+		// address has no more utxos
+		for len(utxos) < limit {
+			toFetch := limit - len(utxos)
+			addrUTXOIDs, err := vm.state.UTXOIDs(addr, assetID, start, toFetch)
+			if err != nil {
+				return nil, ids.ID{}, ids.ID{}, err
+			}
+			for _, utxoID := range addrUTXOIDs {
+				lastAddr = addr
+				lastUTXOID = utxoID
+				start = utxoID
+
+				if utxoIDs.Contains(utxoID) {
+					continue
+				}
+				utxoIDs.Add(utxoID)
+
+				utxo, err := vm.state.UTXO(utxoID)
+				if err != nil {
+					return nil, ids.ID{}, ids.ID{}, err
+				}
+				utxos = append(utxos, utxo)
+			}
+			if len(addrUTXOIDs) < toFetch {
+				break
+			}
+		}
+		if len(utxos) >= limit {
+			break
+		}
+	}
+	return utxos, lastAddr, lastUTXOID, nil
+}
+
 /*
  ******************************************************************************
  *********************************** Fx API ***********************************
  ******************************************************************************
  */
 
+// fee returns the amount of AVA that a transaction verified now must burn.
+// Transactions accepted before the fee activated didn't burn it, so the fee
+// isn't checked when transactions are parsed.
+func (vm *VM) fee() uint64 {
+	if vm.clock.Time().Before(vm.txFeeActivation) {
+		return 0
+	}
+	return vm.txFee
+}
+
 // Clock returns a reference to the internal clock of this VM
 func (vm *VM) Clock() *timer.Clock { return &vm.clock }
 
@@ -356,7 +609,7 @@ func (vm *VM) Logger() logging.Logger { return vm.ctx.Log }
 // FlushTxs into consensus
 func (vm *VM) FlushTxs() {
 	vm.timer.Cancel()
-	if len(vm.txs) != 0 {
+	if vm.mempool.Len() != 0 {
 		select {
 		case vm.toEngine <- common.PendingTxs:
 		default:
@@ -408,6 +661,10 @@ func (vm *VM) initState(genesisBytes []byte) error {
 		return err
 	}
 
+	if err := codec.SetDatabaseVersion(vm.db, CodecVersion); err != nil {
+		return err
+	}
+
 	for _, genesisTx := range genesis.Txs {
 		if len(genesisTx.Outs) != 0 {
 			return errGenesisAssetMustHaveState
@@ -437,11 +694,34 @@ func (vm *VM) initState(genesisBytes []byte) error {
 				return err
 			}
 		}
+		if vm.txIndex != nil {
+			// Genesis transactions are recorded as being accepted at time 0
+			if err := vm.txIndex.Index(txID, nil, tx.UTXOs(), 0); err != nil {
+				return err
+			}
+		}
 	}
 
+	if err := vm.state.SetUTXOIndexInitialized(choices.Accepted); err != nil {
+		return err
+	}
 	return vm.state.SetDBInitialized(choices.Processing)
 }
 
+// cacheMetrics returns the metrics of the cache [name], or nil if the chain
+// doesn't record metrics. Metrics are optional, so if they can't be
+// registered, the cache is used without them.
+func (vm *VM) cacheMetrics(name string) *cache.Metrics {
+	if vm.ctx.Metrics == nil {
+		return nil
+	}
+	metrics, err := cache.NewMetrics(vm.ctx.Namespace, name, vm.ctx.Metrics)
+	if err != nil {
+		vm.ctx.Log.Warn("Failed to register metrics of the %s cache due to %s. Its metrics won't be reported", name, err)
+	}
+	return metrics
+}
+
 func (vm *VM) parseTx(b []byte) (*UniqueTx, error) {
 	rawTx := &Tx{}
 	err := vm.codec.Unmarshal(b, rawTx)
@@ -460,28 +740,121 @@ func (vm *VM) parseTx(b []byte) (*UniqueTx, error) {
 	if err := tx.SyntacticVerify(); err != nil {
 		return nil, err
 	}
+	return tx, nil
+}
 
-	if tx.Status() == choices.Unknown {
-		if err := vm.state.SetTx(tx.ID(), tx.Tx); err != nil {
-			return nil, err
-		}
-
-		if err := tx.setStatus(choices.Processing); err != nil {
-			return nil, err
-		}
+// persistTx records [tx] as processing if this node didn't know about it yet
+func (vm *VM) persistTx(tx *UniqueTx) error {
+	if tx.Status() != choices.Unknown {
+		return nil
 	}
+	if err := vm.state.SetTx(tx.ID(), tx.Tx); err != nil {
+		return err
+	}
+	return tx.setStatus(choices.Processing)
+}
 
-	return tx, nil
+// forgetTx removes [tx], which will never be decided by this node, from the
+// state, so that it's no longer reported as processing
+func (vm *VM) forgetTx(tx *UniqueTx) error {
+	if tx.Status() != choices.Processing {
+		return nil
+	}
+	if err := tx.setStatus(choices.Unknown); err != nil {
+		return err
+	}
+	return vm.state.SetTx(tx.ID(), nil)
 }
 
-func (vm *VM) issueTx(tx snowstorm.Tx) {
-	vm.txs = append(vm.txs, tx)
+func (vm *VM) issueTx(tx *UniqueTx) error {
+	replaced, err := vm.mempool.Add(tx, vm.clock.Time())
+	if err != nil {
+		return err
+	}
+	for _, replacedTx := range replaced {
+		vm.ctx.Log.LogKV(logging.Debug, "Pending transaction was replaced", "txID", replacedTx.ID(), "replacementTxID", tx.ID())
+	}
+
 	switch {
-	case len(vm.txs) == batchSize:
+	case vm.mempool.Len() >= batchSize:
 		vm.FlushTxs()
-	case len(vm.txs) == 1:
+	case vm.mempool.Len() == 1:
 		vm.timer.SetTimeoutIn(vm.batchTimeout)
 	}
+	return nil
+}
+
+// persistPendingTxs saves the txs waiting to be issued, so that they can be
+// restored by restorePendingTxs
+func (vm *VM) persistPendingTxs() error {
+	queued := vm.mempool.Queued()
+	if len(queued) == 0 {
+		return nil
+	}
+
+	txIDs := make([]ids.ID, len(queued))
+	for i, tx := range queued {
+		txID := tx.ID()
+		if err := vm.state.SetTx(txID, tx.Tx); err != nil {
+			return err
+		}
+		if err := vm.state.SetStatus(txID, choices.Processing); err != nil {
+			return err
+		}
+		txIDs[i] = txID
+	}
+	if err := vm.state.SetPendingTxs(txIDs); err != nil {
+		return err
+	}
+	vm.ctx.Log.Info("Persisted %d pending txs on shutdown", len(txIDs))
+	return vm.db.Commit()
+}
+
+// restorePendingTxs adds the txs that were waiting to be issued when the VM
+// last shut down back to the mempool. Txs that are no longer valid are
+// dropped.
+func (vm *VM) restorePendingTxs() error {
+	txIDs, err := vm.state.PendingTxs()
+	if err != nil {
+		return err
+	}
+
+	restored := 0
+	for _, txID := range txIDs {
+		tx := &UniqueTx{
+			vm:   vm,
+			txID: txID,
+		}
+		err := tx.Verify()
+		if err == nil {
+			err = vm.issueTx(tx)
+		}
+		if err != nil {
+			vm.ctx.Log.Debug("Dropping pending tx %s due to %s", txID, err)
+			if err := vm.forgetTx(tx); err != nil {
+				return err
+			}
+			continue
+		}
+		restored++
+	}
+	if len(txIDs) > 0 {
+		vm.ctx.Log.Info("Restored %d of %d pending txs", restored, len(txIDs))
+	}
+	return vm.state.SetPendingTxs(nil)
+}
+
+// dropTx is called when [tx] is removed from the mempool without having been
+// issued, so it will never be decided by this node
+func (vm *VM) dropTx(tx *UniqueTx) {
+	vm.ctx.Log.Debug("Dropping pending tx %s", tx.ID())
+	if err := vm.forgetTx(tx); err != nil {
+		vm.ctx.Log.Error("Failed to forget dropped tx %s due to %s", tx.ID(), err)
+	}
+	if tx.onDecide != nil {
+		tx.onDecide(choices.Rejected)
+		tx.onDecide = nil
+	}
 }
 
 func (vm *VM) getUTXO(utxoID *ava.UTXOID) (*ava.UTXO, error) {
@@ -541,8 +914,15 @@ func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
 	return false
 }
 
-// Parse ...
+// Parse parses [addrStr] as an address on this chain. Both Bech32 addresses,
+// formatted as by formatting.FormatAddress, and legacy addresses
+// "<chain alias>-<cb58>" are accepted.
 func (vm *VM) Parse(addrStr string) ([]byte, error) {
+	return ava.ParseAddress(vm.ctx, vm.ctx.ChainID, addrStr, vm.parseLegacy)
+}
+
+// parseLegacy parses [addrStr] as a legacy address "<chain alias>-<cb58>"
+func (vm *VM) parseLegacy(addrStr string) ([]byte, error) {
 	if count := strings.Count(addrStr, addressSep); count != 1 {
 		return nil, errInvalidAddress
 	}
@@ -6,16 +6,23 @@ package avm
 import (
 	"bytes"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/gecko/cache"
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
+	"github.com/ava-labs/gecko/snow/choices"
 	"github.com/ava-labs/gecko/snow/engine/common"
 	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/hashing"
 	"github.com/ava-labs/gecko/utils/units"
 	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/components/codec"
 	"github.com/ava-labs/gecko/vms/components/verify"
 	"github.com/ava-labs/gecko/vms/nftfx"
 	"github.com/ava-labs/gecko/vms/propertyfx"
@@ -458,6 +465,72 @@ func TestFxInitializationFailure(t *testing.T) {
 	}
 }
 
+func TestCodecVersionMismatch(t *testing.T) {
+	// The VM closes its database on shutdown, so each VM gets its own view of
+	// the same database
+	baseDB := memdb.New()
+	vmDB := func() *prefixdb.Database { return prefixdb.New([]byte("vm"), baseDB) }
+	genesisBytes := BuildGenesisTest(t)
+	fxs := []*common.Fx{&common.Fx{
+		ID: ids.Empty,
+		Fx: &secp256k1fx.Fx{},
+	}}
+
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	vm := &VM{}
+	if err := vm.Initialize(ctx, vmDB(), genesisBytes, make(chan common.Message, 1), fxs); err != nil {
+		t.Fatal(err)
+	}
+	vm.Shutdown()
+
+	if version, err := codec.DatabaseVersion(vmDB()); err != nil {
+		t.Fatal(err)
+	} else if version != CodecVersion {
+		t.Fatalf("Database should have recorded codec version %d, recorded %d", CodecVersion, version)
+	}
+
+	// State stored by a later codec version can't be read
+	if err := codec.SetDatabaseVersion(vmDB(), CodecVersion+1); err != nil {
+		t.Fatal(err)
+	}
+	vm = &VM{}
+	if err := vm.Initialize(ctx, vmDB(), genesisBytes, make(chan common.Message, 1), fxs); err == nil {
+		t.Fatalf("Should have errored due to the codec version of the database")
+	}
+	vm.Shutdown()
+}
+
+func TestTxFeeActivation(t *testing.T) {
+	genesisBytes, _, vm := GenesisVM(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	// The tx doesn't burn any of the fee asset
+	vm.ava = ids.NewID([32]byte{'f', 'e', 'e'})
+	vm.txFee = 10
+	vm.txFeeActivation = time.Unix(1000, 0)
+	vm.clock.Set(vm.txFeeActivation)
+
+	// The tx may have been accepted before the fee activated, so it can be
+	// parsed, but it can't be verified
+	tx, err := vm.ParseTx(NewTx(t, genesisBytes, vm).Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err == nil {
+		t.Fatalf("Tx should have failed verification due to not burning the fee")
+	}
+
+	vm.clock.Set(vm.txFeeActivation.Add(-time.Second))
+	if err := tx.Verify(); err != nil {
+		t.Fatalf("Tx shouldn't need to burn the fee before it activates: %s", err)
+	}
+}
+
 type testTxBytes struct{ unsignedBytes []byte }
 
 func (tx *testTxBytes) UnsignedBytes() []byte { return tx.unsignedBytes }
@@ -491,6 +564,114 @@ func TestIssueTx(t *testing.T) {
 	}
 }
 
+func TestIssueTxStatusAfterReplacement(t *testing.T) {
+	genesisBytes, _, vm := GenesisVM(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	// Burned amounts are measured in the asset the txs spend, so the tx that
+	// burns more of it replaces the others
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	vm.mempool = newMempool(genesisTx.ID(), maxMempoolTxs, maxMempoolSize, mempoolTxTTL, mempoolIssuedTxTTL, vm.dropTx)
+	s := &Service{vm: vm}
+
+	// newTx burns the whole utxo, while the txs returned by returningTx spend
+	// the same utxo and return [amount] of it
+	newTx := NewTx(t, genesisBytes, vm)
+	returningTx := func(amount uint64) *Tx {
+		tx := &Tx{UnsignedTx: &BaseTx{
+			NetID: networkID,
+			BCID:  chainID,
+			Outs: []*ava.TransferableOutput{&ava.TransferableOutput{
+				Asset: ava.Asset{ID: genesisTx.ID()},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+					},
+				},
+			}},
+			Ins: newTx.UnsignedTx.(*BaseTx).Ins,
+		}}
+		if err := s.signTx(tx, [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+			t.Fatal(err)
+		}
+		b, err := vm.codec.Marshal(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx.Initialize(b)
+		return tx
+	}
+	status := func(txID ids.ID) choices.Status {
+		reply := &GetTxStatusReply{}
+		if err := s.GetTxStatus(nil, &GetTxStatusArgs{TxID: txID}, reply); err != nil {
+			t.Fatal(err)
+		}
+		return reply.Status
+	}
+
+	replacedTx := returningTx(40000)
+	if _, err := vm.IssueTx(replacedTx.Bytes(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if txStatus := status(replacedTx.ID()); txStatus != choices.Processing {
+		t.Fatalf("Pending tx should be processing, is %s", txStatus)
+	}
+
+	if _, err := vm.IssueTx(newTx.Bytes(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if txStatus := status(replacedTx.ID()); txStatus != choices.Unknown {
+		t.Fatalf("Replaced tx should be unknown, is %s", txStatus)
+	}
+	if txStatus := status(newTx.ID()); txStatus != choices.Processing {
+		t.Fatalf("Replacement tx should be processing, is %s", txStatus)
+	}
+
+	// A tx the mempool refuses isn't persisted
+	refusedTx := returningTx(30000)
+	if _, err := vm.IssueTx(refusedTx.Bytes(), nil); err != errConflictingTx {
+		t.Fatalf("Should have failed with %s, got %v", errConflictingTx, err)
+	}
+	if txStatus := status(refusedTx.ID()); txStatus != choices.Unknown {
+		t.Fatalf("Refused tx should be unknown, is %s", txStatus)
+	}
+}
+
+func TestTxNotification(t *testing.T) {
+	genesisBytes, _, vm := GenesisVM(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	newTx := NewTx(t, genesisBytes, vm)
+	tx, err := vm.parseTx(newTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := tx.notification()
+	if !n.TxID.Equals(newTx.ID()) {
+		t.Fatalf("Notification has the wrong tx ID")
+	}
+	if assetIDs := n.FilterAssetIDs(); len(assetIDs) != 1 || !assetIDs[0].Equals(genesisTx.ID()) {
+		t.Fatalf("Notification should involve only asset %s, involves %v", genesisTx.ID(), assetIDs)
+	}
+	addr := keys[0].PublicKey().Address()
+	if addrs := n.FilterAddresses(); len(addrs) != 1 || !bytes.Equal(addrs[0], addr.Bytes()) {
+		t.Fatalf("Notification should involve only address %s, involves %v", addr, addrs)
+	}
+	if len(n.Addresses) != 1 || n.Addresses[0] != vm.Format(addr.Bytes()) {
+		t.Fatalf("Notification has the wrong formatted addresses %v", n.Addresses)
+	}
+}
+
 func TestGenesisGetUTXOs(t *testing.T) {
 	_, _, vm := GenesisVM(t)
 	defer func() {
@@ -1006,3 +1187,250 @@ func TestVMFormatAliased(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheConfiguration(t *testing.T) {
+	genesisBytes := BuildGenesisTest(t)
+
+	ctx := snow.DefaultContextTest()
+	ctx.NetworkID = networkID
+	ctx.ChainID = chainID
+	ctx.Namespace = "gecko_avm"
+	registry := prometheus.NewRegistry()
+	ctx.Metrics = registry
+
+	factory := &Factory{StateCacheSize: 1024, TxCacheSize: 5}
+	vmIntf, err := factory.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm := vmIntf.(*VM)
+
+	ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	err = vm.Initialize(
+		ctx,
+		memdb.New(),
+		genesisBytes,
+		make(chan common.Message, 1),
+		[]*common.Fx{{
+			ID: ids.Empty,
+			Fx: &secp256k1fx.Fx{},
+		}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stateCache, ok := vm.state.state.Cache.(*cache.SizedLRU); !ok {
+		t.Fatalf("State cache should be bounded in bytes")
+	} else if stateCache.MaxSize != 1024 {
+		t.Fatalf("State cache should be bounded to 1024 bytes, is bounded to %d", stateCache.MaxSize)
+	}
+	if vm.idCacheSize != defaultIDCacheSize {
+		t.Fatalf("ID caches should have the default size %d, have size %d", defaultIDCacheSize, vm.idCacheSize)
+	}
+	if vm.txCacheSize != 5 {
+		t.Fatalf("Transaction cache should have size 5, has size %d", vm.txCacheSize)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	registered := map[string]bool{}
+	for _, family := range families {
+		registered[family.GetName()] = true
+	}
+	for _, name := range []string{
+		"gecko_avm_state_cache_hits",
+		"gecko_avm_state_cache_misses",
+		"gecko_avm_state_cache_evictions",
+		"gecko_avm_unique_tx_cache_hits",
+	} {
+		if !registered[name] {
+			t.Fatalf("Metric %s wasn't registered, registered %v", name, registered)
+		}
+	}
+}
+
+// Test that a VM whose cache metrics can't be registered still runs, without
+// reporting them
+func TestCacheMetricsRegistrationFailure(t *testing.T) {
+	genesisBytes := BuildGenesisTest(t)
+
+	ctx := snow.DefaultContextTest()
+	ctx.NetworkID = networkID
+	ctx.ChainID = chainID
+	ctx.Namespace = "gecko_avm"
+	registry := prometheus.NewRegistry()
+	ctx.Metrics = registry
+
+	// Take the name of one of the state cache's metrics
+	if err := registry.Register(prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "gecko_avm",
+		Name:      "state_cache_hits",
+	})); err != nil {
+		t.Fatal(err)
+	}
+
+	vm := &VM{}
+	ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	err := vm.Initialize(
+		ctx,
+		memdb.New(),
+		genesisBytes,
+		make(chan common.Message, 1),
+		[]*common.Fx{{
+			ID: ids.Empty,
+			Fx: &secp256k1fx.Fx{},
+		}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stateCache, ok := vm.state.state.Cache.(*cache.SizedLRU); !ok {
+		t.Fatalf("State cache should be bounded in bytes")
+	} else if stateCache.Metrics != nil {
+		t.Fatalf("State cache shouldn't have metrics that failed to register")
+	}
+	if utxoCache, ok := vm.state.utxo.(*cache.LRU); !ok {
+		t.Fatalf("UTXO ID cache should be an LRU")
+	} else if utxoCache.Metrics == nil {
+		t.Fatalf("UTXO ID cache should still report metrics")
+	}
+}
+
+// Test that the utxo and balance indices are built in place for a database
+// that was created before they existed
+func TestBuildUTXOIndex(t *testing.T) {
+	genesisBytes := BuildGenesisTest(t)
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	db := memdb.New()
+
+	shortAddr := keys[0].PublicKey().Address()
+	addr := ids.NewID(hashing.ComputeHash256Array(shortAddr.Bytes()))
+	addrs := ids.Set{}
+	addrs.Add(addr)
+
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	newVM := func() *VM {
+		vm := &VM{}
+		if err := vm.Initialize(
+			ctx,
+			db,
+			genesisBytes,
+			make(chan common.Message, 1),
+			[]*common.Fx{&common.Fx{
+				ID: ids.Empty,
+				Fx: &secp256k1fx.Fx{},
+			}},
+		); err != nil {
+			t.Fatal(err)
+		}
+		vm.timer.Stop()
+		return vm
+	}
+	checkIndices := func(vm *VM) {
+		utxos, err := vm.GetUTXOs(addrs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(utxos) != 7 {
+			t.Fatalf("Wrong number of utxos. Expected (%d) returned (%d)", 7, len(utxos))
+		}
+		balance, err := vm.state.Balance(addr, genesisTx.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if balance != 300000 {
+			t.Fatalf("Wrong balance. Expected (%d) returned (%d)", 300000, balance)
+		}
+	}
+
+	checkIndices(newVM())
+
+	// Remove the indices, which are the only keys that aren't hashes
+	iter := db.NewIterator()
+	for iter.Next() {
+		if len(iter.Key()) != hashing.HashLen || bytes.Equal(iter.Key(), utxoIndexInitialized.Bytes()) {
+			if err := db.Delete(iter.Key()); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	iter.Release()
+
+	// The indices are rebuilt, and are built only once
+	checkIndices(newVM())
+	checkIndices(newVM())
+}
+
+func TestPendingTxsSurviveShutdown(t *testing.T) {
+	genesisBytes := BuildGenesisTest(t)
+	db := memdb.New()
+
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	newVM := func() *VM {
+		vm := &VM{}
+		// The VM closes the database it's given on shutdown, so each VM is
+		// given its own view of [db]
+		if err := vm.Initialize(
+			ctx,
+			prefixdb.New([]byte{}, db),
+			genesisBytes,
+			make(chan common.Message, 1),
+			[]*common.Fx{&common.Fx{
+				ID: ids.Empty,
+				Fx: &secp256k1fx.Fx{},
+			}},
+		); err != nil {
+			t.Fatal(err)
+		}
+		return vm
+	}
+
+	vm := newVM()
+	newTx := NewTx(t, genesisBytes, vm)
+	if _, err := vm.IssueTx(newTx.Bytes(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The tx is still waiting to be issued into consensus when the VM shuts
+	// down
+	vm.Shutdown()
+
+	vm = newVM()
+	defer func() { vm.Shutdown() }()
+
+	queued := vm.mempool.Queued()
+	if len(queued) != 1 || !queued[0].ID().Equals(newTx.ID()) {
+		t.Fatalf("The pending tx should have been restored, %d txs are pending", len(queued))
+	}
+	if status := queued[0].Status(); status != choices.Processing {
+		t.Fatalf("The restored tx should be %s, but is %s", choices.Processing, status)
+	}
+	if txs := vm.PendingTxs(); len(txs) != 1 {
+		t.Fatalf("Should have returned %d tx(s)", 1)
+	}
+
+	// The pending txs are only restored once
+	vm.Shutdown()
+	vm = newVM()
+	if pending := vm.mempool.Len(); pending != 0 {
+		t.Fatalf("No txs should be pending, %d are", pending)
+	}
+}
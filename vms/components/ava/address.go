@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ava
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow"
+	"github.com/ava-labs/gecko/utils/formatting"
+)
+
+var (
+	errWrongNetwork = errors.New("address is for a different network")
+	errWrongChain   = errors.New("address is for a different chain")
+)
+
+// ParseAddress parses [addrStr] as an address on the chain [chainID] of the
+// network of [ctx]. It's either a Bech32 address, formatted as by
+// formatting.FormatAddress, or a legacy address parsed by [parseLegacy]. The
+// chain alias and human readable part of a Bech32 address must be those of
+// [chainID] and the network.
+func ParseAddress(ctx *snow.Context, chainID ids.ID, addrStr string, parseLegacy func(string) ([]byte, error)) ([]byte, error) {
+	chainAlias, hrp, addr, err := formatting.ParseAddress(addrStr)
+	switch {
+	case errors.Is(err, formatting.ErrBech32Checksum):
+		// The address is a Bech32 address with a typo
+		return nil, err
+	case err != nil:
+		return parseLegacy(addrStr)
+	}
+
+	if expected := formatting.NetworkHRP(ctx.NetworkID); hrp != expected {
+		return nil, fmt.Errorf("%w: expected human readable part %q, but got %q", errWrongNetwork, expected, hrp)
+	}
+	addrChainID, err := ctx.BCLookup.Lookup(chainAlias)
+	if err != nil {
+		addrChainID, err = ids.FromString(chainAlias)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !addrChainID.Equals(chainID) {
+		return nil, errWrongChain
+	}
+	return addr, nil
+}
+
+// ParseShortAddress parses [addrStr] as an address on the chain [chainID] of
+// the network of [ctx]. It's either a Bech32 address or a legacy address
+// formatted as an ids.ShortID.
+func ParseShortAddress(ctx *snow.Context, chainID ids.ID, addrStr string) (ids.ShortID, error) {
+	addr, err := ParseAddress(ctx, chainID, addrStr, func(addrStr string) ([]byte, error) {
+		addr, err := ids.ShortFromString(addrStr)
+		if err != nil {
+			return nil, err
+		}
+		return addr.Bytes(), nil
+	})
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	return ids.ToShortID(addr)
+}
+
+// FormatAddress returns the Bech32 address of [addr] on the chain [chainID] of
+// the network of [ctx]
+func FormatAddress(ctx *snow.Context, chainID ids.ID, addr ids.ShortID) (string, error) {
+	chainAlias, err := ctx.BCLookup.PrimaryAlias(chainID)
+	if err != nil {
+		chainAlias = chainID.String()
+	}
+	return formatting.FormatAddress(chainAlias, formatting.NetworkHRP(ctx.NetworkID), addr.Bytes())
+}
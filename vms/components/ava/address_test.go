@@ -0,0 +1,79 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ava
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow"
+	"github.com/ava-labs/gecko/utils/formatting"
+)
+
+var errLegacy = errors.New("legacy address")
+
+func TestParseAddress(t *testing.T) {
+	chainID := ids.NewID([32]byte{1})
+	aliaser := &ids.Aliaser{}
+	aliaser.Initialize()
+	if err := aliaser.Alias(chainID, "X"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := snow.DefaultContextTest()
+	ctx.NetworkID = 12345
+	ctx.ChainID = chainID
+	ctx.BCLookup = aliaser
+
+	addr := ids.NewShortID([20]byte{1, 2, 3})
+	addrStr, err := FormatAddress(ctx, chainID, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed, err := ParseShortAddress(ctx, chainID, addrStr); err != nil {
+		t.Fatal(err)
+	} else if !parsed.Equals(addr) {
+		t.Fatalf("Parsed %s ; Expected: %s", parsed, addr)
+	}
+
+	// Legacy addresses are still accepted
+	if parsed, err := ParseShortAddress(ctx, chainID, addr.String()); err != nil {
+		t.Fatal(err)
+	} else if !parsed.Equals(addr) {
+		t.Fatalf("Parsed %s ; Expected: %s", parsed, addr)
+	}
+
+	// Addresses may use the chain ID instead of an alias
+	bech32, err := formatting.FormatAddress(chainID.String(), formatting.LocalHRP, addr.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseShortAddress(ctx, chainID, bech32); err != nil {
+		t.Fatal(err)
+	}
+
+	wrongNetwork, err := formatting.FormatAddress("X", formatting.MainnetHRP, addr.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseShortAddress(ctx, chainID, wrongNetwork); !errors.Is(err, errWrongNetwork) {
+		t.Fatalf("Expected %s, got %v", errWrongNetwork, err)
+	}
+
+	if _, err := ParseShortAddress(ctx, ids.Empty, addrStr); err != errWrongChain {
+		t.Fatalf("Expected %s, got %v", errWrongChain, err)
+	}
+
+	typo := []byte(addrStr)
+	if typo[len(typo)-1] == 'q' {
+		typo[len(typo)-1] = 'p'
+	} else {
+		typo[len(typo)-1] = 'q'
+	}
+	if _, err := ParseAddress(ctx, chainID, string(typo), func(string) ([]byte, error) {
+		return nil, errLegacy
+	}); !errors.Is(err, formatting.ErrBech32Checksum) {
+		t.Fatalf("Expected %s, got %v", formatting.ErrBech32Checksum, err)
+	}
+}
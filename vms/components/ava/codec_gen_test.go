@@ -0,0 +1,33 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ava
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/vms/components/codec"
+	"github.com/ava-labs/gecko/vms/components/codec/codecgen"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+func TestGeneratedCodec(t *testing.T) {
+	c := codec.NewDefault()
+	c.RegisterType(&secp256k1fx.MintOutput{})
+	c.RegisterType(&secp256k1fx.TransferOutput{})
+	c.RegisterType(&secp256k1fx.MintInput{})
+	c.RegisterType(&secp256k1fx.TransferInput{})
+	c.RegisterType(&secp256k1fx.Credential{})
+
+	for _, value := range []interface{}{
+		&UTXO{},
+		&UTXOID{},
+		&Asset{},
+		&TransferableInput{},
+		&TransferableOutput{},
+	} {
+		if err := codecgen.CheckEquivalence(c, value, 200, 0); err != nil {
+			t.Fatalf("%T: %s", value, err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build ignore
+// +build ignore
+
+// This program generates codec_gen.go. If a change to a type stops the
+// package from compiling, delete codec_gen.go before running it.
+package main
+
+import (
+	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/components/codec/codecgen"
+)
+
+func main() {
+	codecgen.Main(
+		"codec_gen.go",
+		&ava.UTXO{},
+		&ava.UTXOID{},
+		&ava.Asset{},
+		&ava.TransferableInput{},
+		&ava.TransferableOutput{},
+	)
+}
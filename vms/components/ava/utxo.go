@@ -6,9 +6,24 @@ package ava
 import (
 	"errors"
 
+	"github.com/ava-labs/gecko/cache"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/wrappers"
 	"github.com/ava-labs/gecko/vms/components/verify"
 )
 
+//go:generate go run gen_codec.go
+
+const (
+	// utxoSize is the size of a UTXO besides its output: its tx ID, output
+	// index and asset ID
+	utxoSize = 2*hashing.HashLen + wrappers.IntLen
+
+	// defaultOutputSize is the size assumed for outputs that don't report
+	// their size
+	defaultOutputSize = 128
+)
+
 var (
 	errNilUTXO   = errors.New("nil utxo is not valid")
 	errEmptyUTXO = errors.New("empty utxo is not valid")
@@ -22,6 +37,14 @@ type UTXO struct {
 	Out verify.Verifiable `serialize:"true" json:"output"`
 }
 
+// Size implements the cache.Sizable interface
+func (utxo *UTXO) Size() int {
+	if out, ok := utxo.Out.(cache.Sizable); ok {
+		return utxoSize + out.Size()
+	}
+	return utxoSize + defaultOutputSize
+}
+
 // Verify implements the verify.Verifiable interface
 func (utxo *UTXO) Verify() error {
 	switch {
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/ava-labs/gecko/cache"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/vms/components/codec"
@@ -32,6 +33,73 @@ func TestUTXOVerifyEmpty(t *testing.T) {
 	}
 }
 
+func TestUTXOSize(t *testing.T) {
+	utxo := &UTXO{
+		UTXOID: UTXOID{TxID: ids.Empty},
+		Asset:  Asset{ID: ids.Empty},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.ShortEmpty, ids.NewShortID([20]byte{1})},
+			},
+		},
+	}
+
+	// tx ID, output index, asset ID, amount, locktime, threshold, the number
+	// of addresses and the addresses
+	if size, expected := utxo.Size(), 32+4+32+8+8+4+4+2*20; size != expected {
+		t.Fatalf("UTXO should take up %d bytes, takes up %d", expected, size)
+	}
+
+	// Outputs that don't report their size are assumed to be the default
+	utxo.Out = &TestVerifiable{}
+	if size, expected := utxo.Size(), utxoSize+defaultOutputSize; size != expected {
+		t.Fatalf("UTXO should take up %d bytes, takes up %d", expected, size)
+	}
+}
+
+func TestUTXOCacheSize(t *testing.T) {
+	maxSize := 4096
+	c := &cache.SizedLRU{MaxSize: maxSize}
+
+	utxos := make([]*UTXO, 100)
+	for i := range utxos {
+		utxos[i] = &UTXO{
+			UTXOID: UTXOID{TxID: ids.NewID([32]byte{byte(i)})},
+			Asset:  Asset{ID: ids.Empty},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.ShortEmpty},
+				},
+			},
+		}
+		c.Put(utxos[i].InputID(), utxos[i])
+	}
+
+	if size := c.Size(); size > maxSize {
+		t.Fatalf("Cache should take up at most %d bytes, takes up %d", maxSize, size)
+	}
+
+	// Only the most recently added UTXOs that fit are kept
+	kept := 0
+	for i, utxo := range utxos {
+		if _, found := c.Get(utxo.InputID()); found {
+			kept++
+		} else if kept > 0 {
+			t.Fatalf("UTXO %d was evicted before a more recently added UTXO", i)
+		}
+	}
+	if kept == len(utxos) {
+		t.Fatalf("The UTXOs should have exceeded the size of the cache")
+	}
+	if size := c.Size(); size < kept*utxos[0].Size() {
+		t.Fatalf("%d UTXOs should take up at least %d bytes, take up %d", kept, kept*utxos[0].Size(), size)
+	}
+}
+
 func TestUTXOSerialize(t *testing.T) {
 	c := codec.NewDefault()
 	c.RegisterType(&secp256k1fx.MintOutput{})
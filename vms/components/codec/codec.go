@@ -4,9 +4,12 @@
 package codec
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"unicode"
 
 	"github.com/ava-labs/gecko/utils/wrappers"
@@ -31,13 +34,22 @@ var (
 	errUnmarshalUnexportedField  = errors.New("can't deserialize into an unexported field")
 	errOutOfMemory               = errors.New("out of memory")
 	errSliceTooLarge             = errors.New("slice too large")
+	errBadSinceTag               = errors.New("since tag must be a codec version")
+	errUnsortedMapKeys           = errors.New("map keys must be sorted by their encoding and unique")
+	errDuplicateMapKeys          = errors.New("map keys must have unique encodings")
 )
 
 // Codec handles marshaling and unmarshaling of structs
 type codec struct {
+	// version of the codec. Fields tagged as added in a later version aren't
+	// serialized.
+	version     uint16
 	maxSize     int
 	maxSliceLen int
 
+	// reflectOnly is true if generated marshalling code isn't used
+	reflectOnly bool
+
 	typeIDToType map[uint32]reflect.Type
 	typeToTypeID map[reflect.Type]uint32
 }
@@ -50,8 +62,12 @@ type Codec interface {
 }
 
 // New returns a new codec
-func New(maxSize, maxSliceLen int) Codec {
+func New(maxSize, maxSliceLen int) Codec { return newVersion(0, maxSize, maxSliceLen) }
+
+// newVersion returns a new codec that serializes the fields of codec [version]
+func newVersion(version uint16, maxSize, maxSliceLen int) codec {
 	return codec{
+		version:      version,
 		maxSize:      maxSize,
 		maxSliceLen:  maxSliceLen,
 		typeIDToType: map[uint32]reflect.Type{},
@@ -80,13 +96,17 @@ func (c codec) RegisterType(val interface{}) error {
 // 3) To include a field of a struct in the serialized form, add the tag `serialize:"true"` to it
 // 4) These typed members of a struct may be serialized:
 //    bool, string, uint[8,16,32,64, int[8,16,32,64],
-//	  structs, slices, arrays, interface.
-//	  structs, slices and arrays can only be serialized if their constituent parts can be.
+//	  structs, slices, arrays, maps, interface.
+//	  structs, slices, arrays and maps can only be serialized if their constituent parts can be.
 // 5) To marshal an interface typed value, you must pass a _pointer_ to the value
 // 6) If you want to be able to unmarshal into an interface typed value,
 //    you must call codec.RegisterType([instance of the type that fulfills the interface]).
-// 7) nil slices will be unmarshaled as an empty slice of the appropriate type
+// 7) nil slices and maps will be unmarshaled as an empty slice or map of the appropriate type
 // 8) Serialized fields must be exported
+// 9) A field added in codec version N must also have the tag `since:"N"`, so
+//    that it isn't serialized by codecs of earlier versions
+// 10) Map entries are serialized sorted by the bytes of their keys, so that a
+//     map has a single serialization. Unmarshalling rejects any other order.
 
 // Marshal returns the byte representation of [value]
 // If you want to marshal an interface, [value] must be a pointer
@@ -106,12 +126,19 @@ func (c codec) marshal(value reflect.Value) ([]byte, error) {
 
 	valueKind := value.Kind()
 	switch valueKind {
-	case reflect.Interface, reflect.Ptr, reflect.Slice:
+	case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map:
 		if value.IsNil() {
 			return nil, errNil
 		}
 	}
 
+	if gen, ok := c.generated(value); ok {
+		if err := gen.MarshalCodec(c, &p); err != nil {
+			return nil, err
+		}
+		return p.Bytes, p.Err
+	}
+
 	switch valueKind {
 	case reflect.Uint8:
 		p.PackByte(uint8(value.Uint()))
@@ -174,17 +201,30 @@ func (c codec) marshal(value reflect.Value) ([]byte, error) {
 			p.PackFixedBytes(eltBytes)
 		}
 		return p.Bytes, p.Err
+	case reflect.Map:
+		entries, err := c.marshalMapEntries(value)
+		if err != nil {
+			return nil, err
+		}
+		p.PackInt(uint32(len(entries)))
+		for _, entry := range entries {
+			p.PackFixedBytes(entry.key)
+			p.PackFixedBytes(entry.value)
+		}
+		return p.Bytes, p.Err
 	case reflect.Struct:
 		for i := 0; i < t.NumField(); i++ { // Go through all fields of this struct
 			field := t.Field(i)
-			if !shouldSerialize(field) { // Skip fields we don't need to serialize
+			if serialize, err := c.shouldSerialize(field); err != nil {
+				return nil, err
+			} else if !serialize { // Skip fields we don't need to serialize
 				continue
 			}
 			if unicode.IsLower(rune(field.Name[0])) { // Can only marshal exported fields
 				return nil, errMarshalUnexportedField
 			}
 			fieldVal := value.Field(i) // The field we're serializing
-			if fieldVal.Kind() == reflect.Slice && fieldVal.IsNil() {
+			if kind := fieldVal.Kind(); (kind == reflect.Slice || kind == reflect.Map) && fieldVal.IsNil() {
 				p.PackInt(0)
 				continue
 			}
@@ -237,6 +277,13 @@ func (c codec) Unmarshal(bytes []byte, dest interface{}) error {
 // Unmarshal bytes from [p] into [field]
 // [field] must be addressable
 func (c codec) unmarshal(p *wrappers.Packer, field reflect.Value) error {
+	if gen, ok := c.generated(field); ok {
+		if err := gen.UnmarshalCodec(c, p); err != nil {
+			return err
+		}
+		return p.Err
+	}
+
 	kind := field.Kind()
 	switch kind {
 	case reflect.Uint8:
@@ -278,6 +325,35 @@ func (c codec) unmarshal(p *wrappers.Packer, field reflect.Value) error {
 				return err
 			}
 		}
+	case reflect.Map:
+		numEntries := int(p.UnpackInt())
+		if numEntries < 0 || numEntries > c.maxSliceLen {
+			return errSliceTooLarge
+		}
+
+		mapType := field.Type()
+		field.Set(reflect.MakeMapWithSize(mapType, numEntries))
+		var prevKey []byte
+		for i := 0; i < numEntries; i++ {
+			keyStart := p.Offset
+			key := reflect.New(mapType.Key()).Elem()
+			if err := c.unmarshal(p, key); err != nil {
+				return err
+			}
+			// Keys must be in the order they're marshalled in, which also
+			// rules out duplicates
+			keyBytes := p.Bytes[keyStart:p.Offset]
+			if i > 0 && bytes.Compare(prevKey, keyBytes) >= 0 {
+				return errUnsortedMapKeys
+			}
+			prevKey = keyBytes
+
+			value := reflect.New(mapType.Elem()).Elem()
+			if err := c.unmarshal(p, value); err != nil {
+				return err
+			}
+			field.SetMapIndex(key, value)
+		}
 	case reflect.String:
 		field.SetString(p.UnpackStr())
 	case reflect.Interface:
@@ -306,7 +382,9 @@ func (c codec) unmarshal(p *wrappers.Packer, field reflect.Value) error {
 		// Go through all the fields and umarshal into each
 		for i := 0; i < structType.NumField(); i++ {
 			structField := structType.Field(i)
-			if !shouldSerialize(structField) { // Skip fields we don't need to unmarshal
+			if serialize, err := c.shouldSerialize(structField); err != nil {
+				return err
+			} else if !serialize { // Skip fields we don't need to unmarshal
 				continue
 			}
 			if unicode.IsLower(rune(structField.Name[0])) { // Only unmarshal into exported field
@@ -339,7 +417,48 @@ func (c codec) unmarshal(p *wrappers.Packer, field reflect.Value) error {
 	return p.Err
 }
 
-// Returns true iff [field] should be serialized
-func shouldSerialize(field reflect.StructField) bool {
-	return field.Tag.Get("serialize") == "true"
+// mapEntry is a marshalled map entry
+type mapEntry struct {
+	key, value []byte
+}
+
+// Returns the marshalled entries of the map [value], sorted by their keys
+func (c codec) marshalMapEntries(value reflect.Value) ([]mapEntry, error) {
+	entries := make([]mapEntry, 0, value.Len())
+	iter := value.MapRange()
+	for iter.Next() {
+		key, err := c.marshal(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		val, err := c.marshal(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, mapEntry{key: key, value: val})
+	}
+	sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].key, entries[j].key) < 0 })
+	for i := 1; i < len(entries); i++ {
+		// Keys that differ only in fields that aren't serialized
+		if bytes.Equal(entries[i-1].key, entries[i].key) {
+			return nil, errDuplicateMapKeys
+		}
+	}
+	return entries, nil
+}
+
+// Returns true iff [field] should be serialized by this version of the codec
+func (c codec) shouldSerialize(field reflect.StructField) (bool, error) {
+	if field.Tag.Get("serialize") != "true" {
+		return false, nil
+	}
+	since, ok := field.Tag.Lookup("since")
+	if !ok {
+		return true, nil
+	}
+	version, err := strconv.ParseUint(since, 10, 16)
+	if err != nil {
+		return false, fmt.Errorf("%w, not %q, on field %s", errBadSinceTag, since, field.Name)
+	}
+	return uint16(version) <= c.version, nil
 }
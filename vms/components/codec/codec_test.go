@@ -5,6 +5,7 @@ package codec
 
 import (
 	"bytes"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -606,3 +607,130 @@ func TestUnmarshalInvalidInterface(t *testing.T) {
 		}
 	}
 }
+
+type mapStruct struct {
+	Balances map[string]uint64            `serialize:"true"`
+	Inner    map[uint32]MyInnerStruct     `serialize:"true"`
+	Nested   map[uint16]map[uint16]string `serialize:"true"`
+}
+
+func TestMap(t *testing.T) {
+	c := NewDefault()
+	value := mapStruct{
+		Balances: map[string]uint64{"bob": 2, "alice": 1, "ed": 3},
+		Inner:    map[uint32]MyInnerStruct{2: {"two"}, 1: {"one"}},
+		Nested:   map[uint16]map[uint16]string{1: {2: "three"}},
+	}
+	bytes, err := c.Marshal(&value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries are ordered by their encoded keys, so shorter strings come first
+	expectedBalances := []byte{
+		0x00, 0x00, 0x00, 0x03, // number of entries
+		0x00, 0x02, 'e', 'd',
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03,
+		0x00, 0x03, 'b', 'o', 'b',
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+		0x00, 0x05, 'a', 'l', 'i', 'c', 'e',
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+	}
+	if !reflect.DeepEqual(bytes[:len(expectedBalances)], expectedBalances) {
+		t.Fatalf("Returned:\n0x%x\nExpected:\n0x%x", bytes[:len(expectedBalances)], expectedBalances)
+	}
+
+	// Marshalling is deterministic
+	for i := 0; i < 10; i++ {
+		if again, err := c.Marshal(&value); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(again, bytes) {
+			t.Fatalf("Map was marshalled as\n0x%x\nand\n0x%x", bytes, again)
+		}
+	}
+
+	parsed := mapStruct{}
+	if err := c.Unmarshal(bytes, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, value) {
+		t.Fatalf("Unmarshalled %+v, expected %+v", parsed, value)
+	}
+}
+
+func TestNilMap(t *testing.T) {
+	c := NewDefault()
+	bytes, err := c.Marshal(&mapStruct{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	if !reflect.DeepEqual(bytes, expected) {
+		t.Fatalf("Returned:\n0x%x\nExpected:\n0x%x", bytes, expected)
+	}
+
+	parsed := mapStruct{}
+	if err := c.Unmarshal(bytes, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Balances == nil || len(parsed.Balances) != 0 {
+		t.Fatalf("Should have unmarshalled an empty map, got %v", parsed.Balances)
+	}
+
+	if _, err := c.Marshal(map[string]uint64(nil)); !errors.Is(err, errNil) {
+		t.Fatalf("Should have failed with %s, got %v", errNil, err)
+	}
+}
+
+func TestUnmarshalNonCanonicalMap(t *testing.T) {
+	c := NewDefault()
+	tests := map[string][]byte{
+		"unsorted": {
+			0x00, 0x00, 0x00, 0x02,
+			0x00, 0x00, 0x00, 0x02, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x01,
+		},
+		"duplicate": {
+			0x00, 0x00, 0x00, 0x02,
+			0x00, 0x00, 0x00, 0x01, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00,
+		},
+	}
+	for name, bytes := range tests {
+		m := map[uint32]bool{}
+		if err := c.Unmarshal(bytes, &m); !errors.Is(err, errUnsortedMapKeys) {
+			t.Fatalf("%s: should have failed with %s, got %v", name, errUnsortedMapKeys, err)
+		}
+	}
+
+	tooLarge := New(defaultMaxSize, 1)
+	bytes := []byte{
+		0x00, 0x00, 0x00, 0x02,
+		0x00, 0x00, 0x00, 0x01, 0x01,
+		0x00, 0x00, 0x00, 0x02, 0x01,
+	}
+	m := map[uint32]bool{}
+	if err := tooLarge.Unmarshal(bytes, &m); !errors.Is(err, errSliceTooLarge) {
+		t.Fatalf("Should have failed with %s, got %v", errSliceTooLarge, err)
+	}
+	if err := c.Unmarshal(bytes, &m); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMarshalDuplicateMapKeys(t *testing.T) {
+	type key struct {
+		Serialized uint32 `serialize:"true"`
+		Ignored    uint32
+	}
+
+	c := NewDefault()
+	m := map[key]bool{{1, 1}: true, {1, 2}: false}
+	if _, err := c.Marshal(&m); !errors.Is(err, errDuplicateMapKeys) {
+		t.Fatalf("Should have failed with %s, got %v", errDuplicateMapKeys, err)
+	}
+}
@@ -0,0 +1,469 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package codecgen generates marshalling code that doesn't use reflection for
+// types serialized by the codec. The generated code produces the same bytes as
+// the reflective codec, which uses it for the types it was generated for.
+//
+// A package generates its code with a program, excluded from the build, that
+// passes the types to Main. Fields of other struct types are inlined into the
+// generated code, so it must be regenerated when any of the types it inlines
+// change. Values of interface types are marshalled by the codec. Code can't be
+// generated for types that contain maps.
+package codecgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ava-labs/gecko/vms/components/codec"
+)
+
+const header = "// Code generated by codecgen. DO NOT EDIT.\n\n"
+
+var (
+	errNoTypes       = errors.New("no types to generate code for")
+	errNotStruct     = errors.New("can only generate code for structs")
+	errMixedPackages = errors.New("types must all be in the same package")
+	errNotRegistered = errors.New("codec doesn't list its registered types")
+)
+
+// Main writes the marshalling code of [types], given as pointers to values of
+// the types, to [file]. It exits if generating the code fails.
+func Main(file string, types ...interface{}) {
+	reflectTypes := make([]reflect.Type, len(types))
+	for i, value := range types {
+		reflectTypes[i] = reflect.TypeOf(value).Elem()
+	}
+
+	src, err := Generate(reflectTypes)
+	if err != nil {
+		log.Fatalf("couldn't generate marshalling code: %s", err)
+	}
+	if err := ioutil.WriteFile(file, src, 0644); err != nil {
+		log.Fatalf("couldn't write %s: %s", file, err)
+	}
+}
+
+// RegisteredTypes returns the pointers to values of the types registered with
+// [c] that are in the package [pkgPath], such as unexported types registered
+// by that package, so that they can be passed to Main.
+func RegisteredTypes(c codec.Codec, pkgPath string) ([]interface{}, error) {
+	lister, ok := c.(interface{ RegisteredTypes() []reflect.Type })
+	if !ok {
+		return nil, errNotRegistered
+	}
+	values := []interface{}(nil)
+	for _, typ := range lister.RegisteredTypes() {
+		if typ.Kind() == reflect.Ptr && typ.Elem().PkgPath() == pkgPath {
+			values = append(values, reflect.New(typ.Elem()).Interface())
+		}
+	}
+	return values, nil
+}
+
+// Generate returns the formatted source of a file that implements
+// codec.Generated for each of [types], which must be structs of the same
+// package
+func Generate(types []reflect.Type) ([]byte, error) {
+	if len(types) == 0 {
+		return nil, errNoTypes
+	}
+
+	g := &generator{
+		pkgPath:  types[0].PkgPath(),
+		types:    map[reflect.Type]bool{},
+		imports:  map[string]string{},
+		inlining: map[reflect.Type]bool{},
+	}
+	for _, typ := range types {
+		if typ.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%w, not %s", errNotStruct, typ)
+		}
+		if typ.PkgPath() != g.pkgPath {
+			return nil, fmt.Errorf("%w: %s isn't in %s", errMixedPackages, typ, g.pkgPath)
+		}
+		g.types[typ] = true
+	}
+	g.imports["github.com/ava-labs/gecko/utils/wrappers"] = "wrappers"
+	g.imports["github.com/ava-labs/gecko/vms/components/codec"] = "codec"
+
+	body := &bytes.Buffer{}
+	g.buf = body
+	g.printf("func init() {\n")
+	g.printf("codec.RegisterGenerated(\n")
+	for _, typ := range types {
+		g.printf("&%s{},\n", typ.Name())
+	}
+	g.printf(")\n}\n")
+	for _, typ := range types {
+		if err := g.generateType(typ); err != nil {
+			return nil, err
+		}
+	}
+
+	file := &bytes.Buffer{}
+	file.WriteString(header)
+	fmt.Fprintf(file, "package %s\n\n", path.Base(g.pkgPath))
+	file.WriteString("import (\n")
+	// The standard library is imported in its own group
+	stdPaths, paths := []string(nil), []string(nil)
+	for importPath := range g.imports {
+		if strings.Contains(importPath, ".") {
+			paths = append(paths, importPath)
+		} else {
+			stdPaths = append(stdPaths, importPath)
+		}
+	}
+	sort.Strings(stdPaths)
+	sort.Strings(paths)
+	for _, importPath := range stdPaths {
+		fmt.Fprintf(file, "%q\n", importPath)
+	}
+	if len(stdPaths) > 0 {
+		file.WriteString("\n")
+	}
+	for _, importPath := range paths {
+		fmt.Fprintf(file, "%q\n", importPath)
+	}
+	file.WriteString(")\n\n")
+	file.Write(body.Bytes())
+
+	src, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	pkgPath string
+	// types code is being generated for
+	types map[reflect.Type]bool
+	// import path -> package name
+	imports map[string]string
+	// struct types being inlined, to catch recursive types
+	inlining map[reflect.Type]bool
+	// depth of the loop being generated, used to name loop variables
+	depth int
+
+	buf *bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g.buf, format, args...)
+}
+
+func (g *generator) generateType(typ reflect.Type) error {
+	g.printf("\n// MarshalCodec implements the codec.Generated interface\n")
+	g.printf("func (t *%s) MarshalCodec(c codec.Context, p *wrappers.Packer) error {\n", typ.Name())
+	if err := g.marshalStruct("t", typ); err != nil {
+		return err
+	}
+	g.printf("return p.Err\n}\n")
+
+	g.printf("\n// UnmarshalCodec implements the codec.Generated interface\n")
+	g.printf("func (t *%s) UnmarshalCodec(c codec.Context, p *wrappers.Packer) error {\n", typ.Name())
+	if err := g.unmarshalStruct("t", typ); err != nil {
+		return err
+	}
+	g.printf("return p.Err\n}\n")
+	return nil
+}
+
+// serializedFields returns the fields of [typ] the codec serializes, along
+// with the codec version each was added in
+func serializedFields(typ reflect.Type) ([]reflect.StructField, []uint64, error) {
+	fields := []reflect.StructField(nil)
+	versions := []uint64(nil)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Tag.Get("serialize") != "true" {
+			continue
+		}
+		if unicode.IsLower(rune(field.Name[0])) {
+			return nil, nil, fmt.Errorf("can't serialize the unexported field %s of %s", field.Name, typ)
+		}
+		version := uint64(0)
+		if since, ok := field.Tag.Lookup("since"); ok {
+			v, err := strconv.ParseUint(since, 10, 16)
+			if err != nil {
+				return nil, nil, fmt.Errorf("bad since tag %q on the field %s of %s", since, field.Name, typ)
+			}
+			version = v
+		}
+		fields = append(fields, field)
+		versions = append(versions, version)
+	}
+	return fields, versions, nil
+}
+
+func (g *generator) marshalStruct(expr string, typ reflect.Type) error {
+	fields, versions, err := serializedFields(typ)
+	if err != nil {
+		return err
+	}
+	for i, field := range fields {
+		if versions[i] > 0 {
+			g.printf("if c.Version() >= %d {\n", versions[i])
+		}
+		if err := g.marshal(expr+"."+field.Name, field.Type, true); err != nil {
+			return err
+		}
+		if versions[i] > 0 {
+			g.printf("}\n")
+		}
+	}
+	return nil
+}
+
+// marshal generates the code that packs [expr], of type [typ]. [isField] is
+// true if [expr] is a struct field, in which case a nil slice is packed as an
+// empty one rather than being an error.
+func (g *generator) marshal(expr string, typ reflect.Type, isField bool) error {
+	switch typ.Kind() {
+	case reflect.Bool:
+		g.printf("p.PackBool(bool(%s))\n", expr)
+	case reflect.Uint8, reflect.Int8:
+		g.printf("p.PackByte(byte(%s))\n", expr)
+	case reflect.Uint16, reflect.Int16:
+		g.printf("p.PackShort(uint16(%s))\n", expr)
+	case reflect.Uint32, reflect.Int32:
+		g.printf("p.PackInt(uint32(%s))\n", expr)
+	case reflect.Uint64, reflect.Int64:
+		g.printf("p.PackLong(uint64(%s))\n", expr)
+	case reflect.String:
+		g.printf("p.PackStr(string(%s))\n", expr)
+	case reflect.Array:
+		if typ.Elem() == byteType {
+			g.printf("p.PackFixedBytes(%s[:])\n", expr)
+			return nil
+		}
+		return g.marshalElements(expr, typ)
+	case reflect.Slice:
+		if !isField {
+			g.printf("if %s == nil {\nreturn codec.ErrNil\n}\n", expr)
+		}
+		g.printf("p.PackInt(uint32(len(%s)))\n", expr)
+		if typ.Elem() == byteType {
+			g.printf("p.PackFixedBytes(%s)\n", expr)
+			return nil
+		}
+		return g.marshalElements(expr, typ)
+	case reflect.Ptr:
+		g.printf("if %s == nil {\nreturn codec.ErrNil\n}\n", expr)
+		if typ.Elem().Kind() == reflect.Struct {
+			// Fields and methods are reached through the pointer
+			return g.marshal(expr, typ.Elem(), false)
+		}
+		return g.marshal("(*"+expr+")", typ.Elem(), false)
+	case reflect.Interface:
+		g.printf("if err := c.PackInterface(p, %s); err != nil {\nreturn err\n}\n", expr)
+	case reflect.Struct:
+		if g.types[typ] {
+			g.printf("if err := %s.MarshalCodec(c, p); err != nil {\nreturn err\n}\n", expr)
+			return nil
+		}
+		if g.inlining[typ] {
+			return fmt.Errorf("can't inline the recursive type %s", typ)
+		}
+		g.inlining[typ] = true
+		defer delete(g.inlining, typ)
+		return g.marshalStruct(expr, typ)
+	default:
+		return fmt.Errorf("can't marshal %s of kind %s", typ, typ.Kind())
+	}
+	return nil
+}
+
+func (g *generator) marshalElements(expr string, typ reflect.Type) error {
+	index := g.loopVar()
+	g.printf("for %s := range %s {\n", index, expr)
+	g.depth++
+	if err := g.marshal(expr+"["+index+"]", typ.Elem(), false); err != nil {
+		return err
+	}
+	g.depth--
+	g.printf("}\n")
+	return nil
+}
+
+func (g *generator) unmarshalStruct(expr string, typ reflect.Type) error {
+	fields, versions, err := serializedFields(typ)
+	if err != nil {
+		return err
+	}
+	for i, field := range fields {
+		// Unmarshalling stops at the first error. The error of the last field
+		// is returned by the caller.
+		if i > 0 {
+			g.printf("if p.Errored() {\nreturn p.Err\n}\n")
+		}
+		if versions[i] > 0 {
+			g.printf("if c.Version() >= %d {\n", versions[i])
+		}
+		if err := g.unmarshal(expr+"."+field.Name, field.Type); err != nil {
+			return err
+		}
+		if versions[i] > 0 {
+			g.printf("}\n")
+		}
+	}
+	return nil
+}
+
+// unmarshal generates the code that unpacks [expr], of type [typ]
+func (g *generator) unmarshal(expr string, typ reflect.Type) error {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return g.unpack(expr, typ, "p.UnpackBool()")
+	case reflect.Uint8, reflect.Int8:
+		return g.unpack(expr, typ, "p.UnpackByte()")
+	case reflect.Uint16, reflect.Int16:
+		return g.unpack(expr, typ, "p.UnpackShort()")
+	case reflect.Uint32, reflect.Int32:
+		return g.unpack(expr, typ, "p.UnpackInt()")
+	case reflect.Uint64, reflect.Int64:
+		return g.unpack(expr, typ, "p.UnpackLong()")
+	case reflect.String:
+		return g.unpack(expr, typ, "p.UnpackStr()")
+	case reflect.Array:
+		if typ.Elem() == byteType {
+			g.printf("copy(%s[:], p.UnpackFixedBytes(%d))\n", expr, typ.Len())
+			return nil
+		}
+		return g.unmarshalElements(expr, typ)
+	case reflect.Slice:
+		typeName, err := g.typeName(typ)
+		if err != nil {
+			return err
+		}
+		length := fmt.Sprintf("n%d", g.depth)
+		g.printf("{\n%s := int(p.UnpackInt())\n", length)
+		g.printf("if %s > c.MaxSliceLen() {\nreturn codec.ErrSliceTooLarge\n}\n", length)
+		g.printf("%s = make(%s, %s)\n", expr, typeName, length)
+		if typ.Elem() == byteType {
+			g.printf("copy(%s, p.UnpackFixedBytes(%s))\n}\n", expr, length)
+			return nil
+		}
+		if err := g.unmarshalElements(expr, typ); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case reflect.Ptr:
+		typeName, err := g.typeName(typ.Elem())
+		if err != nil {
+			return err
+		}
+		g.printf("%s = new(%s)\n", expr, typeName)
+		if typ.Elem().Kind() == reflect.Struct {
+			// Fields and methods are reached through the pointer
+			return g.unmarshal(expr, typ.Elem())
+		}
+		return g.unmarshal("(*"+expr+")", typ.Elem())
+	case reflect.Interface:
+		typeName, err := g.typeName(typ)
+		if err != nil {
+			return err
+		}
+		g.imports["fmt"] = "fmt"
+		value := fmt.Sprintf("v%d", g.depth)
+		g.printf("{\n%s, err := c.UnpackInterface(p)\nif err != nil {\nreturn err\n}\n", value)
+		g.printf("concrete, ok := %s.(%s)\n", value, typeName)
+		g.printf("if !ok {\nreturn fmt.Errorf(\"%%T does not implement interface %s\", %s)\n}\n", typ, value)
+		g.printf("%s = concrete\n}\n", expr)
+	case reflect.Struct:
+		if g.types[typ] {
+			g.printf("if err := %s.UnmarshalCodec(c, p); err != nil {\nreturn err\n}\n", expr)
+			return nil
+		}
+		if g.inlining[typ] {
+			return fmt.Errorf("can't inline the recursive type %s", typ)
+		}
+		g.inlining[typ] = true
+		defer delete(g.inlining, typ)
+		return g.unmarshalStruct(expr, typ)
+	default:
+		return fmt.Errorf("can't unmarshal %s of kind %s", typ, typ.Kind())
+	}
+	return nil
+}
+
+// unpack generates the code that assigns [unpacked], converted to [typ], to
+// [expr]
+func (g *generator) unpack(expr string, typ reflect.Type, unpacked string) error {
+	typeName, err := g.typeName(typ)
+	if err != nil {
+		return err
+	}
+	g.printf("%s = %s(%s)\n", expr, typeName, unpacked)
+	return nil
+}
+
+func (g *generator) unmarshalElements(expr string, typ reflect.Type) error {
+	index := g.loopVar()
+	g.printf("for %s := range %s {\n", index, expr)
+	g.depth++
+	if err := g.unmarshal(expr+"["+index+"]", typ.Elem()); err != nil {
+		return err
+	}
+	g.depth--
+	g.printf("}\n")
+	return nil
+}
+
+func (g *generator) loopVar() string { return fmt.Sprintf("i%d", g.depth) }
+
+// typeName returns how [typ] is written in the generated file
+func (g *generator) typeName(typ reflect.Type) (string, error) {
+	if typ == byteType {
+		return "byte", nil
+	}
+	if name := typ.Name(); name != "" {
+		switch pkgPath := typ.PkgPath(); pkgPath {
+		case "", g.pkgPath:
+			return name, nil
+		default:
+			if unicode.IsLower(rune(name[0])) {
+				return "", fmt.Errorf("can't refer to the unexported type %s", typ)
+			}
+			pkgName := path.Base(pkgPath)
+			for otherPath, otherName := range g.imports {
+				if otherName == pkgName && otherPath != pkgPath {
+					return "", fmt.Errorf("packages %s and %s have the same name", pkgPath, otherPath)
+				}
+			}
+			g.imports[pkgPath] = pkgName
+			return pkgName + "." + name, nil
+		}
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		elem, err := g.typeName(typ.Elem())
+		return "*" + elem, err
+	case reflect.Slice:
+		elem, err := g.typeName(typ.Elem())
+		return "[]" + elem, err
+	case reflect.Array:
+		elem, err := g.typeName(typ.Elem())
+		return fmt.Sprintf("[%d]%s", typ.Len(), elem), err
+	case reflect.Interface:
+		if typ.NumMethod() == 0 {
+			return "interface{}", nil
+		}
+	}
+	return "", fmt.Errorf("can't refer to the unnamed type %s", typ)
+}
+
+var byteType = reflect.TypeOf(byte(0))
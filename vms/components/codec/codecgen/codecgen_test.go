@@ -0,0 +1,90 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecgen
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+type inner struct {
+	Bytes []byte `serialize:"true"`
+}
+
+type outer struct {
+	Inner   inner    `serialize:"true"`
+	IDs     []ids.ID `serialize:"true"`
+	Added   uint32   `serialize:"true" since:"2"`
+	Ignored string
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate([]reflect.Type{reflect.TypeOf(outer{})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(src, []byte(header)) {
+		t.Fatalf("Generated code should start with %q", header)
+	}
+	for _, expected := range []string{
+		"func (t *outer) MarshalCodec(c codec.Context, p *wrappers.Packer) error {",
+		"func (t *outer) UnmarshalCodec(c codec.Context, p *wrappers.Packer) error {",
+		"p.PackFixedBytes(t.Inner.Bytes)",
+		"t.IDs = make([]ids.ID, n0)",
+		"if c.Version() >= 2 {",
+		`"github.com/ava-labs/gecko/ids"`,
+	} {
+		if !bytes.Contains(src, []byte(expected)) {
+			t.Fatalf("Generated code should contain %q:\n%s", expected, src)
+		}
+	}
+	if bytes.Contains(src, []byte("Ignored")) {
+		t.Fatalf("Generated code shouldn't serialize untagged fields:\n%s", src)
+	}
+
+	again, err := Generate([]reflect.Type{reflect.TypeOf(outer{})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, again) {
+		t.Fatalf("Generated code should be deterministic")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	if _, err := Generate(nil); !errors.Is(err, errNoTypes) {
+		t.Fatalf("Should have failed with %s, got %v", errNoTypes, err)
+	}
+	if _, err := Generate([]reflect.Type{reflect.TypeOf(uint32(0))}); !errors.Is(err, errNotStruct) {
+		t.Fatalf("Should have failed with %s, got %v", errNotStruct, err)
+	}
+	if _, err := Generate([]reflect.Type{reflect.TypeOf(outer{}), reflect.TypeOf(ids.ID{})}); !errors.Is(err, errMixedPackages) {
+		t.Fatalf("Should have failed with %s, got %v", errMixedPackages, err)
+	}
+
+	type unexported struct {
+		field uint32 `serialize:"true"`
+	}
+	if _, err := Generate([]reflect.Type{reflect.TypeOf(unexported{})}); err == nil {
+		t.Fatalf("Should have failed to serialize an unexported field")
+	}
+
+	type withMap struct {
+		Field map[uint32]uint32 `serialize:"true"`
+	}
+	if _, err := Generate([]reflect.Type{reflect.TypeOf(withMap{})}); err == nil {
+		t.Fatalf("Should have failed to generate code for a map")
+	}
+
+	type badTag struct {
+		Field uint32 `serialize:"true" since:"first"`
+	}
+	if _, err := Generate([]reflect.Type{reflect.TypeOf(badTag{})}); err == nil {
+		t.Fatalf("Should have failed due to the since tag")
+	}
+}
@@ -0,0 +1,167 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecgen
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"reflect"
+
+	"github.com/ava-labs/gecko/vms/components/codec"
+)
+
+const (
+	// maxFillDepth is how many slices, pointers and interfaces deep
+	// CheckEquivalence fills values
+	maxFillDepth = 8
+
+	// maxFillLen is the length of the longest slice or string
+	// CheckEquivalence fills
+	maxFillLen = 3
+)
+
+// CheckEquivalence returns an error if the generated marshalling code used by
+// [c] behaves differently from reflection on values of the type [value]
+// points to.
+//
+// [iterations] values are filled with random data drawn from [seed]. Values of
+// interface types are drawn from the types registered with [c]. Each value is
+// marshalled, the bytes are unmarshalled, and truncated or corrupted bytes are
+// unmarshalled, checking that the generated code and reflection return the
+// same bytes, values and whether an error occurred.
+func CheckEquivalence(c codec.Codec, value interface{}, iterations int, seed int64) error {
+	if m, ok := c.(codec.Manager); ok {
+		versionCodec, err := m.VersionCodec(m.CurrentVersion())
+		if err != nil {
+			return err
+		}
+		c = versionCodec
+	}
+	reflective, err := codec.NewReflective(c)
+	if err != nil {
+		return err
+	}
+	lister, ok := c.(interface{ RegisteredTypes() []reflect.Type })
+	if !ok {
+		return errNotRegistered
+	}
+
+	f := filler{
+		rand:  rand.New(rand.NewSource(seed)),
+		types: lister.RegisteredTypes(),
+	}
+	typ := reflect.TypeOf(value).Elem()
+	for i := 0; i < iterations; i++ {
+		v := reflect.New(typ)
+		f.fill(v.Elem(), 0)
+
+		genBytes, genErr := c.Marshal(v.Interface())
+		reflectBytes, reflectErr := reflective.Marshal(v.Interface())
+		if (genErr == nil) != (reflectErr == nil) {
+			return fmt.Errorf("marshalling %#v returned %v with generated code, but %v with reflection", v.Interface(), genErr, reflectErr)
+		}
+		if genErr != nil {
+			continue
+		}
+		if !bytes.Equal(genBytes, reflectBytes) {
+			return fmt.Errorf("marshalling %#v returned\n0x%x\nwith generated code, but\n0x%x\nwith reflection", v.Interface(), genBytes, reflectBytes)
+		}
+
+		if err := checkUnmarshal(c, reflective, typ, genBytes); err != nil {
+			return err
+		}
+		if len(genBytes) == 0 {
+			continue
+		}
+		truncated := genBytes[:f.rand.Intn(len(genBytes))]
+		if err := checkUnmarshal(c, reflective, typ, truncated); err != nil {
+			return err
+		}
+		corrupted := make([]byte, len(genBytes))
+		copy(corrupted, genBytes)
+		corrupted[f.rand.Intn(len(corrupted))] ^= byte(1 + f.rand.Intn(255))
+		if err := checkUnmarshal(c, reflective, typ, corrupted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUnmarshal returns an error if unmarshalling [b] into a value of [typ]
+// with [c] and [reflective] has different results
+func checkUnmarshal(c, reflective codec.Codec, typ reflect.Type, b []byte) error {
+	genValue := reflect.New(typ)
+	genErr := c.Unmarshal(b, genValue.Interface())
+	reflectValue := reflect.New(typ)
+	reflectErr := reflective.Unmarshal(b, reflectValue.Interface())
+	switch {
+	case (genErr == nil) != (reflectErr == nil):
+		return fmt.Errorf("unmarshalling 0x%x returned %v with generated code, but %v with reflection", b, genErr, reflectErr)
+	case genErr == nil && !reflect.DeepEqual(genValue.Interface(), reflectValue.Interface()):
+		return fmt.Errorf("unmarshalling 0x%x returned %#v with generated code, but %#v with reflection", b, genValue.Interface(), reflectValue.Interface())
+	default:
+		return nil
+	}
+}
+
+// filler fills values with random data
+type filler struct {
+	rand *rand.Rand
+	// types values of interface types are drawn from
+	types []reflect.Type
+}
+
+func (f *filler) fill(v reflect.Value, depth int) {
+	if !v.CanSet() || depth > maxFillDepth {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(f.rand.Intn(2) == 1)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(f.rand.Uint64())
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(f.rand.Uint64()))
+	case reflect.String:
+		b := make([]byte, f.rand.Intn(maxFillLen+1))
+		f.rand.Read(b)
+		v.SetString(string(b))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			f.fill(v.Index(i), depth)
+		}
+	case reflect.Slice:
+		// Nil slices are marshalled as empty slices
+		if f.rand.Intn(4) == 0 {
+			return
+		}
+		n := f.rand.Intn(maxFillLen + 1)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			f.fill(v.Index(i), depth+1)
+		}
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		f.fill(elem.Elem(), depth+1)
+		v.Set(elem)
+	case reflect.Interface:
+		implementations := []reflect.Type(nil)
+		for _, typ := range f.types {
+			if typ.Implements(v.Type()) {
+				implementations = append(implementations, typ)
+			}
+		}
+		if len(implementations) == 0 {
+			return
+		}
+		concrete := reflect.New(implementations[f.rand.Intn(len(implementations))]).Elem()
+		f.fill(concrete, depth+1)
+		v.Set(concrete)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f.fill(v.Field(i), depth)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/utils/wrappers"
+)
+
+var (
+	errDatabaseVersion = errors.New("database values must be migrated to the current codec version")
+
+	// versionKey is the key a database records the codec version of its values
+	// under
+	versionKey = []byte("codecVersion")
+)
+
+// DatabaseVersion returns the codec version that the values stored in [db]
+// were marshalled with. Databases that don't record a version were created
+// before versions were recorded, so their values have version 0.
+func DatabaseVersion(db database.KeyValueReader) (uint16, error) {
+	bytes, err := db.Get(versionKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	p := wrappers.Packer{Bytes: bytes}
+	version := p.UnpackShort()
+	return version, p.Err
+}
+
+// SetDatabaseVersion records that the values stored in [db] were marshalled
+// with codec [version]
+func SetDatabaseVersion(db database.KeyValueWriter, version uint16) error {
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.ShortLen)}
+	p.PackShort(version)
+	return db.Put(versionKey, p.Bytes)
+}
+
+// VerifyDatabaseVersion returns an error if the values stored in [db] weren't
+// marshalled with the current version of [m]. Such values can only be read
+// with UnmarshalVersion, so they must be migrated before [m] is used on [db].
+func VerifyDatabaseVersion(db database.KeyValueReader, m Manager) error {
+	version, err := DatabaseVersion(db)
+	if err != nil {
+		return err
+	}
+	if current := m.CurrentVersion(); version != current {
+		return fmt.Errorf("%w: the values have version %d, the current version is %d", errDatabaseVersion, version, current)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ava-labs/gecko/utils/wrappers"
+)
+
+// Errors returned by generated marshalling code
+var (
+	// ErrNil is returned when marshalling a nil value
+	ErrNil = errNil
+	// ErrSliceTooLarge is returned when unmarshalling a slice that is longer
+	// than the codec allows
+	ErrSliceTooLarge = errSliceTooLarge
+)
+
+// generatedTypes are the types whose marshalling code was generated. Types
+// that embed one of them get its methods, so a type only uses generated code
+// if it's registered here itself.
+var generatedTypes = map[reflect.Type]bool{}
+
+// Generated is implemented by pointers to types with marshalling code
+// generated by codecgen. The codec uses it instead of reflection, and it
+// produces the same bytes.
+type Generated interface {
+	MarshalCodec(c Context, p *wrappers.Packer) error
+	UnmarshalCodec(c Context, p *wrappers.Packer) error
+}
+
+// Context is the codec generated marshalling code runs in
+type Context interface {
+	// Version of the codec
+	Version() uint16
+
+	// MaxSliceLen is the length of the longest slice the codec unmarshals
+	MaxSliceLen() int
+
+	// PackInterface packs the type ID of [value], then [value]
+	PackInterface(p *wrappers.Packer, value interface{}) error
+
+	// UnpackInterface unpacks a type ID, then a value of that type
+	UnpackInterface(p *wrappers.Packer) (interface{}, error)
+}
+
+// RegisterGenerated records that the types of [values], which must be
+// pointers, have generated marshalling code. It must only be called from the
+// init function of generated code.
+func RegisterGenerated(values ...Generated) {
+	for _, value := range values {
+		generatedTypes[reflect.TypeOf(value).Elem()] = true
+	}
+}
+
+// NewReflective returns a codec with the version, limits and registered types
+// of [c] that marshals every type with reflection, even those with generated
+// marshalling code. If [c] is a Manager, the codec of its current version is
+// used. Generated code is checked against the returned codec.
+func NewReflective(c Codec) (Codec, error) {
+	var original codec
+	switch c := c.(type) {
+	case codec:
+		original = c
+	case *manager:
+		original = c.codecs[c.current]
+	default:
+		return nil, errBadCodec
+	}
+
+	reflective := newVersion(original.version, original.maxSize, original.maxSliceLen)
+	reflective.reflectOnly = true
+	for typeID, typ := range original.typeIDToType {
+		reflective.typeIDToType[typeID] = typ
+		reflective.typeToTypeID[typ] = typeID
+	}
+	return reflective, nil
+}
+
+// generated returns the generated marshalling code of [value], if it has any
+func (c codec) generated(value reflect.Value) (Generated, bool) {
+	if c.reflectOnly || value.Kind() != reflect.Struct || !value.CanAddr() || !generatedTypes[value.Type()] {
+		return nil, false
+	}
+	gen, ok := value.Addr().Interface().(Generated)
+	return gen, ok
+}
+
+func (c codec) Version() uint16 { return c.version }
+
+func (c codec) MaxSliceLen() int { return c.maxSliceLen }
+
+func (c codec) PackInterface(p *wrappers.Packer, value interface{}) error {
+	if value == nil {
+		return errNil
+	}
+	valueType := reflect.TypeOf(value)
+	typeID, ok := c.typeToTypeID[valueType]
+	if !ok {
+		return fmt.Errorf("can't marshal unregistered type '%v'", valueType.String())
+	}
+	bytes, err := c.Marshal(value)
+	if err != nil {
+		return err
+	}
+	p.PackInt(typeID)
+	p.PackFixedBytes(bytes)
+	return p.Err
+}
+
+func (c codec) UnpackInterface(p *wrappers.Packer) (interface{}, error) {
+	typeID := p.UnpackInt()
+	if p.Errored() {
+		return nil, p.Err
+	}
+	typ, ok := c.typeIDToType[typeID]
+	if !ok {
+		return nil, errUnmarshalUnregisteredType
+	}
+	value := reflect.New(typ).Elem()
+	if err := c.unmarshal(p, value); err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+// RegisteredTypes returns the types registered with the codec, in the order
+// of their type IDs
+func (c codec) RegisteredTypes() []reflect.Type {
+	types := make([]reflect.Type, len(c.typeIDToType))
+	for typeID, typ := range c.typeIDToType {
+		types[typeID] = typ
+	}
+	return types
+}
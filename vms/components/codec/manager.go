@@ -0,0 +1,122 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	errUnknownVersion = errors.New("unknown codec version")
+)
+
+// Manager is a codec that can marshal and unmarshal payloads in the format of
+// every codec version up to its current version.
+//
+// Payloads don't carry the version they were marshalled with, so payloads of
+// version 0 have the format they had before versioning, and no payload can be
+// mistaken for one of another version. The version of a stored payload must
+// be recorded out of band, such as with SetDatabaseVersion.
+//
+// Marshal and Unmarshal use the current version, while MarshalVersion and
+// UnmarshalVersion use the given version, so payloads marshalled by earlier
+// versions stay decodable after the format changes. The format of a struct is
+// changed by adding fields tagged with the version that added them, such as
+// `serialize:"true" since:"1"`, and increasing the current version.
+//
+// RegisterType registers a type with every version, so that its type ID is the
+// same in every version. A type that is only used by some versions can be
+// registered with those versions through VersionCodec.
+type Manager interface {
+	Codec
+
+	// CurrentVersion returns the version Marshal and Unmarshal use
+	CurrentVersion() uint16
+
+	// MarshalVersion returns the byte representation of [value] in the format
+	// of codec [version]
+	MarshalVersion(version uint16, value interface{}) ([]byte, error)
+
+	// UnmarshalVersion unmarshals [bytes], which are in the format of codec
+	// [version], into [dest]
+	UnmarshalVersion(version uint16, bytes []byte, dest interface{}) error
+
+	// VersionCodec returns the codec of [version]
+	VersionCodec(version uint16) (Codec, error)
+}
+
+type manager struct {
+	current uint16
+	// codecs[v] is the codec of version v
+	codecs []codec
+}
+
+// NewManager returns a new codec manager whose current version is [current],
+// and which can unmarshal payloads of every version up to [current]
+func NewManager(current uint16, maxSize, maxSliceLen int) Manager {
+	m := &manager{
+		current: current,
+		codecs:  make([]codec, int(current)+1),
+	}
+	for version := range m.codecs {
+		m.codecs[version] = newVersion(uint16(version), maxSize, maxSliceLen)
+	}
+	return m
+}
+
+// NewDefaultManager returns a new codec manager whose current version is
+// [current] with reasonable default values
+func NewDefaultManager(current uint16) Manager {
+	return NewManager(current, defaultMaxSize, defaultMaxSliceLength)
+}
+
+func (m *manager) CurrentVersion() uint16 { return m.current }
+
+func (m *manager) RegisterType(val interface{}) error {
+	for _, c := range m.codecs {
+		if err := c.RegisterType(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manager) Marshal(value interface{}) ([]byte, error) {
+	return m.MarshalVersion(m.current, value)
+}
+
+func (m *manager) MarshalVersion(version uint16, value interface{}) ([]byte, error) {
+	c, err := m.codec(version)
+	if err != nil {
+		return nil, err
+	}
+	return c.Marshal(value)
+}
+
+func (m *manager) Unmarshal(bytes []byte, dest interface{}) error {
+	return m.UnmarshalVersion(m.current, bytes, dest)
+}
+
+func (m *manager) UnmarshalVersion(version uint16, bytes []byte, dest interface{}) error {
+	c, err := m.codec(version)
+	if err != nil {
+		return err
+	}
+	return c.Unmarshal(bytes, dest)
+}
+
+func (m *manager) VersionCodec(version uint16) (Codec, error) { return m.codec(version) }
+
+func (m *manager) codec(version uint16) (codec, error) {
+	if int(version) >= len(m.codecs) {
+		return codec{}, fmt.Errorf("%w %d, the current version is %d", errUnknownVersion, version, m.current)
+	}
+	return m.codecs[version], nil
+}
+
+// RegisteredTypes returns the types registered with the current version, in
+// the order of their type IDs
+func (m *manager) RegisteredTypes() []reflect.Type { return m.codecs[m.current].RegisteredTypes() }
@@ -0,0 +1,203 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/gecko/database/memdb"
+)
+
+// Account is the format of an account in codec version 0
+type Account struct {
+	Balance uint64 `serialize:"true"`
+}
+
+// AccountV1 is the format of an account after codec version 1 added a nonce
+type AccountV1 struct {
+	Balance uint64 `serialize:"true"`
+	Nonce   uint32 `serialize:"true" since:"1"`
+}
+
+// Output is the format of an output in codec version 0. It starts with an ID,
+// so its bytes can start with anything.
+type Output struct {
+	TxID   [32]byte `serialize:"true"`
+	Amount uint64   `serialize:"true"`
+}
+
+// OutputV1 is the format of an output after codec version 1 added a locktime
+type OutputV1 struct {
+	TxID     [32]byte `serialize:"true"`
+	Amount   uint64   `serialize:"true"`
+	Locktime uint64   `serialize:"true" since:"1"`
+}
+
+func TestManagerVersionFormats(t *testing.T) {
+	m := NewDefaultManager(1)
+	account := &AccountV1{Balance: 1, Nonce: 2}
+
+	// Version 0 payloads have the format they had before versioning
+	unversioned, err := NewDefault().Marshal(&Account{Balance: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v0Bytes, err := m.MarshalVersion(0, account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v0Bytes, unversioned) {
+		t.Fatalf("Returned:\n0x%x\nExpected:\n0x%x", v0Bytes, unversioned)
+	}
+
+	// Payloads don't carry their version
+	v1Bytes, err := m.Marshal(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, // balance
+		0x00, 0x00, 0x00, 0x02, // nonce
+	}
+	if !bytes.Equal(v1Bytes, expected) {
+		t.Fatalf("Returned:\n0x%x\nExpected:\n0x%x", v1Bytes, expected)
+	}
+
+	for version, b := range [][]byte{v0Bytes, v1Bytes} {
+		parsed := AccountV1{}
+		if err := m.UnmarshalVersion(uint16(version), b, &parsed); err != nil {
+			t.Fatal(err)
+		}
+		if parsed.Balance != 1 {
+			t.Fatalf("Version %d unmarshalled a balance of %d, expected 1", version, parsed.Balance)
+		}
+	}
+}
+
+func TestManagerDecodesOldVersions(t *testing.T) {
+	// An output stored before the bump, whose ID starts like a version 1
+	// prefix would have
+	old := NewDefaultManager(0)
+	output := Output{TxID: [32]byte{0x00, 0x01, 0xff}, Amount: 5}
+	oldBytes, err := old.Marshal(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := memdb.New()
+	if err := db.Put([]byte("output"), oldBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	// The database doesn't record a version, so its values have version 0
+	m := NewDefaultManager(1)
+	version, err := DatabaseVersion(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 0 {
+		t.Fatalf("Database should have version 0, has %d", version)
+	}
+	if err := VerifyDatabaseVersion(db, m); !errors.Is(err, errDatabaseVersion) {
+		t.Fatalf("Should have failed with %s, got %v", errDatabaseVersion, err)
+	}
+
+	stored, err := db.Get([]byte("output"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed := OutputV1{}
+	if err := m.UnmarshalVersion(version, stored, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.TxID != output.TxID || parsed.Amount != 5 || parsed.Locktime != 0 {
+		t.Fatalf("Unmarshalled %+v from a version 0 payload", parsed)
+	}
+
+	// Re-marshalling with version 0 reproduces the original payload
+	if b, err := m.MarshalVersion(0, &parsed); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(b, oldBytes) {
+		t.Fatalf("Version 0 payload changed from\n0x%x\nto\n0x%x", oldBytes, b)
+	}
+
+	// Migrate the database to version 1
+	parsed.Locktime = 7
+	newBytes, err := m.Marshal(&parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newBytes) != len(oldBytes)+8 {
+		t.Fatalf("Version 1 payload should include the locktime, has length %d", len(newBytes))
+	}
+	if err := db.Put([]byte("output"), newBytes); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetDatabaseVersion(db, m.CurrentVersion()); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyDatabaseVersion(db, m); err != nil {
+		t.Fatal(err)
+	}
+
+	migrated := OutputV1{}
+	if err := m.Unmarshal(newBytes, &migrated); err != nil {
+		t.Fatal(err)
+	}
+	if migrated != parsed {
+		t.Fatalf("Unmarshalled %+v, expected %+v", migrated, parsed)
+	}
+}
+
+func TestManagerRegisterType(t *testing.T) {
+	m := NewDefaultManager(1)
+	if err := m.RegisterType(&MyInnerStruct{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var foo Foo = &MyInnerStruct{Str: "hi"}
+	for version := uint16(0); version <= 1; version++ {
+		bytes, err := m.MarshalVersion(version, &foo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var parsed Foo
+		if err := m.UnmarshalVersion(version, bytes, &parsed); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(parsed, foo) {
+			t.Fatalf("Version %d unmarshalled %+v, expected %+v", version, parsed, foo)
+		}
+	}
+
+	if err := m.RegisterType(&MyInnerStruct{}); err == nil {
+		t.Fatalf("Should have failed to register a type twice")
+	}
+}
+
+func TestManagerVersionErrors(t *testing.T) {
+	m := NewDefaultManager(1)
+	if _, err := m.MarshalVersion(2, &Account{}); !errors.Is(err, errUnknownVersion) {
+		t.Fatalf("Should have failed with %s, got %v", errUnknownVersion, err)
+	}
+	if err := m.UnmarshalVersion(2, make([]byte, 8), &Account{}); !errors.Is(err, errUnknownVersion) {
+		t.Fatalf("Should have failed with %s, got %v", errUnknownVersion, err)
+	}
+}
+
+func TestBadSinceTag(t *testing.T) {
+	type badTag struct {
+		Field uint32 `serialize:"true" since:"first"`
+	}
+
+	c := NewDefault()
+	if _, err := c.Marshal(&badTag{}); !errors.Is(err, errBadSinceTag) {
+		t.Fatalf("Should have failed with %s, got %v", errBadSinceTag, err)
+	}
+	if err := c.Unmarshal([]byte{0, 0, 0, 0}, &badTag{}); !errors.Is(err, errBadSinceTag) {
+		t.Fatalf("Should have failed with %s, got %v", errBadSinceTag, err)
+	}
+}
@@ -6,6 +6,7 @@ package state
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/gecko/snow/choices"
@@ -85,6 +86,8 @@ type state struct {
 	// Keys:   Type ID
 	// Values: Cache that stores uniqueIDs for values that were put with that type ID
 	//         (Saves us from having to re-compute uniqueIDs)
+	// Guarded by [uniqueIDLock] so reads may happen concurrently
+	uniqueIDLock   sync.Mutex
 	uniqueIDCaches map[uint64]*cache.LRU
 }
 
@@ -208,6 +211,9 @@ func (s *state) GetTime(db database.Database, key ids.ID) (time.Time, error) {
 
 // Prefix [ID] with [typeID] to prevent key collisions in the database
 func (s *state) uniqueID(ID ids.ID, typeID uint64) ids.ID {
+	s.uniqueIDLock.Lock()
+	defer s.uniqueIDLock.Unlock()
+
 	uIDCache, cacheExists := s.uniqueIDCaches[typeID]
 	if cacheExists {
 		if uID, uIDExists := uIDCache.Get(ID); uIDExists { // Get the uniqueID associated with [typeID] and [ID]
@@ -0,0 +1,22 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/vms/components/codec/codecgen"
+)
+
+func TestGeneratedCodec(t *testing.T) {
+	types, err := codecgen.RegisteredTypes(Codec, "github.com/ava-labs/gecko/vms/platformvm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range types {
+		if err := codecgen.CheckEquivalence(Codec, value, 100, 0); err != nil {
+			t.Fatalf("%T: %s", value, err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build ignore
+// +build ignore
+
+// This program generates codec_gen.go. If a change to a type stops the
+// package from compiling, delete codec_gen.go before running it.
+package main
+
+import (
+	"log"
+
+	"github.com/ava-labs/gecko/vms/components/codec/codecgen"
+	"github.com/ava-labs/gecko/vms/platformvm"
+)
+
+func main() {
+	// Unexported types are only reachable through the codec
+	types, err := codecgen.RegisteredTypes(platformvm.Codec, "github.com/ava-labs/gecko/vms/platformvm")
+	if err != nil {
+		log.Fatal(err)
+	}
+	codecgen.Main("codec_gen.go", types...)
+}
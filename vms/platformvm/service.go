@@ -37,6 +37,7 @@ var (
 	errDSCantValidate        = errors.New("new blockchain can't be validated by default Subnet")
 	errNilSigner             = errors.New("nil ShortID 'signer' is not valid")
 	errNilTo                 = errors.New("nil ShortID 'to' is not valid")
+	errUnsignableTx          = errors.New("transaction can't be signed with Sign or AttachSignature")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -138,7 +139,15 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 		args.SubnetID = DefaultSubnetID
 	}
 
-	validators, err := service.vm.getCurrentValidators(service.vm.DB, args.SubnetID)
+	// GetCurrentValidators is served without the context lock, so it reads from
+	// a snapshot of the state
+	db, err := service.vm.snapshotDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	validators, err := service.vm.getCurrentValidators(db, args.SubnetID)
 	if err != nil {
 		return fmt.Errorf("couldn't get validators of subnet with ID %s. Does it exist?", args.SubnetID)
 	}
@@ -187,7 +196,15 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 		args.SubnetID = DefaultSubnetID
 	}
 
-	validators, err := service.vm.getPendingValidators(service.vm.DB, args.SubnetID)
+	// GetPendingValidators is served without the context lock, so it reads from
+	// a snapshot of the state
+	db, err := service.vm.snapshotDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	validators, err := service.vm.getPendingValidators(db, args.SubnetID)
 	if err != nil {
 		return fmt.Errorf("couldn't get validators of subnet with ID %s. Does it exist?", args.SubnetID)
 	}
@@ -266,8 +283,9 @@ func (service *Service) SampleValidators(_ *http.Request, args *SampleValidators
 
 // GetAccountArgs are the arguments for calling GetAccount
 type GetAccountArgs struct {
-	// Address of the account we want the information about
-	Address ids.ShortID `json:"address"`
+	// Address of the account we want the information about. Either a Bech32
+	// address or a legacy CB58 account ID.
+	Address string `json:"address"`
 }
 
 // GetAccountReply is the response from calling GetAccount
@@ -279,11 +297,16 @@ type GetAccountReply struct {
 
 // GetAccount details given account ID
 func (service *Service) GetAccount(_ *http.Request, args *GetAccountArgs, reply *GetAccountReply) error {
-	account, err := service.vm.getAccount(service.vm.DB, args.Address)
+	address, err := service.parseAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address: %w", err)
+	}
+
+	account, err := service.vm.getAccount(service.vm.DB, address)
 	if err != nil && err != database.ErrNotFound {
 		return fmt.Errorf("couldn't get account: %w", err)
 	} else if err == database.ErrNotFound {
-		account = newAccount(args.Address, 0, 0)
+		account = newAccount(address, 0, 0)
 	}
 
 	reply.Address = account.Address
@@ -383,13 +406,15 @@ func (service *Service) CreateAccount(_ *http.Request, args *CreateAccountArgs,
 
 	// private key that controls the new account
 	var privKey *crypto.PrivateKeySECP256K1R
-	// If no private key supplied in args, create a new one
+	// If no private key supplied in args, create a new one. If the user holds
+	// a mnemonic, the new key is the next key derived from it.
 	if args.PrivateKey == "" {
-		privKeyInt, err := service.vm.factory.NewPrivateKey() // The private key that controls the new account
-		if err != nil {                                       // The account ID is [private key].PublicKey().Address()
+		// The private key that controls the new account
+		// The account ID is [private key].PublicKey().Address()
+		privKey, err = service.vm.Ctx.Keystore.NewKey(args.Username, args.Password)
+		if err != nil {
 			return errors.New("problem generating private key")
 		}
-		privKey = privKeyInt.(*crypto.PrivateKeySECP256K1R)
 	} else { // parse provided private key
 		byteFormatter := formatting.CB58{}
 		err := byteFormatter.FromString(args.PrivateKey)
@@ -429,7 +454,12 @@ type CreateTxResponse struct {
 
 // AddDefaultSubnetValidatorArgs are the arguments to AddDefaultSubnetValidator
 type AddDefaultSubnetValidatorArgs struct {
-	APIDefaultSubnetValidator
+	APIValidator
+
+	// Account the staked $AVA and reward are sent to. Either a Bech32 address
+	// or a legacy CB58 account ID.
+	Destination       string      `json:"destination"`
+	DelegationFeeRate json.Uint32 `json:"delegationFeeRate"`
 
 	// Next nonce of the sender
 	PayerNonce json.Uint64 `json:"payerNonce"`
@@ -449,6 +479,11 @@ func (service *Service) AddDefaultSubnetValidator(_ *http.Request, args *AddDefa
 		return fmt.Errorf("start time must be in the future")
 	}
 
+	destination, err := service.parseAddress(args.Destination)
+	if err != nil {
+		return fmt.Errorf("couldn't parse destination: %w", err)
+	}
+
 	// Create the transaction
 	tx := addDefaultSubnetValidatorTx{UnsignedAddDefaultSubnetValidatorTx: UnsignedAddDefaultSubnetValidatorTx{
 		DurationValidator: DurationValidator{
@@ -460,7 +495,7 @@ func (service *Service) AddDefaultSubnetValidator(_ *http.Request, args *AddDefa
 			End:   uint64(args.EndTime),
 		},
 		Nonce:       uint64(args.PayerNonce),
-		Destination: args.Destination,
+		Destination: destination,
 		NetworkID:   service.vm.Ctx.NetworkID,
 		Shares:      uint32(args.DelegationFeeRate),
 	}}
@@ -478,7 +513,9 @@ func (service *Service) AddDefaultSubnetValidator(_ *http.Request, args *AddDefa
 type AddDefaultSubnetDelegatorArgs struct {
 	APIValidator
 
-	Destination ids.ShortID `json:"destination"`
+	// Account the staked $AVA is sent to. Either a Bech32 address or a legacy
+	// CB58 account ID.
+	Destination string `json:"destination"`
 
 	// Next unused nonce of the account the staked $AVA and tx fee are paid from
 	PayerNonce json.Uint64 `json:"payerNonce"`
@@ -499,6 +536,11 @@ func (service *Service) AddDefaultSubnetDelegator(_ *http.Request, args *AddDefa
 		return fmt.Errorf("start time must be in the future")
 	}
 
+	destination, err := service.parseAddress(args.Destination)
+	if err != nil {
+		return fmt.Errorf("couldn't parse destination: %w", err)
+	}
+
 	// Create the transaction
 	tx := addDefaultSubnetDelegatorTx{UnsignedAddDefaultSubnetDelegatorTx: UnsignedAddDefaultSubnetDelegatorTx{
 		DurationValidator: DurationValidator{
@@ -511,7 +553,7 @@ func (service *Service) AddDefaultSubnetDelegator(_ *http.Request, args *AddDefa
 		},
 		NetworkID:   service.vm.Ctx.NetworkID,
 		Nonce:       uint64(args.PayerNonce),
-		Destination: args.Destination,
+		Destination: destination,
 	}}
 
 	txBytes, err := Codec.Marshal(genericTx{Tx: &tx})
@@ -572,8 +614,12 @@ func (service *Service) AddNonDefaultSubnetValidator(_ *http.Request, args *AddN
 
 // CreateSubnetArgs are the arguments to CreateSubnet
 type CreateSubnetArgs struct {
-	// The ID member of APISubnet is ignored
-	APISubnet
+	// Each element of [ControlKeys] is the address of a public key, either as
+	// a Bech32 address or a legacy CB58 ID. A transaction to add a validator
+	// to this subnet requires signatures from [Threshold] of these keys to be
+	// valid.
+	ControlKeys []string    `json:"controlKeys"`
+	Threshold   json.Uint16 `json:"threshold"`
 
 	// Nonce of the account that pays the transaction fee
 	PayerNonce json.Uint64 `json:"payerNonce"`
@@ -589,12 +635,21 @@ func (service *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, re
 		return fmt.Errorf("sender's next nonce not specified")
 	}
 
+	controlKeys := make([]ids.ShortID, len(args.ControlKeys))
+	for i, controlKey := range args.ControlKeys {
+		addr, err := service.parseAddress(controlKey)
+		if err != nil {
+			return fmt.Errorf("couldn't parse control key %q: %w", controlKey, err)
+		}
+		controlKeys[i] = addr
+	}
+
 	// Create the transaction
 	tx := CreateSubnetTx{
 		UnsignedCreateSubnetTx: UnsignedCreateSubnetTx{
 			NetworkID:   service.vm.Ctx.NetworkID,
 			Nonce:       uint64(args.PayerNonce),
-			ControlKeys: args.ControlKeys,
+			ControlKeys: controlKeys,
 			Threshold:   uint16(args.Threshold),
 		},
 		key:   nil,
@@ -613,8 +668,9 @@ func (service *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, re
 
 // ExportAVAArgs are the arguments to ExportAVA
 type ExportAVAArgs struct {
-	// X-Chain address (without prepended X-) that will receive the exported AVA
-	To ids.ShortID `json:"to"`
+	// X-Chain address that will receive the exported AVA. Either a Bech32
+	// address or a legacy CB58 address without the prepended X-.
+	To string `json:"to"`
 
 	// Nonce of the account that pays the transaction fee and provides the export AVA
 	PayerNonce json.Uint64 `json:"payerNonce"`
@@ -637,6 +693,11 @@ func (service *Service) ExportAVA(_ *http.Request, args *ExportAVAArgs, response
 		return fmt.Errorf("amount must be >0")
 	}
 
+	to, err := ava.ParseShortAddress(service.vm.Ctx, service.vm.avm, args.To)
+	if err != nil {
+		return fmt.Errorf("couldn't parse to address: %w", err)
+	}
+
 	// Create the transaction
 	tx := ExportTx{UnsignedExportTx: UnsignedExportTx{
 		NetworkID: service.vm.Ctx.NetworkID,
@@ -647,7 +708,7 @@ func (service *Service) ExportAVA(_ *http.Request, args *ExportAVAArgs, response
 				Amt: uint64(args.Amount),
 				OutputOwners: secp256k1fx.OutputOwners{
 					Threshold: 1,
-					Addrs:     []ids.ShortID{args.To},
+					Addrs:     []ids.ShortID{to},
 				},
 			},
 		}},
@@ -674,8 +735,9 @@ type SignArgs struct {
 	// Must be the output of AddDefaultSubnetValidator
 	Tx formatting.CB58 `json:"tx"`
 
-	// The address of the key signing the bytes
-	Signer ids.ShortID `json:"signer"`
+	// The address of the key signing the bytes. Either a Bech32 address or a
+	// legacy CB58 ID.
+	Signer string `json:"signer"`
 
 	// User that controls Signer
 	Username string `json:"username"`
@@ -692,9 +754,13 @@ type SignResponse struct {
 func (service *Service) Sign(_ *http.Request, args *SignArgs, reply *SignResponse) error {
 	service.vm.Ctx.Log.Debug("sign called")
 
-	if args.Signer.IsZero() {
+	if args.Signer == "" {
 		return errNilSigner
 	}
+	signer, err := service.parseAddress(args.Signer)
+	if err != nil {
+		return fmt.Errorf("couldn't parse signer: %w", err)
+	}
 
 	// Get the key of the Signer
 	db, err := service.vm.Ctx.Keystore.GetDatabase(args.Username, args.Password)
@@ -703,11 +769,11 @@ func (service *Service) Sign(_ *http.Request, args *SignArgs, reply *SignRespons
 	}
 	user := user{db: db}
 
-	key, err := user.getKey(args.Signer) // Key of [args.Signer]
+	key, err := user.getKey(signer) // Key of [signer]
 	if err != nil {
 		return errDB
 	}
-	if !bytes.Equal(key.PublicKey().Address().Bytes(), args.Signer.Bytes()) { // sanity check
+	if !bytes.Equal(key.PublicKey().Address().Bytes(), signer.Bytes()) { // sanity check
 		return errors.New("got unexpected key from database")
 	}
 
@@ -716,23 +782,16 @@ func (service *Service) Sign(_ *http.Request, args *SignArgs, reply *SignRespons
 		return err
 	}
 
-	switch tx := genTx.Tx.(type) {
-	case *addDefaultSubnetValidatorTx:
-		genTx.Tx, err = service.signAddDefaultSubnetValidatorTx(tx, key)
-	case *addDefaultSubnetDelegatorTx:
-		genTx.Tx, err = service.signAddDefaultSubnetDelegatorTx(tx, key)
-	case *addNonDefaultSubnetValidatorTx:
-		genTx.Tx, err = service.signAddNonDefaultSubnetValidatorTx(tx, key)
-	case *CreateSubnetTx:
-		genTx.Tx, err = service.signCreateSubnetTx(tx, key)
-	case *CreateChainTx:
-		genTx.Tx, err = service.signCreateChainTx(tx, key)
-	case *ExportTx:
-		genTx.Tx, err = service.signExportTx(tx, key)
-	default:
-		err = errors.New("Could not parse given tx")
+	// TODO: Should we check if tx is already signed?
+	unsignedTxBytes, err := unsignedBytes(genTx.Tx)
+	if err != nil {
+		return err
 	}
+	sig, err := key.Sign(unsignedTxBytes)
 	if err != nil {
+		return errors.New("error while signing")
+	}
+	if err := service.attachSignature(genTx.Tx, signer, sig); err != nil {
 		return err
 	}
 
@@ -740,155 +799,173 @@ func (service *Service) Sign(_ *http.Request, args *SignArgs, reply *SignRespons
 	return err
 }
 
-// Sign [unsigned] with [key]
-func (service *Service) signAddDefaultSubnetValidatorTx(tx *addDefaultSubnetValidatorTx, key *crypto.PrivateKeySECP256K1R) (*addDefaultSubnetValidatorTx, error) {
-	service.vm.Ctx.Log.Debug("signAddDefaultSubnetValidatorTx called")
-
-	// TODO: Should we check if tx is already signed?
-	unsignedIntf := interface{}(&tx.UnsignedAddDefaultSubnetValidatorTx)
-	unsignedTxBytes, err := Codec.Marshal(&unsignedIntf)
-	if err != nil {
-		return nil, fmt.Errorf("error serializing unsigned tx: %w", err)
-	}
-
-	sig, err := key.Sign(unsignedTxBytes)
-	if err != nil {
-		return nil, errors.New("error while signing")
-	}
-	if len(sig) != crypto.SECP256K1RSigLen {
-		return nil, fmt.Errorf("expected signature to be length %d but was length %d", crypto.SECP256K1RSigLen, len(sig))
-	}
-	copy(tx.Sig[:], sig)
+// GetUnsignedTxArgs are the arguments to GetUnsignedTx
+type GetUnsignedTxArgs struct {
+	// The transaction to sign
+	Tx formatting.CB58 `json:"tx"`
+}
 
-	return tx, nil
+// GetUnsignedTxReply is the response from GetUnsignedTx
+type GetUnsignedTxReply struct {
+	// The bytes that are signed to sign the transaction
+	UnsignedTx formatting.CB58 `json:"unsignedTx"`
 }
 
-// Sign [unsigned] with [key]
-func (service *Service) signAddDefaultSubnetDelegatorTx(tx *addDefaultSubnetDelegatorTx, key *crypto.PrivateKeySECP256K1R) (*addDefaultSubnetDelegatorTx, error) {
-	service.vm.Ctx.Log.Debug("signAddDefaultSubnetValidatorTx called")
+// GetUnsignedTx returns the bytes that a signer of [args.Tx] signs, so that
+// the transaction can be signed offline, without the key being in the
+// keystore. The signer signs the SHA256 hash of [UnsignedTx], and the
+// signature is added to the transaction with AttachSignature.
+func (service *Service) GetUnsignedTx(_ *http.Request, args *GetUnsignedTxArgs, reply *GetUnsignedTxReply) error {
+	service.vm.Ctx.Log.Debug("platform.getUnsignedTx called")
 
-	// TODO: Should we check if tx is already signed?
-	unsignedIntf := interface{}(&tx.UnsignedAddDefaultSubnetDelegatorTx)
-	unsignedTxBytes, err := Codec.Marshal(&unsignedIntf)
-	if err != nil {
-		return nil, fmt.Errorf("error serializing unsigned tx: %w", err)
+	genTx := genericTx{}
+	if err := Codec.Unmarshal(args.Tx.Bytes, &genTx); err != nil {
+		return err
 	}
-
-	sig, err := key.Sign(unsignedTxBytes)
+	unsignedTxBytes, err := unsignedBytes(genTx.Tx)
 	if err != nil {
-		return nil, errors.New("error while signing")
-	}
-	if len(sig) != crypto.SECP256K1RSigLen {
-		return nil, fmt.Errorf("expected signature to be length %d but was length %d", crypto.SECP256K1RSigLen, len(sig))
+		return err
 	}
-	copy(tx.Sig[:], sig)
+	reply.UnsignedTx.Bytes = unsignedTxBytes
+	return nil
+}
 
-	return tx, nil
+// AttachSignatureArgs are the arguments to AttachSignature
+type AttachSignatureArgs struct {
+	// The transaction to add the signature to
+	Tx formatting.CB58 `json:"tx"`
+
+	// The signature of the transaction's unsigned bytes, as returned by
+	// GetUnsignedTx
+	Signature formatting.CB58 `json:"signature"`
 }
 
-// Sign [xt] with [key]
-func (service *Service) signCreateSubnetTx(tx *CreateSubnetTx, key *crypto.PrivateKeySECP256K1R) (*CreateSubnetTx, error) {
-	service.vm.Ctx.Log.Debug("signAddDefaultSubnetValidatorTx called")
+// AttachSignature adds a signature that was produced outside of the node to
+// [args.Tx]. The signer is recovered from the signature, and the signature is
+// placed the same way Sign would place a signature of the signer's key.
+func (service *Service) AttachSignature(_ *http.Request, args *AttachSignatureArgs, reply *SignResponse) error {
+	service.vm.Ctx.Log.Debug("platform.attachSignature called")
 
-	// TODO: Should we check if tx is already signed?
-	unsignedIntf := interface{}(&tx.UnsignedCreateSubnetTx)
-	unsignedTxBytes, err := Codec.Marshal(&unsignedIntf)
+	genTx := genericTx{}
+	if err := Codec.Unmarshal(args.Tx.Bytes, &genTx); err != nil {
+		return err
+	}
+	unsignedTxBytes, err := unsignedBytes(genTx.Tx)
 	if err != nil {
-		return nil, fmt.Errorf("error serializing unsigned tx: %w", err)
+		return err
 	}
-
-	sig, err := key.Sign(unsignedTxBytes)
+	key, err := service.vm.factory.RecoverPublicKey(unsignedTxBytes, args.Signature.Bytes)
 	if err != nil {
-		return nil, errors.New("error while signing")
+		return fmt.Errorf("couldn't recover signer from signature: %w", err)
 	}
-	if len(sig) != crypto.SECP256K1RSigLen {
-		return nil, fmt.Errorf("expected signature to be length %d but was length %d", crypto.SECP256K1RSigLen, len(sig))
+	if err := service.attachSignature(genTx.Tx, key.Address(), args.Signature.Bytes); err != nil {
+		return err
 	}
-	copy(tx.Sig[:], sig)
 
-	return tx, nil
+	reply.Tx.Bytes, err = Codec.Marshal(genTx)
+	return err
 }
 
-// Sign [tx] with [key]
-func (service *Service) signExportTx(tx *ExportTx, key *crypto.PrivateKeySECP256K1R) (*ExportTx, error) {
-	service.vm.Ctx.Log.Debug("platform.signAddDefaultSubnetValidatorTx called")
-
-	// TODO: Should we check if tx is already signed?
-	unsignedIntf := interface{}(&tx.UnsignedExportTx)
+// unsignedBytes returns the byte representation of the unsigned part of [tx],
+// which is what signers of [tx] sign
+func unsignedBytes(tx interface{}) ([]byte, error) {
+	var unsignedIntf interface{}
+	switch tx := tx.(type) {
+	case *addDefaultSubnetValidatorTx:
+		unsignedIntf = &tx.UnsignedAddDefaultSubnetValidatorTx
+	case *addDefaultSubnetDelegatorTx:
+		unsignedIntf = &tx.UnsignedAddDefaultSubnetDelegatorTx
+	case *addNonDefaultSubnetValidatorTx:
+		unsignedIntf = &tx.UnsignedAddNonDefaultSubnetValidatorTx
+	case *CreateSubnetTx:
+		unsignedIntf = &tx.UnsignedCreateSubnetTx
+	case *CreateChainTx:
+		unsignedIntf = &tx.UnsignedCreateChainTx
+	case *ExportTx:
+		unsignedIntf = &tx.UnsignedExportTx
+	default:
+		return nil, errUnsignableTx
+	}
 	unsignedTxBytes, err := Codec.Marshal(&unsignedIntf)
 	if err != nil {
 		return nil, fmt.Errorf("error serializing unsigned tx: %w", err)
 	}
+	return unsignedTxBytes, nil
+}
 
-	sig, err := key.Sign(unsignedTxBytes)
-	if err != nil {
-		return nil, errors.New("error while signing")
-	}
+// attachSignature adds [sig], the signature of [signer] over the unsigned
+// bytes of [tx], to [tx]
+func (service *Service) attachSignature(tx interface{}, signer ids.ShortID, sig []byte) error {
 	if len(sig) != crypto.SECP256K1RSigLen {
-		return nil, fmt.Errorf("expected signature to be length %d but was length %d", crypto.SECP256K1RSigLen, len(sig))
+		return fmt.Errorf("expected signature to be length %d but was length %d", crypto.SECP256K1RSigLen, len(sig))
 	}
-	copy(tx.Sig[:], sig)
-
-	return tx, nil
-}
-
-// Signs an unsigned or partially signed addNonDefaultSubnetValidatorTx with [key]
-// If [key] is a control key for the subnet and there is an empty spot in tx.ControlSigs, signs there
-// If [key] is a control key for the subnet and there is no empty spot in tx.ControlSigs, signs as payer
-// If [key] is not a control key, sign as payer (account controlled by [key] pays the tx fee)
-// Sorts tx.ControlSigs before returning
-// Assumes each element of tx.ControlSigs is actually a signature, not just empty bytes
-func (service *Service) signAddNonDefaultSubnetValidatorTx(tx *addNonDefaultSubnetValidatorTx, key *crypto.PrivateKeySECP256K1R) (*addNonDefaultSubnetValidatorTx, error) {
-	service.vm.Ctx.Log.Debug("signAddNonDefaultSubnetValidatorTx called")
 
-	// Compute the byte repr. of the unsigned tx and the signature of [key] over it
-	unsignedIntf := interface{}(&tx.UnsignedAddNonDefaultSubnetValidatorTx)
-	unsignedTxBytes, err := Codec.Marshal(&unsignedIntf)
-	if err != nil {
-		return nil, fmt.Errorf("error serializing unsigned tx: %w", err)
-	}
-	sig, err := key.Sign(unsignedTxBytes)
-	if err != nil {
-		return nil, errors.New("error while signing")
-	}
-	if len(sig) != crypto.SECP256K1RSigLen {
-		return nil, fmt.Errorf("expected signature to be length %d but was length %d", crypto.SECP256K1RSigLen, len(sig))
+	var err error
+	switch tx := tx.(type) {
+	case *addDefaultSubnetValidatorTx:
+		copy(tx.Sig[:], sig)
+	case *addDefaultSubnetDelegatorTx:
+		copy(tx.Sig[:], sig)
+	case *CreateSubnetTx:
+		copy(tx.Sig[:], sig)
+	case *ExportTx:
+		copy(tx.Sig[:], sig)
+	case *addNonDefaultSubnetValidatorTx:
+		tx.ControlSigs, err = service.attachControlSignature(tx.SubnetID(), tx.ControlSigs, &tx.PayerSig, signer, sig)
+	case *CreateChainTx:
+		tx.ControlSigs, err = service.attachControlSignature(tx.SubnetID, tx.ControlSigs, &tx.PayerSig, signer, sig)
+	default:
+		err = errUnsignableTx
 	}
+	return err
+}
 
+// Adds [sig] to the signatures of a transaction that must be signed by the
+// control keys of [subnetID] and by the payer of the tx fee
+// If [signer] is a control key for the subnet and there is an empty spot in [controlSigs], signs there
+// If [signer] is a control key for the subnet and there is no empty spot in [controlSigs], signs as payer
+// If [signer] is not a control key, sign as payer (account controlled by [signer] pays the tx fee)
+// Sorts the control signatures before returning them
+// Assumes each element of [controlSigs] is actually a signature, not just empty bytes
+func (service *Service) attachControlSignature(
+	subnetID ids.ID,
+	controlSigs [][crypto.SECP256K1RSigLen]byte,
+	payerSig *[crypto.SECP256K1RSigLen]byte,
+	signer ids.ShortID,
+	sig []byte,
+) ([][crypto.SECP256K1RSigLen]byte, error) {
 	// Get information about the subnet
-	subnet, err := service.vm.getSubnet(service.vm.DB, tx.SubnetID())
+	subnet, err := service.vm.getSubnet(service.vm.DB, subnetID)
 	if err != nil {
 		return nil, fmt.Errorf("problem getting subnet information: %w", err)
 	}
 
-	// Find the location at which [key] should put its signature.
-	// If [key] is a control key for this subnet and there is an empty spot in tx.ControlSigs, sign there
-	// If [key] is a control key for this subnet and there is no empty spot in tx.ControlSigs, sign as payer
-	// If [key] is not a control key, sign as payer (account controlled by [key] pays the tx fee)
+	// Find the location at which [signer] should put its signature.
 	controlKeySet := ids.ShortSet{}
 	controlKeySet.Add(subnet.ControlKeys...)
-	isControlKey := controlKeySet.Contains(key.PublicKey().Address())
+	isControlKey := controlKeySet.Contains(signer)
 
-	payerSigEmpty := tx.PayerSig == [crypto.SECP256K1RSigLen]byte{} // true if no key has signed to pay the tx fee
+	payerSigEmpty := *payerSig == [crypto.SECP256K1RSigLen]byte{} // true if no key has signed to pay the tx fee
 
-	if isControlKey && len(tx.ControlSigs) != int(subnet.Threshold) { // Sign as controlSig
-		tx.ControlSigs = append(tx.ControlSigs, [crypto.SECP256K1RSigLen]byte{})
-		copy(tx.ControlSigs[len(tx.ControlSigs)-1][:], sig)
+	if isControlKey && len(controlSigs) != int(subnet.Threshold) { // Sign as controlSig
+		controlSigs = append(controlSigs, [crypto.SECP256K1RSigLen]byte{})
+		copy(controlSigs[len(controlSigs)-1][:], sig)
 	} else if payerSigEmpty { // sign as payer
-		copy(tx.PayerSig[:], sig)
+		copy(payerSig[:], sig)
 	} else {
 		return nil, errors.New("no place for key to sign")
 	}
 
-	crypto.SortSECP2561RSigs(tx.ControlSigs)
+	crypto.SortSECP2561RSigs(controlSigs)
 
-	return tx, nil
+	return controlSigs, nil
 }
 
 // ImportAVAArgs are the arguments to ImportAVA
 type ImportAVAArgs struct {
-	// ID of the account that will receive the imported funds, and pay the transaction fee
-	To ids.ShortID `json:"to"`
+	// Account that will receive the imported funds, and pay the transaction
+	// fee. Either a Bech32 address or a legacy CB58 account ID.
+	To string `json:"to"`
 
 	// Next nonce of the sender
 	PayerNonce json.Uint64 `json:"payerNonce"`
@@ -905,12 +982,17 @@ func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, response
 	service.vm.Ctx.Log.Debug("platform.ImportAVA called")
 
 	switch {
-	case args.To.IsZero():
+	case args.To == "":
 		return errNilTo
 	case args.PayerNonce == 0:
 		return fmt.Errorf("sender's next nonce not specified")
 	}
 
+	to, err := service.parseAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("couldn't parse to address: %w", err)
+	}
+
 	// Get the key of the Signer
 	db, err := service.vm.Ctx.Keystore.GetDatabase(args.Username, args.Password)
 	if err != nil {
@@ -919,14 +1001,14 @@ func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, response
 	user := user{db: db}
 
 	kc := secp256k1fx.NewKeychain()
-	key, err := user.getKey(args.To)
+	key, err := user.getKey(to)
 	if err != nil {
 		return errDB
 	}
 	kc.Add(key)
 
 	addrSet := ids.Set{}
-	addrSet.Add(ids.NewID(hashing.ComputeHash256Array(args.To.Bytes())))
+	addrSet.Add(ids.NewID(hashing.ComputeHash256Array(to.Bytes())))
 
 	utxos, err := service.vm.GetAtomicUTXOs(addrSet)
 	if err != nil {
@@ -972,7 +1054,7 @@ func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, response
 	tx := ImportTx{UnsignedImportTx: UnsignedImportTx{
 		NetworkID: service.vm.Ctx.NetworkID,
 		Nonce:     uint64(args.PayerNonce),
-		Account:   args.To,
+		Account:   to,
 		Ins:       ins,
 	}}
 
@@ -1014,59 +1096,6 @@ func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, response
 	return nil
 }
 
-// Signs an unsigned or partially signed CreateChainTx with [key]
-// If [key] is a control key for the subnet and there is an empty spot in tx.ControlSigs, signs there
-// If [key] is a control key for the subnet and there is no empty spot in tx.ControlSigs, signs as payer
-// If [key] is not a control key, sign as payer (account controlled by [key] pays the tx fee)
-// Sorts tx.ControlSigs before returning
-// Assumes each element of tx.ControlSigs is actually a signature, not just empty bytes
-func (service *Service) signCreateChainTx(tx *CreateChainTx, key *crypto.PrivateKeySECP256K1R) (*CreateChainTx, error) {
-	service.vm.Ctx.Log.Debug("signCreateChainTx called")
-
-	// Compute the byte repr. of the unsigned tx and the signature of [key] over it
-	unsignedIntf := interface{}(&tx.UnsignedCreateChainTx)
-	unsignedTxBytes, err := Codec.Marshal(&unsignedIntf)
-	if err != nil {
-		return nil, fmt.Errorf("error serializing unsigned tx: %w", err)
-	}
-	sig, err := key.Sign(unsignedTxBytes)
-	if err != nil {
-		return nil, errors.New("error while signing")
-	}
-	if len(sig) != crypto.SECP256K1RSigLen {
-		return nil, fmt.Errorf("expected signature to be length %d but was length %d", crypto.SECP256K1RSigLen, len(sig))
-	}
-
-	// Get information about the subnet
-	subnet, err := service.vm.getSubnet(service.vm.DB, tx.SubnetID)
-	if err != nil {
-		return nil, fmt.Errorf("problem getting subnet information: %w", err)
-	}
-
-	// Find the location at which [key] should put its signature.
-	// If [key] is a control key for this subnet and there is an empty spot in tx.ControlSigs, sign there
-	// If [key] is a control key for this subnet and there is no empty spot in tx.ControlSigs, sign as payer
-	// If [key] is not a control key, sign as payer (account controlled by [key] pays the tx fee)
-	controlKeySet := ids.ShortSet{}
-	controlKeySet.Add(subnet.ControlKeys...)
-	isControlKey := controlKeySet.Contains(key.PublicKey().Address())
-
-	payerSigEmpty := tx.PayerSig == [crypto.SECP256K1RSigLen]byte{} // true if no key has signed to pay the tx fee
-
-	if isControlKey && len(tx.ControlSigs) != int(subnet.Threshold) { // Sign as controlSig
-		tx.ControlSigs = append(tx.ControlSigs, [crypto.SECP256K1RSigLen]byte{})
-		copy(tx.ControlSigs[len(tx.ControlSigs)-1][:], sig)
-	} else if payerSigEmpty { // sign as payer
-		copy(tx.PayerSig[:], sig)
-	} else {
-		return nil, errors.New("no place for key to sign")
-	}
-
-	crypto.SortSECP2561RSigs(tx.ControlSigs)
-
-	return tx, nil
-}
-
 // IssueTxArgs are the arguments to IssueTx
 type IssueTxArgs struct {
 	// Tx being sent to the network
@@ -1395,3 +1424,9 @@ func (service *Service) GetBlockchains(_ *http.Request, args *struct{}, response
 	}
 	return nil
 }
+
+// parseAddress parses [addrStr] as the address of an account on this chain.
+// Both Bech32 addresses and legacy CB58 account IDs are accepted.
+func (service *Service) parseAddress(addrStr string) (ids.ShortID, error) {
+	return ava.ParseShortAddress(service.vm.Ctx, service.vm.Ctx.ChainID, addrStr)
+}
@@ -6,10 +6,15 @@ package platformvm
 import (
 	"encoding/json"
 	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/vms/components/ava"
 )
 
 func TestAddDefaultSubnetValidator(t *testing.T) {
-	expectedJSONString := `{"startTime":"0","endTime":"0","id":null,"destination":null,"delegationFeeRate":"0","payerNonce":"0"}`
+	expectedJSONString := `{"startTime":"0","endTime":"0","id":null,"destination":"","delegationFeeRate":"0","payerNonce":"0"}`
 	args := AddDefaultSubnetValidatorArgs{}
 	bytes, err := json.Marshal(&args)
 	if err != nil {
@@ -32,3 +37,183 @@ func TestCreateBlockchainArgsParsing(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestGetAccountAddressFormats(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	service := Service{vm: vm}
+
+	addr := keys[0].PublicKey().Address()
+	bech32, err := ava.FormatAddress(vm.Ctx, vm.Ctx.ChainID, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, addrStr := range []string{addr.String(), bech32} {
+		reply := GetAccountReply{}
+		if err := service.GetAccount(nil, &GetAccountArgs{Address: addrStr}, &reply); err != nil {
+			t.Fatalf("Getting account %s errored: %s", addrStr, err)
+		}
+		if !reply.Address.Equals(addr) {
+			t.Fatalf("Got account %s ; Expected: %s", reply.Address, addr)
+		}
+		if uint64(reply.Balance) != defaultBalance {
+			t.Fatalf("Got balance %d ; Expected: %d", reply.Balance, defaultBalance)
+		}
+	}
+
+	// Addresses of other networks are rejected
+	wrongNetwork, err := formatting.FormatAddress(vm.Ctx.ChainID.String(), formatting.MainnetHRP, addr.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.GetAccount(nil, &GetAccountArgs{Address: wrongNetwork}, &GetAccountReply{}); err == nil {
+		t.Fatalf("Getting an account of another network should have errored")
+	}
+}
+
+func TestAttachSignature(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	service := Service{vm: vm}
+
+	// An unsigned transaction, as returned by CreateSubnet
+	unsignedTx := &CreateSubnetTx{UnsignedCreateSubnetTx: UnsignedCreateSubnetTx{
+		NetworkID:   vm.Ctx.NetworkID,
+		Nonce:       1,
+		ControlKeys: []ids.ShortID{keys[1].PublicKey().Address()},
+		Threshold:   1,
+	}}
+	txBytes, err := Codec.Marshal(genericTx{Tx: unsignedTx})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsignedReply := GetUnsignedTxReply{}
+	if err := service.GetUnsignedTx(nil, &GetUnsignedTxArgs{Tx: formatting.CB58{Bytes: txBytes}}, &unsignedReply); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sign without the keystore
+	sig, err := keys[0].Sign(unsignedReply.UnsignedTx.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply := SignResponse{}
+	if err := service.AttachSignature(nil, &AttachSignatureArgs{
+		Tx:        formatting.CB58{Bytes: txBytes},
+		Signature: formatting.CB58{Bytes: sig},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	genTx := genericTx{}
+	if err := Codec.Unmarshal(reply.Tx.Bytes, &genTx); err != nil {
+		t.Fatal(err)
+	}
+	tx, ok := genTx.Tx.(*CreateSubnetTx)
+	if !ok {
+		t.Fatalf("Signed transaction should be a *CreateSubnetTx but is %T", genTx.Tx)
+	}
+	if err := tx.initialize(vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err != nil {
+		t.Fatal(err)
+	}
+	if signer := tx.key.Address(); !signer.Equals(keys[0].PublicKey().Address()) {
+		t.Fatalf("Transaction was signed by %s ; Expected: %s", signer, keys[0].PublicKey().Address())
+	}
+
+	// Signatures of the wrong length are rejected
+	if err := service.AttachSignature(nil, &AttachSignatureArgs{
+		Tx:        formatting.CB58{Bytes: txBytes},
+		Signature: formatting.CB58{Bytes: sig[1:]},
+	}, &SignResponse{}); err == nil {
+		t.Fatalf("Attaching a malformed signature should have errored")
+	}
+}
+
+func TestAttachControlSignature(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	service := Service{vm: vm}
+
+	unsignedTx := &addNonDefaultSubnetValidatorTx{
+		UnsignedAddNonDefaultSubnetValidatorTx: UnsignedAddNonDefaultSubnetValidatorTx{
+			SubnetValidator: SubnetValidator{
+				DurationValidator: DurationValidator{
+					Validator: Validator{
+						NodeID: keys[0].PublicKey().Address(),
+						Wght:   defaultWeight,
+					},
+					Start: uint64(defaultValidateStartTime.Unix()) + 1,
+					End:   uint64(defaultValidateEndTime.Unix()) - 1,
+				},
+				Subnet: testSubnet1.id,
+			},
+			NetworkID: vm.Ctx.NetworkID,
+			Nonce:     1,
+		},
+	}
+	txBytes, err := Codec.Marshal(genericTx{Tx: unsignedTx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsignedReply := GetUnsignedTxReply{}
+	if err := service.GetUnsignedTx(nil, &GetUnsignedTxArgs{Tx: formatting.CB58{Bytes: txBytes}}, &unsignedReply); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first signature of a control key is a control signature. Once the
+	// subnet's threshold is met, the next signature pays the tx fee.
+	signers := []*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1], testSubnet1ControlKeys[2]}
+	for _, key := range signers {
+		sig, err := key.Sign(unsignedReply.UnsignedTx.Bytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reply := SignResponse{}
+		if err := service.AttachSignature(nil, &AttachSignatureArgs{
+			Tx:        formatting.CB58{Bytes: txBytes},
+			Signature: formatting.CB58{Bytes: sig},
+		}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		txBytes = reply.Tx.Bytes
+	}
+
+	genTx := genericTx{}
+	if err := Codec.Unmarshal(txBytes, &genTx); err != nil {
+		t.Fatal(err)
+	}
+	tx, ok := genTx.Tx.(*addNonDefaultSubnetValidatorTx)
+	if !ok {
+		t.Fatalf("Signed transaction should be a *addNonDefaultSubnetValidatorTx but is %T", genTx.Tx)
+	}
+	if len(tx.ControlSigs) != int(testSubnet1.Threshold) {
+		t.Fatalf("Transaction has %d control signatures ; Expected: %d", len(tx.ControlSigs), testSubnet1.Threshold)
+	}
+	if tx.PayerSig == [crypto.SECP256K1RSigLen]byte{} {
+		t.Fatalf("Transaction should have been signed by the payer")
+	}
+	if err := tx.initialize(vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/ava-labs/gecko/chains"
 	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/snapshotdb"
 	"github.com/ava-labs/gecko/database/versiondb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
@@ -31,6 +32,8 @@ import (
 	"github.com/ava-labs/gecko/vms/secp256k1fx"
 )
 
+//go:generate go run gen_codec.go
+
 const (
 	// For putting/getting values from state
 	accountTypeID uint64 = iota
@@ -100,11 +103,16 @@ var (
 	errInvalidLastAcceptedBlock = errors.New("last accepted block must be a decision block")
 )
 
+// CodecVersion is the version of the codec that blocks and state are
+// marshalled with. State stored with an earlier version must be migrated when
+// it's increased.
+const CodecVersion = 0
+
 // Codec does serialization and deserialization
-var Codec codec.Codec
+var Codec codec.Manager
 
 func init() {
-	Codec = codec.NewDefault()
+	Codec = codec.NewDefaultManager(CodecVersion)
 
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -287,6 +295,10 @@ func (vm *VM) Initialize(
 		genesisBlock.onAcceptDB = versiondb.New(vm.DB)
 		genesisBlock.CommonBlock.Accept()
 
+		if err := codec.SetDatabaseVersion(vm.DB, CodecVersion); err != nil {
+			return err
+		}
+
 		vm.SetDBInitialized()
 
 		if err := vm.DB.Commit(); err != nil {
@@ -294,6 +306,12 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	// State stored with another codec version can't be read until it has been
+	// migrated
+	if err := codec.VerifyDatabaseVersion(vm.DB, Codec); err != nil {
+		return err
+	}
+
 	// Transactions from clients that have not yet been put into blocks
 	// and added to consensus
 	vm.unissuedEvents = &EventHeap{SortByStartTime: true}
@@ -608,11 +626,27 @@ func (vm *VM) SetPreference(blkID ids.ID) {
 // * values are API handlers
 // See API documentation for more information
 func (vm *VM) CreateHandlers() map[string]*common.HTTPHandler {
-	// Create a service with name "platform"
+	// Create a service with name "platform". Reads of the validator sets are
+	// served from snapshots, so they don't hold the context lock.
 	handler := vm.SnowmanVM.NewHandler("platform", &Service{vm: vm})
+	handler = common.NewMethodLockHandler(&vm.Ctx.Lock, handler.Handler,
+		"platform.getCurrentValidators",
+		"platform.getPendingValidators",
+	)
 	return map[string]*common.HTTPHandler{"": handler}
 }
 
+// snapshotDB returns a read-only view of the last committed state that
+// doesn't change and can be read without holding the context lock. The
+// returned database must be closed once it's no longer needed.
+func (vm *VM) snapshotDB() (*snapshotdb.Database, error) {
+	snapshot, err := vm.DB.GetDatabase().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snapshotdb.New(snapshot), nil
+}
+
 // CreateStaticHandlers implements the snowman.ChainVM interface
 func (vm *VM) CreateStaticHandlers() map[string]*common.HTTPHandler {
 	// Static service's name is platform
@@ -12,6 +12,8 @@ import (
 	"github.com/ava-labs/gecko/vms/components/verify"
 )
 
+//go:generate go run gen_codec.go
+
 var (
 	errWrongVMType         = errors.New("wrong vm type")
 	errWrongTxType         = errors.New("wrong tx type")
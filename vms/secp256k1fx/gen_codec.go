@@ -0,0 +1,25 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build ignore
+// +build ignore
+
+// This program generates codec_gen.go. If a change to a type stops the
+// package from compiling, delete codec_gen.go before running it.
+package main
+
+import (
+	"github.com/ava-labs/gecko/vms/components/codec/codecgen"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+func main() {
+	codecgen.Main(
+		"codec_gen.go",
+		&secp256k1fx.TransferInput{},
+		&secp256k1fx.TransferOutput{},
+		&secp256k1fx.MintOutput{},
+		&secp256k1fx.MintOperation{},
+		&secp256k1fx.Credential{},
+	)
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/wrappers"
 )
 
 var (
@@ -31,6 +33,11 @@ func (out *OutputOwners) Addresses() [][]byte {
 	return addrs
 }
 
+// Size implements the cache.Sizable interface
+func (out *OutputOwners) Size() int {
+	return 2*wrappers.IntLen + len(out.Addrs)*hashing.AddrLen
+}
+
 // Equals returns true if the provided owners create the same condition
 func (out *OutputOwners) Equals(other *OutputOwners) bool {
 	if out == other {
@@ -5,6 +5,8 @@ package secp256k1fx
 
 import (
 	"errors"
+
+	"github.com/ava-labs/gecko/utils/wrappers"
 )
 
 var (
@@ -22,6 +24,9 @@ type TransferOutput struct {
 // Amount returns the quantity of the asset this output consumes
 func (out *TransferOutput) Amount() uint64 { return out.Amt }
 
+// Size implements the cache.Sizable interface
+func (out *TransferOutput) Size() int { return 2*wrappers.LongLen + out.OutputOwners.Size() }
+
 // Verify ...
 func (out *TransferOutput) Verify() error {
 	switch {
@@ -62,7 +62,7 @@ func ConsensusLeader(numBlocks, numTxsPerBlock int, b *testing.B) {
 		go timeoutManager.Dispatch()
 
 		router := &router.ChainRouter{}
-		router.Initialize(logging.NoLog{}, &timeoutManager, time.Hour)
+		router.Initialize(logging.NoLog{}, &timeoutManager, time.Hour, time.Second)
 
 		// Initialize the VM
 		vm := &VM{}
@@ -190,7 +190,7 @@ func ConsensusFollower(numBlocks, numTxsPerBlock int, b *testing.B) {
 		go timeoutManager.Dispatch()
 
 		router := &router.ChainRouter{}
-		router.Initialize(logging.NoLog{}, &timeoutManager, time.Hour)
+		router.Initialize(logging.NoLog{}, &timeoutManager, time.Hour, time.Second)
 
 		wg := sync.WaitGroup{}
 		wg.Add(numBlocks)
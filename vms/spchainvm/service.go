@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/json"
+	"github.com/ava-labs/gecko/vms/components/ava"
 )
 
 // Service defines the API exposed by the payments vm
@@ -41,9 +42,10 @@ func (service *Service) IssueTx(_ *http.Request, args *IssueTxArgs, reply *Issue
 }
 
 // GetAccountArgs is the arguments for calling GetAccount
-// [Address] is the string repr. of the address we want to know the nonce and balance of
+// [Address] is the string repr. of the address we want to know the nonce and balance of.
+// It's either a Bech32 address or a legacy CB58 ID.
 type GetAccountArgs struct {
-	Address ids.ShortID `json:"address"`
+	Address string `json:"address"`
 }
 
 // GetAccountReply is the reply from calling GetAccount
@@ -56,11 +58,15 @@ type GetAccountReply struct {
 
 // GetAccount gets the nonce and balance of the account specified in [args]
 func (service *Service) GetAccount(_ *http.Request, args *GetAccountArgs, reply *GetAccountReply) error {
-	if args.Address.IsZero() {
+	address, err := ava.ParseShortAddress(service.vm.ctx, service.vm.ctx.ChainID, args.Address)
+	if err != nil {
+		return err
+	}
+	if address.IsZero() {
 		return errInvalidAddress
 	}
 
-	account := service.vm.GetAccount(service.vm.baseDB, args.Address)
+	account := service.vm.GetAccount(service.vm.baseDB, address)
 	reply.Nonce = json.Uint64(account.nonce)
 	reply.Balance = json.Uint64(account.balance)
 	return nil
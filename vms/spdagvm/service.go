@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/choices"
 	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/vms/components/ava"
 )
 
 var (
@@ -72,8 +73,9 @@ func (service *Service) GetTxStatus(r *http.Request, args *GetTxStatusArgs, repl
 }
 
 // GetUTXOsArgs are arguments for GetUTXOs
+// Each address is either a Bech32 address or a legacy CB58 ID
 type GetUTXOsArgs struct {
-	Addresses []ids.ShortID `json:"addresses"`
+	Addresses []string `json:"addresses"`
 }
 
 // GetUTXOsReply is the reply from GetUTXOs
@@ -89,12 +91,16 @@ func (service *Service) GetUTXOs(r *http.Request, args *GetUTXOsArgs, reply *Get
 	service.vm.ctx.Log.Verbo("GetUTXOs called with %s", args.Addresses)
 
 	addrSet := ids.ShortSet{}
-	for _, addr := range args.Addresses {
+	for _, addrStr := range args.Addresses {
+		addr, err := ava.ParseShortAddress(service.vm.ctx, service.vm.ctx.ChainID, addrStr)
+		if err != nil {
+			return err
+		}
 		if addr.IsZero() {
 			return errNilID
 		}
+		addrSet.Add(addr)
 	}
-	addrSet.Add(args.Addresses...)
 
 	utxos, err := service.vm.GetUTXOs(addrSet)
 	if err != nil {
@@ -44,7 +44,7 @@ type Wallet struct {
 
 // NewWallet returns a new Wallet
 func NewWallet(log logging.Logger, networkID uint32, chainID ids.ID, txFee uint64) (*Wallet, error) {
-	c := codec.NewDefault()
+	c := codec.NewDefaultManager(avm.CodecVersion)
 	errs := wrappers.Errs{}
 	errs.Add(
 		c.RegisterType(&avm.BaseTx{}),